@@ -9,11 +9,15 @@ import (
 	"table-api/internal/database"
 	"table-api/internal/entitys"
 	"table-api/internal/handler"
+	"table-api/internal/readiness"
 	"table-api/internal/repository"
 	"table-api/internal/router"
 	"table-api/internal/service"
+	"table-api/internal/visibility"
 	"table-api/pkg/logger"
+	"table-api/pkg/timezone"
 	"table-api/pkg/validator"
+	"table-api/pkg/vault"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -29,7 +33,17 @@ func main() {
 
 	logger := logger.NewLogger(cfg.Server.LoggerConsole)
 
-	db, err := database.ConnectDB(&cfg.Db)
+	// Пояс задаётся до подключения к БД: миграция дат и драйвер работают в нём
+	if err := timezone.Configure(cfg.Server.TimeZone); err != nil {
+		log.Fatal(err.Error())
+	}
+
+	// Ключ нужен до подключения к БД: миграция шифрует старые ключи трансляций
+	if err := vault.Configure(cfg.Vault.StreamKeySecret); err != nil {
+		log.Fatal(err.Error())
+	}
+
+	db, err := database.ConnectDB(&cfg.Db, logger)
 	if err != nil {
 		logger.Error(err.Error())
 		os.Exit(1)
@@ -38,6 +52,25 @@ func main() {
 
 	validator.Init()
 
+	policy, err := visibility.Load(cfg.Visibility.File)
+	if err != nil {
+		logger.Error(err.Error())
+		os.Exit(1)
+	}
+
+	readinessRules, err := readiness.Load(cfg.Readiness.File)
+	if err != nil {
+		logger.Error(err.Error())
+		os.Exit(1)
+	}
+
+	transactor := repository.NewTransactor(db)
+
+	// Audit
+	auditRepo := repository.NewAuditLogRepository(db)
+	auditService := service.NewAuditService(auditRepo)
+	auditHandler := handler.NewAuditHandlers(auditService, policy)
+
 	//ShortLink
 	sRepo := repository.NewShortLinkRepository(db)
 	sService := service.NewShortLinkService(sRepo)
@@ -46,21 +79,67 @@ func main() {
 	// Mailer
 	mailer := service.NewMailService(&cfg.Smtp, logger)
 
-	// Meets
-	mRepo := repository.NewMeetRepository(db)
-	mService := service.NewMeetService(mRepo, mailer, sService)
-	mHandler := handler.NewMeetHandlers(mService)
-
-	// Lectures
-	lRepo := repository.NewLectureRepository(db)
-	lService := service.NewLectureService(lRepo, sService)
-	lHandler := handler.NewLectureHandlers(lService)
-
 	// Users
 	uRepo := repository.NewUserRepository(db)
 	uService := service.NewUserService(uRepo)
 	uHandler := handler.NewUserHandlers(uService)
 
+	// Directories
+	lRepo := repository.NewLectureRepository(db)
+	dRepo := repository.NewDirectoryRepository(db)
+	dService := service.NewDirectoryService(dRepo, lRepo, transactor)
+	dHandler := handler.NewDirectoryHandlers(dService)
+
+	// Rooms
+	mRepo := repository.NewMeetRepository(db)
+	rRepo := repository.NewRoomRepository(db)
+	rService := service.NewRoomService(rRepo, lRepo, mRepo, dService)
+	rHandler := handler.NewRoomHandlers(rService)
+
+	// Meets
+	mService := service.NewMeetService(mRepo, mailer, sService, transactor, auditService, rService, uService)
+	mHandler := handler.NewMeetHandlers(mService, policy)
+
+	// Export templates
+	etRepo := repository.NewExportTemplateRepository(db)
+	etService := service.NewExportTemplateService(etRepo)
+	etHandler := handler.NewExportTemplateHandlers(etService)
+
+	// Lectures
+	lsRepo := repository.NewLectureSeriesRepository(db)
+	linkRepo := repository.NewLinkOperationRepository(db)
+	lService := service.NewLectureService(lRepo, lsRepo, transactor, sService, auditService, dService, rService, etService, uService, linkRepo)
+	lHandler := handler.NewLectureHandlers(lService, policy, logger)
+
+	// Workload
+	avRepo := repository.NewAvailabilityRepository(db)
+	wlService := service.NewWorkloadService(lRepo, mRepo, uRepo, avRepo, lService, mService, rService, transactor)
+	wlHandler := handler.NewWorkloadHandlers(wlService)
+
+	// Readiness
+	rdService := service.NewReadinessService(
+		lRepo, mRepo, uRepo, sService, rService, mailer,
+		readinessRules, cfg.Readiness.Days, cfg.Readiness.ReportHour, logger,
+	)
+	rdHandler := handler.NewReadinessHandlers(rdService)
+
+	// Digest
+	dgRepo := repository.NewDigestSubscriptionRepository(db)
+	dgService := service.NewDigestService(
+		dgRepo, lRepo, mRepo, uRepo, dService, mailer,
+		cfg.Digest.SendAt, cfg.Server.Domain, logger,
+	)
+	dgHandler := handler.NewDigestHandlers(dgService)
+
+	// Idempotency
+	idemRepo := repository.NewIdempotencyKeyRepository(db)
+	idemService := service.NewIdempotencyService(idemRepo, cfg.Server.IdempotencyRetention, logger)
+
+	// Calendar
+	cRepo := repository.NewCalendarFeedRepository(db)
+	cService := service.NewCalendarService(cRepo, lRepo, dService, rService, policy.For(visibility.RoleFeed), cfg.Server.Domain)
+	cHandler := handler.NewCalendarHandlers(cService, logger)
+
 	if _, err := uService.Create(context.TODO(), entitys.User{
 		Login:    cfg.Server.Admin,
 		Password: cfg.Server.Password,
@@ -74,9 +153,20 @@ func main() {
 	aService := service.NewAuthService(uRepo, aRepo)
 	aHandler := handler.NewAuthHandlers(aService)
 
-	router := router.NewRouter(uHandler, aHandler, lHandler, mHandler, sHandler, logger, cfg.Server.Frontend)
+	router := router.NewRouter(uHandler, aHandler, lHandler, mHandler, sHandler, cHandler, auditHandler, dHandler, rHandler, etHandler, wlHandler, rdHandler, dgHandler, idemService, logger, cfg.Server.Frontend)
+
+	// Trash
+	trashService := service.NewTrashService(cfg.Server.TrashRetention, logger, map[string]service.TrashPurger{
+		"lectures": lService,
+		"meets":    mService,
+		"users":    uService,
+	})
 
 	go mService.AutoUpdate(time.Minute)
+	go trashService.AutoPurge(time.Hour)
+	go rdService.AutoReport()
+	go dgService.AutoSend()
+	go idemService.AutoPurge(time.Hour)
 
 	logger.Info("Server started successfully!")
 	log.Fatal(http.ListenAndServe(":8080", router))
@@ -7,10 +7,14 @@ import (
 var JwtSecret string = os.Getenv("SECRET_KEY")
 
 type Config struct {
-	Server Server
-	Smtp   Smtp
-	Jwt    Jwt
-	Db     Database
+	Server     Server
+	Smtp       Smtp
+	Jwt        Jwt
+	Db         Database
+	Vault      Vault
+	Visibility Visibility
+	Readiness  Readiness
+	Digest     Digest
 }
 
 func LoadConfig() (*Config, error) {
@@ -26,11 +30,31 @@ func LoadConfig() (*Config, error) {
 
 	databaseCfg, err := getDatabaseConfig()
 	jwtCfg := getJwtConfig()
+	visibilityCfg := getVisibilityConfig()
+
+	vaultCfg, err := getVaultConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	readinessCfg, err := getReadinessConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	digestCfg, err := getDigestConfig()
+	if err != nil {
+		return nil, err
+	}
 
 	return &Config{
-		Server: *serverCfg,
-		Smtp:   *smtpCfg,
-		Jwt:    *jwtCfg,
-		Db:     *databaseCfg,
+		Server:     *serverCfg,
+		Smtp:       *smtpCfg,
+		Jwt:        *jwtCfg,
+		Db:         *databaseCfg,
+		Vault:      *vaultCfg,
+		Visibility: *visibilityCfg,
+		Readiness:  *readinessCfg,
+		Digest:     *digestCfg,
 	}, nil
 }
@@ -12,6 +12,8 @@ type Database struct {
 	Password string
 	DB       string
 	Migrate  bool
+	// Применять сведение написаний справочников при миграции, иначе оно только планируется
+	MergeDirectories bool
 }
 
 func getDatabaseConfig() (*Database, error) {
@@ -26,6 +28,7 @@ func getDatabaseConfig() (*Database, error) {
 	password := os.Getenv("POSTGRES_PASSWORD")
 	db := os.Getenv("POSTGRES_DB")
 	migrateStr := os.Getenv("AUTO_MIGRATE")
+	mergeStr := os.Getenv("DIRECTORY_MERGE_APPLY")
 
 	var migrate bool
 	if migrateStr == "true" {
@@ -41,5 +44,7 @@ func getDatabaseConfig() (*Database, error) {
 		Password: password,
 		DB:       db,
 		Migrate:  migrate,
+
+		MergeDirectories: mergeStr == "true",
 	}, nil
 }
@@ -0,0 +1,26 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"table-api/pkg/utils"
+)
+
+type Digest struct {
+	// Время суток (в поясе организации), когда подписчикам уходит сводка на завтра
+	SendAt utils.Clock
+}
+
+func getDigestConfig() (*Digest, error) {
+	sendAt := utils.NewClock(18, 0)
+
+	if sendAtStr := os.Getenv("DIGEST_SEND_TIME"); sendAtStr != "" {
+		minutes, err := utils.ParseClock(sendAtStr)
+		if err != nil {
+			return nil, errors.New("is not valid digest send time")
+		}
+		sendAt = utils.Clock(minutes)
+	}
+
+	return &Digest{SendAt: sendAt}, nil
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"strconv"
+)
+
+type Readiness struct {
+	// JSON-файл с правилами готовности; пустой путь — правила по умолчанию
+	File string
+	// На сколько дней вперёд проверяются лекции и мероприятия
+	Days int
+	// Час (в поясе организации), в который админам и модераторам уходит отчёт
+	ReportHour int
+}
+
+func getReadinessConfig() (*Readiness, error) {
+	days := 3
+	if daysStr := os.Getenv("READINESS_DAYS"); daysStr != "" {
+		value, err := strconv.Atoi(daysStr)
+		if err != nil || value < 1 || value > 31 {
+			return nil, errors.New("is not valid readiness days")
+		}
+		days = value
+	}
+
+	hour := 8
+	if hourStr := os.Getenv("READINESS_REPORT_HOUR"); hourStr != "" {
+		value, err := strconv.Atoi(hourStr)
+		if err != nil || value < 0 || value > 23 {
+			return nil, errors.New("is not valid readiness report hour")
+		}
+		hour = value
+	}
+
+	return &Readiness{
+		File:       os.Getenv("READINESS_RULES_FILE"),
+		Days:       days,
+		ReportHour: hour,
+	}, nil
+}
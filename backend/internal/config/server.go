@@ -3,7 +3,9 @@ package config
 import (
 	"errors"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 )
 
 type Server struct {
@@ -13,6 +15,12 @@ type Server struct {
 	LoggerConsole bool
 	Admin         string
 	Password      string
+	// Срок хранения записей в корзине до окончательного удаления
+	TrashRetention time.Duration
+	// Срок хранения ответов на запросы с Idempotency-Key
+	IdempotencyRetention time.Duration
+	// Часовой пояс организации для границ дней и месяцев
+	TimeZone string
 }
 
 func isValidDomain(domain string) bool {
@@ -28,6 +36,9 @@ func getServerConfig() (*Server, error) {
 	serverLogger := os.Getenv("SERVER_LOGGER_CONSOLE")
 	adminLogin := os.Getenv("SERVER_ADMIN_LOGIN")
 	adminPassword := os.Getenv("SERVER_ADMIN_PASSWORD")
+	trashRetentionStr := os.Getenv("TRASH_RETENTION_DAYS")
+	idempotencyRetentionStr := os.Getenv("IDEMPOTENCY_RETENTION_HOURS")
+	timeZone := os.Getenv("ORG_TIMEZONE")
 
 	if !isValidDomain(serverDomain) {
 		return nil, errors.New("is not valid server domain")
@@ -51,6 +62,31 @@ func getServerConfig() (*Server, error) {
 		return nil, errors.New("is not valid admin password")
 	}
 
+	trashRetentionDays := 30
+	if trashRetentionStr != "" {
+		days, err := strconv.Atoi(trashRetentionStr)
+		if err != nil || days < 1 {
+			return nil, errors.New("is not valid trash retention days")
+		}
+		trashRetentionDays = days
+	}
+
+	idempotencyRetentionHours := 24
+	if idempotencyRetentionStr != "" {
+		hours, err := strconv.Atoi(idempotencyRetentionStr)
+		if err != nil || hours < 1 {
+			return nil, errors.New("is not valid idempotency retention hours")
+		}
+		idempotencyRetentionHours = hours
+	}
+
+	if timeZone == "" {
+		timeZone = "UTC"
+	}
+	if _, err := time.LoadLocation(timeZone); err != nil {
+		return nil, errors.New("is not valid organisation time zone")
+	}
+
 	return &Server{
 		Port:          serverPort,
 		Domain:        serverDomain,
@@ -58,5 +94,9 @@ func getServerConfig() (*Server, error) {
 		LoggerConsole: logger,
 		Admin:         adminLogin,
 		Password:      adminPassword,
+
+		TrashRetention:       time.Duration(trashRetentionDays) * 24 * time.Hour,
+		IdempotencyRetention: time.Duration(idempotencyRetentionHours) * time.Hour,
+		TimeZone:             timeZone,
 	}, nil
 }
@@ -0,0 +1,21 @@
+package config
+
+import (
+	"errors"
+	"os"
+)
+
+type Vault struct {
+	// Секрет шифрования ключей трансляций
+	StreamKeySecret string
+}
+
+func getVaultConfig() (*Vault, error) {
+	secret := os.Getenv("STREAM_KEY_SECRET")
+
+	if len(secret) < 16 {
+		return nil, errors.New("is not valid stream key secret")
+	}
+
+	return &Vault{StreamKeySecret: secret}, nil
+}
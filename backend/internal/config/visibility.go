@@ -0,0 +1,13 @@
+package config
+
+import "os"
+
+type Visibility struct {
+	// JSON-файл с правилами видимости полей по ролям;
+	// пустой путь — правила по умолчанию
+	File string
+}
+
+func getVisibilityConfig() *Visibility {
+	return &Visibility{File: os.Getenv("FIELD_VISIBILITY_FILE")}
+}
@@ -0,0 +1,54 @@
+package database
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// Ключ имени в SQL, как utils.NameKey: нижний регистр и одиночные пробелы
+const sqlNameKey = `LOWER(REGEXP_REPLACE(TRIM(%s), '\s+', ' ', 'g'))`
+
+// migrateAdmins связывает лекции и мероприятия, где админ записан текстом,
+// с пользователями по логину или имени, включая записи в корзине.
+// Возвращает число связанных записей и число имён, для которых пользователь не нашёлся.
+func migrateAdmins(db *gorm.DB) (int64, int64, error) {
+	var linked, unmatched int64
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, table := range []string{"lectures", "meets"} {
+			admin := fmt.Sprintf(sqlNameKey, table+".admin")
+
+			result := tx.Exec(fmt.Sprintf(`
+				UPDATE %[1]s SET admin_id = u.id
+				FROM users u
+				WHERE %[1]s.admin_id IS NULL
+					AND TRIM(COALESCE(%[1]s.admin, '')) <> ''
+					AND u.deleted_at IS NULL
+					AND (%[2]s = LOWER(u.login) OR %[2]s = %[3]s)`,
+				table, admin, fmt.Sprintf(sqlNameKey, "u.name"),
+			))
+			if result.Error != nil {
+				return fmt.Errorf("failed to link %s admins: %w", table, result.Error)
+			}
+			linked += result.RowsAffected
+
+			var count int64
+			err := tx.Table(table).
+				Where("admin_id IS NULL AND TRIM(COALESCE(admin, '')) <> ''").
+				Distinct("admin").
+				Count(&count).Error
+			if err != nil {
+				return fmt.Errorf("failed to count %s admins: %w", table, err)
+			}
+			unmatched += count
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return linked, unmatched, nil
+}
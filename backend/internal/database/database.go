@@ -1,26 +1,49 @@
 package database
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
 	"table-api/internal/config"
 	"table-api/internal/models"
+	"table-api/pkg/timezone"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/stdlib"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
-func ConnectDB(cfg *config.Database) (*gorm.DB, error) {
+func ConnectDB(cfg *config.Database, logger *slog.Logger) (*gorm.DB, error) {
 
 	dsn := fmt.Sprintf(
-		"host=%s user=%s password=%s dbname=%s port=%d sslmode=disable",
+		"host=%s user=%s password=%s dbname=%s port=%d sslmode=disable TimeZone=%s",
 		cfg.Host,
 		cfg.User,
 		cfg.Password,
 		cfg.DB,
 		cfg.Port,
+		timezone.Org().String(),
 	)
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	connConfig, err := pgx.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse database config: %v", err)
+	}
+
+	// Значения timestamptz читаются сразу в поясе организации: по ним считаются
+	// календарные дни лекций и смещение в ответах API
+	conn := stdlib.OpenDB(*connConfig, stdlib.OptionAfterConnect(func(_ context.Context, c *pgx.Conn) error {
+		c.TypeMap().RegisterType(&pgtype.Type{
+			Name:  "timestamptz",
+			OID:   pgtype.TimestamptzOID,
+			Codec: &pgtype.TimestamptzCodec{ScanLocation: timezone.Org()},
+		})
+		return nil
+	}))
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: conn}), &gorm.Config{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %v", err)
 	}
@@ -31,16 +54,103 @@ func ConnectDB(cfg *config.Database) (*gorm.DB, error) {
 
 	// TODO: Ручные миграции, отдельным скриптом
 	if cfg.Migrate {
+		// Текстовые start/end лекций переводятся в time до AutoMigrate,
+		// иначе смена типа упадёт на первом нераспознанном значении
+		issues, err := migrateLectureTimes(db)
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range issues {
+			logger.Warn("Lecture time migration: " + issue.String())
+		}
+
+		if err := migrateUserLogins(db); err != nil {
+			return nil, err
+		}
+
 		err = db.AutoMigrate(
 			&models.User{},
 			&models.Meet{},
 			&models.Lecture{},
+			&models.LectureSeries{},
 			&models.ShortLink{},
 			&models.RefreshToken{},
+			&models.CalendarFeed{},
+			&models.AuditLog{},
+			&models.DirectoryEntry{},
+			&models.DirectoryMerge{},
+			&models.Room{},
+			&models.ExportTemplate{},
+			&models.Availability{},
+			&models.DigestSubscription{},
+			&models.LinkOperation{},
+			&models.IdempotencyKey{},
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to migrate database: %w", err)
 		}
+
+		// Свободный текст групп, преподавателей, подразделений и мест
+		// переводится в справочники; отчёт доступен в /api/directory-merges.
+		// Сведение разных написаний применяется только с DIRECTORY_MERGE_APPLY
+		merges, err := migrateDirectories(db, cfg.MergeDirectories)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, m := range merges {
+			if m.Source == models.DirectoryMergePlanned {
+				logger.Warn(fmt.Sprintf(
+					"Directory migration: %s %q will be merged into %q (%d lectures) once DIRECTORY_MERGE_APPLY=true",
+					m.Kind, m.Variant, m.Canonical, m.Lectures,
+				))
+				continue
+			}
+
+			logger.Info(fmt.Sprintf(
+				"Directory migration: %s %q merged into %q (%d lectures)",
+				m.Kind, m.Variant, m.Canonical, m.Lectures,
+			))
+		}
+
+		days, err := migrateDays(db)
+		if err != nil {
+			return nil, err
+		}
+		if days > 0 {
+			logger.Info(fmt.Sprintf("Date migration: %d days moved to %s", days, timezone.Org()))
+		}
+
+		encrypted, masked, err := migrateStreamKeys(db)
+		if err != nil {
+			return nil, err
+		}
+		if encrypted > 0 {
+			logger.Info(fmt.Sprintf("Stream key migration: %d keys encrypted", encrypted))
+		}
+		if masked > 0 {
+			logger.Info(fmt.Sprintf("Stream key migration: %d audit records masked", masked))
+		}
+
+		replays, err := maskIdempotencyStreamKeys(db)
+		if err != nil {
+			return nil, err
+		}
+		if replays > 0 {
+			logger.Info(fmt.Sprintf("Stream key migration: %d idempotent responses masked", replays))
+		}
+
+		// Админы лекций и мероприятий становятся ссылками на пользователей
+		linked, unmatched, err := migrateAdmins(db)
+		if err != nil {
+			return nil, err
+		}
+		if linked > 0 {
+			logger.Info(fmt.Sprintf("Admin migration: %d records linked to users", linked))
+		}
+		if unmatched > 0 {
+			logger.Warn(fmt.Sprintf("Admin migration: %d admin names match no user and stay as text", unmatched))
+		}
 	}
 	return db, nil
 }
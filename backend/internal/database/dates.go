@@ -0,0 +1,51 @@
+package database
+
+import (
+	"fmt"
+	"table-api/pkg/timezone"
+
+	"gorm.io/gorm"
+)
+
+// Колонки календарных дней, которые раньше сохранялись полночью UTC
+var dayColumns = []struct {
+	table  string
+	column string
+}{
+	{"lectures", "date"},
+	{"lecture_series", "start_date"},
+}
+
+// migrateDays переносит дни, сохранённые полночью UTC, на полночь того же дня
+// в поясе организации, включая лекции в корзине. Уже перенесённые значения
+// не совпадают с полночью UTC и повторно не трогаются. Смена ORG_TIMEZONE
+// после миграции сохранённые дни не пересчитывает.
+func migrateDays(db *gorm.DB) (int64, error) {
+	var migrated int64
+	zone := timezone.Org().String()
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, c := range dayColumns {
+			query := fmt.Sprintf(
+				`UPDATE %[1]s SET %[2]s = ((%[2]s AT TIME ZONE 'UTC')::date)::timestamp AT TIME ZONE @zone
+				WHERE (%[2]s AT TIME ZONE 'UTC')::time = '00:00'
+				AND ((%[2]s AT TIME ZONE 'UTC')::date)::timestamp AT TIME ZONE @zone <> %[2]s`,
+				c.table, c.column,
+			)
+
+			result := tx.Exec(query, map[string]interface{}{"zone": zone})
+			if result.Error != nil {
+				return fmt.Errorf("failed to migrate %s.%s to %s: %w", c.table, c.column, zone, result.Error)
+			}
+
+			migrated += result.RowsAffected
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return migrated, nil
+}
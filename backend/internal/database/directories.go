@@ -0,0 +1,218 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"table-api/internal/models"
+	"table-api/pkg/utils"
+
+	"gorm.io/gorm"
+)
+
+// Колонки названия и ссылки лекции для каждого вида справочника
+var directoryColumns = []struct {
+	kind     string
+	column   string
+	idColumn string
+}{
+	{models.DirectoryGroup, "group", "group_id"},
+	{models.DirectoryLector, "lector", "lector_id"},
+	{models.DirectoryUnit, "unit", "unit_id"},
+	{models.DirectoryLocation, "location", "location_id"},
+}
+
+type directoryVariant struct {
+	Value string
+	Count int64
+}
+
+// migrateDirectories связывает лекции без ссылок на справочники с записями справочников,
+// включая лекции в корзине. Написания с одинаковым ключом ("ИВТ-21" и "ивт-21 ")
+// сводятся к самому частому из них. Без apply сведение только планируется: лекции
+// с другим написанием остаются несвязанными, а план попадает в отчёт directory_merges
+// со статусом planned, чтобы его можно было просмотреть до изменения данных.
+func migrateDirectories(db *gorm.DB, apply bool) ([]*models.DirectoryMerge, error) {
+	var merges []*models.DirectoryMerge
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, c := range directoryColumns {
+			kindMerges, err := migrateDirectoryColumn(tx, c.kind, c.column, c.idColumn, apply)
+			if err != nil {
+				return err
+			}
+
+			merges = append(merges, kindMerges...)
+		}
+
+		// План пересобирается при каждом запуске
+		err := tx.Where("source = ?", models.DirectoryMergePlanned).Delete(&models.DirectoryMerge{}).Error
+		if err != nil {
+			return err
+		}
+
+		if len(merges) == 0 {
+			return nil
+		}
+
+		return tx.Create(&merges).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate directories: %w", err)
+	}
+
+	return merges, nil
+}
+
+func migrateDirectoryColumn(tx *gorm.DB, kind, column, idColumn string, apply bool) ([]*models.DirectoryMerge, error) {
+	var variants []directoryVariant
+
+	err := tx.Table("lectures").
+		Select(fmt.Sprintf(`%q AS value, COUNT(*) AS count`, column)).
+		Where(fmt.Sprintf(`%s IS NULL AND TRIM(COALESCE(%q, '')) <> ''`, idColumn, column)).
+		Group(fmt.Sprintf("%q", column)).
+		Find(&variants).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	byKey := map[string][]directoryVariant{}
+	var keys []string
+
+	for _, v := range variants {
+		key := utils.NameKey(v.Value)
+		if _, ok := byKey[key]; !ok {
+			keys = append(keys, key)
+		}
+		byKey[key] = append(byKey[key], v)
+	}
+
+	sort.Strings(keys)
+
+	source := models.DirectoryMergeMigration
+	if !apply {
+		source = models.DirectoryMergePlanned
+	}
+
+	var merges []*models.DirectoryMerge
+
+	for _, key := range keys {
+		group := byKey[key]
+
+		// Каноническим становится самое частое написание
+		sort.Slice(group, func(i, j int) bool {
+			if group[i].Count != group[j].Count {
+				return group[i].Count > group[j].Count
+			}
+			return group[i].Value < group[j].Value
+		})
+
+		entry, err := findDirectoryEntry(tx, kind, key)
+		if err != nil {
+			return nil, err
+		}
+
+		name := utils.CleanName(group[0].Value)
+		if entry != nil {
+			name = entry.Name
+		}
+
+		var (
+			values      []string
+			groupMerges []*models.DirectoryMerge
+		)
+
+		for _, v := range group {
+			if v.Value != name {
+				groupMerges = append(groupMerges, &models.DirectoryMerge{
+					Kind:      kind,
+					Variant:   v.Value,
+					Canonical: name,
+					Lectures:  v.Count,
+					Source:    source,
+				})
+
+				// Без подтверждения связываются только лекции с каноническим написанием
+				if !apply {
+					continue
+				}
+			}
+
+			values = append(values, v.Value)
+		}
+
+		// Запись создаётся и для плана без канонического написания среди лекций:
+		// иначе его нечем будет подтвердить
+		if (len(values) > 0 || len(groupMerges) > 0) && entry == nil {
+			if entry, err = createDirectoryEntry(tx, kind, key, name); err != nil {
+				return nil, err
+			}
+		}
+
+		if entry != nil {
+			for _, m := range groupMerges {
+				m.EntryID = entry.ID
+			}
+		}
+
+		merges = append(merges, groupMerges...)
+
+		if len(values) == 0 {
+			continue
+		}
+
+		// Версия растёт, чтобы правка по старому ETag получила 412
+		err = tx.Table("lectures").
+			Where(fmt.Sprintf("%s IS NULL AND %q IN ?", idColumn, column), values).
+			Updates(map[string]interface{}{
+				column:    entry.Name,
+				idColumn:  entry.ID,
+				"version": gorm.Expr("version + 1"),
+			}).
+			Error
+		if err != nil {
+			return nil, err
+		}
+
+		// Шаблоны серий получают те же названия, что и их лекции
+		err = tx.Table("lecture_series").
+			Where(fmt.Sprintf("%q IN ?", column), values).
+			Update(column, entry.Name).
+			Error
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return merges, nil
+}
+
+// findDirectoryEntry ищет запись по ключу названия или псевдонима; nil, если её нет.
+func findDirectoryEntry(tx *gorm.DB, kind, key string) (*models.DirectoryEntry, error) {
+	var entry models.DirectoryEntry
+
+	err := tx.
+		Where("kind = ? AND (key = ? OR jsonb_exists(alias_keys, ?))", kind, key, key).
+		Order("id ASC").
+		First(&entry).
+		Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+func createDirectoryEntry(tx *gorm.DB, kind, key, name string) (*models.DirectoryEntry, error) {
+	entry := models.DirectoryEntry{Kind: kind, Name: name, Key: key}
+	if err := tx.Create(&entry).Error; err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
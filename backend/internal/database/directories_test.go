@@ -0,0 +1,133 @@
+package database
+
+import (
+	"strings"
+	"table-api/internal/models"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// directoryDB — подключение без БД: выборка написаний отдаёт variants, справочник
+// пуст, созданные записи получают ID, а SQL изменений копится в updates.
+type directoryDB struct {
+	variants []directoryVariant
+	created  []*models.DirectoryEntry
+	updates  []string
+}
+
+func (d *directoryDB) open(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(
+		postgres.New(postgres.Config{DSN: "host=localhost"}),
+		&gorm.Config{
+			DryRun:                 true,
+			DisableAutomaticPing:   true,
+			SkipDefaultTransaction: true,
+			Logger:                 logger.Discard,
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = db.Callback().Query().After("gorm:query").Register("test:query", func(tx *gorm.DB) {
+		switch dest := tx.Statement.Dest.(type) {
+		case *[]directoryVariant:
+			*dest = d.variants
+		case *models.DirectoryEntry:
+			tx.AddError(gorm.ErrRecordNotFound)
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = db.Callback().Create().After("gorm:create").Register("test:create", func(tx *gorm.DB) {
+		if entry, ok := tx.Statement.Dest.(*models.DirectoryEntry); ok {
+			entry.ID = len(d.created) + 1
+			d.created = append(d.created, entry)
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = db.Callback().Update().After("gorm:update").Register("test:update", func(tx *gorm.DB) {
+		d.updates = append(d.updates, tx.Statement.SQL.String())
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
+
+func TestMigrateDirectoryColumnPlanned(t *testing.T) {
+	tests := []struct {
+		name      string
+		variants  []directoryVariant
+		apply     bool
+		merges    int
+		canonical string
+		updates   int
+	}{
+		{
+			name:      "planned without canonical spelling",
+			variants:  []directoryVariant{{Value: "ивт-21 ", Count: 3}, {Value: " ИВТ-21", Count: 1}},
+			merges:    2,
+			canonical: "ивт-21",
+		},
+		{
+			name:      "planned with canonical spelling",
+			variants:  []directoryVariant{{Value: "ИВТ-21", Count: 3}, {Value: "ивт-21 ", Count: 1}},
+			merges:    1,
+			canonical: "ИВТ-21",
+			updates:   2,
+		},
+		{
+			name:      "applied without canonical spelling",
+			variants:  []directoryVariant{{Value: "ивт-21 ", Count: 3}, {Value: " ИВТ-21", Count: 1}},
+			apply:     true,
+			merges:    2,
+			canonical: "ивт-21",
+			updates:   2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &directoryDB{variants: tt.variants}
+
+			merges, err := migrateDirectoryColumn(d.open(t), models.DirectoryGroup, "group", "group_id", tt.apply)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(d.created) != 1 || d.created[0].Name != tt.canonical {
+				t.Fatalf("created entries = %+v, want one %q", d.created, tt.canonical)
+			}
+
+			if len(merges) != tt.merges {
+				t.Fatalf("merges = %d, want %d", len(merges), tt.merges)
+			}
+			for _, m := range merges {
+				if m.EntryID != d.created[0].ID {
+					t.Fatalf("merge %q EntryID = %d, want %d", m.Variant, m.EntryID, d.created[0].ID)
+				}
+			}
+
+			if len(d.updates) != tt.updates {
+				t.Fatalf("updates = %d, want %d: %v", len(d.updates), tt.updates, d.updates)
+			}
+			for _, sql := range d.updates {
+				if strings.Contains(sql, `UPDATE "lectures"`) && !strings.Contains(sql, `"version"=version + 1`) {
+					t.Fatalf("lecture relink does not bump version: %s", sql)
+				}
+			}
+		})
+	}
+}
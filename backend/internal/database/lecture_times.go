@@ -0,0 +1,157 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+	"table-api/pkg/utils"
+
+	"gorm.io/gorm"
+)
+
+// TimeMigrationIssue — строка, время которой не удалось перенести в колонку типа time.
+type TimeMigrationIssue struct {
+	Table  string
+	ID     int
+	Column string
+	Value  string
+	Reason string
+}
+
+func (i TimeMigrationIssue) String() string {
+	return fmt.Sprintf("%s id=%d %s=%q: %s", i.Table, i.ID, i.Column, i.Value, i.Reason)
+}
+
+type legacyTimeRow struct {
+	ID           int
+	Start        *string
+	End          *string
+	AbnormalTime *string
+}
+
+// migrateLectureTimes переводит текстовые колонки start и end в тип time.
+// Распознанные значения приводятся к виду "09:00", нераспознанные обнуляются,
+// а исходный текст сохраняется в abnormal_time, если там пусто.
+// Возвращает список строк, которые не удалось перенести как есть.
+func migrateLectureTimes(db *gorm.DB) ([]TimeMigrationIssue, error) {
+	var issues []TimeMigrationIssue
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, table := range []string{"lectures", "lecture_series"} {
+			migrated, err := isTimeColumn(tx, table)
+			if err != nil {
+				return err
+			}
+			if migrated {
+				continue
+			}
+
+			tableIssues, err := migrateTableTimes(tx, table)
+			if err != nil {
+				return err
+			}
+
+			issues = append(issues, tableIssues...)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate lecture times: %w", err)
+	}
+
+	return issues, nil
+}
+
+// isTimeColumn сообщает, что колонка start уже имеет тип time или таблицы ещё нет.
+func isTimeColumn(tx *gorm.DB, table string) (bool, error) {
+	var dataTypes []string
+
+	err := tx.Raw(
+		`SELECT data_type FROM information_schema.columns
+		 WHERE table_schema = CURRENT_SCHEMA() AND table_name = ? AND column_name = 'start'`,
+		table,
+	).Scan(&dataTypes).Error
+	if err != nil {
+		return false, err
+	}
+
+	return len(dataTypes) == 0 || dataTypes[0] != "text", nil
+}
+
+func migrateTableTimes(tx *gorm.DB, table string) ([]TimeMigrationIssue, error) {
+	var rows []legacyTimeRow
+	if err := tx.Table(table).Select(`id, start, "end", abnormal_time`).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	var issues []TimeMigrationIssue
+
+	for _, row := range rows {
+		var (
+			updates  = map[string]interface{}{}
+			original []string
+		)
+
+		start, ok := normalizeLegacyTime(row.Start)
+		if !ok {
+			issues = append(issues, TimeMigrationIssue{table, row.ID, "start", *row.Start, "unparseable time, cleared"})
+			original = append(original, *row.Start)
+		}
+		updates["start"] = start
+
+		end, ok := normalizeLegacyTime(row.End)
+		if !ok {
+			issues = append(issues, TimeMigrationIssue{table, row.ID, "end", *row.End, "unparseable time, cleared"})
+			original = append(original, *row.End)
+		}
+		updates["end"] = end
+
+		if start != nil && end != nil && *end <= *start {
+			issues = append(issues, TimeMigrationIssue{table, row.ID, "end", *row.End, "end is not after start"})
+		}
+
+		if len(original) > 0 && (row.AbnormalTime == nil || *row.AbnormalTime == "") {
+			updates["abnormal_time"] = joinLegacyTimes(original)
+		}
+
+		if err := tx.Table(table).Where("id = ?", row.ID).Updates(updates).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	for _, column := range []string{"start", `"end"`} {
+		err := tx.Exec(fmt.Sprintf(
+			`ALTER TABLE %s ALTER COLUMN %s TYPE time USING NULLIF(%s, '')::time`,
+			table, column, column,
+		)).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return issues, nil
+}
+
+// normalizeLegacyTime возвращает время в формате "09:00:00" или nil.
+// ok=false означает, что непустое значение не удалось распознать.
+func normalizeLegacyTime(value *string) (*string, bool) {
+	if value == nil || strings.TrimSpace(*value) == "" {
+		return nil, true
+	}
+
+	minutes, err := utils.ParseClock(*value)
+	if err != nil {
+		return nil, false
+	}
+
+	normalized := utils.Clock(minutes).String() + ":00"
+	return &normalized, true
+}
+
+func joinLegacyTimes(values []string) string {
+	if len(values) == 1 {
+		return values[0]
+	}
+
+	return values[0] + " - " + values[1]
+}
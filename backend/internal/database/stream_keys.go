@@ -0,0 +1,149 @@
+package database
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"table-api/internal/models"
+	"table-api/internal/visibility"
+	"table-api/pkg/utils"
+	"table-api/pkg/vault"
+
+	"gorm.io/gorm"
+)
+
+type plainStreamKey struct {
+	ID        int
+	StreamKey string
+}
+
+// migrateStreamKeys шифрует ключи трансляций, сохранённые открытым текстом,
+// включая лекции в корзине, и маскирует их в снимках журнала аудита.
+// Уже зашифрованные и замаскированные значения не трогаются.
+func migrateStreamKeys(db *gorm.DB) (int, int, error) {
+	var migrated, masked int
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, table := range []string{"lectures", "lecture_series"} {
+			var rows []plainStreamKey
+
+			err := tx.Table(table).
+				Select("id, stream_key").
+				Where("stream_key IS NOT NULL AND stream_key <> '' AND stream_key NOT LIKE ?", "enc:%").
+				Find(&rows).Error
+			if err != nil {
+				return fmt.Errorf("failed to read %s stream keys: %w", table, err)
+			}
+
+			for _, row := range rows {
+				err := tx.Table(table).
+					Where("id = ?", row.ID).
+					UpdateColumn("stream_key", vault.Secret(row.StreamKey)).Error
+				if err != nil {
+					return fmt.Errorf("failed to encrypt %s stream key %d: %w", table, row.ID, err)
+				}
+			}
+
+			migrated += len(rows)
+		}
+
+		var err error
+		masked, err = maskAuditStreamKeys(tx)
+		return err
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return migrated, masked, nil
+}
+
+// maskAuditStreamKeys заменяет ключи трансляций в записях журнала маской,
+// как их теперь пишет visibility.Snapshot. Возвращает число исправленных записей.
+func maskAuditStreamKeys(tx *gorm.DB) (int, error) {
+	var logs []*models.AuditLog
+
+	err := tx.
+		Where("changes @> ?", `[{"field": "streamKey"}]`).
+		Find(&logs).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to read audit stream keys: %w", err)
+	}
+
+	masked := 0
+
+	for _, log := range logs {
+		changed := false
+
+		for i, change := range log.Changes {
+			if change.Field != "streamKey" {
+				continue
+			}
+
+			for _, value := range []*any{&log.Changes[i].Before, &log.Changes[i].After} {
+				if key, ok := (*value).(string); ok && key != "" && !utils.IsMasked(key) {
+					*value = utils.Mask(key)
+					changed = true
+				}
+			}
+		}
+
+		if !changed {
+			continue
+		}
+
+		changes, err := json.Marshal(log.Changes)
+		if err != nil {
+			return 0, err
+		}
+
+		err = tx.Model(&models.AuditLog{}).
+			Where("id = ?", log.ID).
+			UpdateColumn("changes", gorm.Expr("?::jsonb", string(changes))).Error
+		if err != nil {
+			return 0, fmt.Errorf("failed to mask audit stream key %d: %w", log.ID, err)
+		}
+
+		masked++
+	}
+
+	return masked, nil
+}
+
+// maskIdempotencyStreamKeys маскирует ключи трансляций в ответах, сохранённых
+// для повтора по Idempotency-Key, как их теперь пишет сервис. Возвращает число
+// исправленных ответов.
+func maskIdempotencyStreamKeys(db *gorm.DB) (int, error) {
+	var records []*models.IdempotencyKey
+
+	err := db.
+		Where("status <> 0 AND position(? in body) > 0", []byte(`"streamKey"`)).
+		Find(&records).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to read idempotency stream keys: %w", err)
+	}
+
+	masked := 0
+
+	for _, record := range records {
+		body, err := visibility.ApplyJSON(record.Body, visibility.Snapshot.Lecture)
+		if err != nil {
+			return 0, fmt.Errorf("failed to mask idempotency key %d: %w", record.ID, err)
+		}
+
+		if bytes.Equal(body, record.Body) {
+			continue
+		}
+
+		err = db.Model(&models.IdempotencyKey{}).
+			Where("id = ?", record.ID).
+			UpdateColumn("body", body).Error
+		if err != nil {
+			return 0, fmt.Errorf("failed to mask idempotency key %d: %w", record.ID, err)
+		}
+
+		masked++
+	}
+
+	return masked, nil
+}
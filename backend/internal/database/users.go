@@ -0,0 +1,24 @@
+package database
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// migrateUserLogins снимает прежнее ограничение уникальности логина до AutoMigrate:
+// оно учитывало пользователей в корзине, и логин удалённого пользователя нельзя
+// было занять снова. Его заменяет частичный индекс idx_users_login.
+// Старые версии gorm называли ограничение users_login_key, новые — uni_users_login.
+func migrateUserLogins(db *gorm.DB) error {
+	err := db.Exec(`
+		ALTER TABLE IF EXISTS users
+			DROP CONSTRAINT IF EXISTS uni_users_login,
+			DROP CONSTRAINT IF EXISTS users_login_key`,
+	).Error
+	if err != nil {
+		return fmt.Errorf("failed to migrate user logins: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package entitys
+
+import "time"
+
+// DigestEntry — строка сводки расписания.
+type DigestEntry struct {
+	Kind     string
+	ID       int
+	Time     string
+	Title    string
+	Location string
+	Platform string
+	Link     string
+	Admin    string
+}
+
+// Digest — сводка расписания на день для одного получателя.
+type Digest struct {
+	Date      time.Time
+	Recipient string
+	Scope     string
+	Entries   []DigestEntry
+}
+
+// DigestMail — готовое письмо со сводкой.
+type DigestMail struct {
+	Digest  *Digest
+	Subject string
+	Text    string
+	HTML    string
+}
@@ -0,0 +1,7 @@
+package entitys
+
+// StoredResponse — сохранённый ответ на запрос с Idempotency-Key.
+type StoredResponse struct {
+	Status int
+	Body   []byte
+}
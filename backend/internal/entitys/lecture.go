@@ -1,5 +1,7 @@
 package entitys
 
+import "table-api/internal/models"
+
 type LectureDates struct {
 	Years []*LectureYear `json:"years"`
 }
@@ -19,3 +21,43 @@ type DailySchedule struct {
 	Groups       []string `json:"groups"`
 	LectureCount int      `json:"lectureCount"`
 }
+
+type LectureConflict struct {
+	Index              *int   `json:"index,omitempty"`
+	LectureID          *int   `json:"lectureId,omitempty"`
+	Date               string `json:"date"`
+	Dimension          string `json:"dimension"`
+	Value              string `json:"value"`
+	ConflictingIDs     []int  `json:"conflictingIds"`
+	ConflictingIndexes []int  `json:"conflictingIndexes,omitempty"`
+}
+
+// LectureBlock — результат копирования или сдвига блока расписания.
+type LectureBlock struct {
+	OffsetDays int
+	Lectures   []*models.Lecture
+	Conflicts  []LectureConflict
+}
+
+// Итог лекции при пакетном создании с частичным успехом
+const (
+	BatchRowCreated = "created"
+	BatchRowFailed  = "failed"
+)
+
+// LectureBatchRow — итог создания одной лекции пакета. Index — позиция в запросе.
+type LectureBatchRow struct {
+	Index     int
+	Status    string
+	Lecture   *models.Lecture
+	Errors    []string
+	Conflicts []LectureConflict
+}
+
+// LectureStat — число лекций и их минуты для одного сочетания разрезов.
+// Keys идут в порядке запрошенных разрезов, nil — значение не указано.
+type LectureStat struct {
+	Keys     []*string
+	Lectures int64
+	Minutes  int64
+}
@@ -0,0 +1,28 @@
+package entitys
+
+import "time"
+
+// LinkValues — ссылки лекции.
+type LinkValues struct {
+	URL      *string
+	ShortURL *string
+}
+
+// LinkDiffRow — лекция, у которой меняются ссылки.
+type LinkDiffRow struct {
+	LectureID int
+	Date      time.Time
+	Group     *string
+	Lector    *string
+	Platform  *string
+	Before    LinkValues
+	After     LinkValues
+}
+
+// LinkDiff — результат массовой простановки ссылки или её отмены.
+// Skipped — подходящие лекции, которые остались без изменений.
+type LinkDiff struct {
+	OperationID int
+	Skipped     int
+	Changes     []LinkDiffRow
+}
@@ -0,0 +1,30 @@
+package entitys
+
+import "time"
+
+// ReadinessIssue — нарушенное правило готовности.
+// Details — незаполненные поля или замечания проверки.
+type ReadinessIssue struct {
+	Rule     string
+	Severity string
+	Message  string
+	Details  []string
+}
+
+// ReadinessItem — лекция или мероприятие с замечаниями.
+type ReadinessItem struct {
+	Kind   string
+	ID     int
+	Title  string
+	Date   time.Time
+	Time   string
+	Admin  string
+	Issues []ReadinessIssue
+}
+
+type Readiness struct {
+	Start   time.Time
+	End     time.Time
+	Checked int
+	Items   []ReadinessItem
+}
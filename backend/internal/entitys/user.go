@@ -3,6 +3,7 @@ package entitys
 type User struct {
 	Login    string
 	Name     *string
+	Email    *string
 	Role     string
 	Password string
 }
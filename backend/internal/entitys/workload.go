@@ -0,0 +1,61 @@
+package entitys
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WorkloadWeek — нагрузка за одну ISO-неделю.
+type WorkloadWeek struct {
+	Week     string
+	Start    time.Time
+	Lectures int
+	Meets    int
+	Minutes  int
+}
+
+// AdminWorkload — нагрузка одного админа по неделям. AdminID пуст
+// у имён, которые не удалось связать с пользователем.
+type AdminWorkload struct {
+	AdminID *uuid.UUID
+	Admin   string
+	Role    string
+	Weeks   []*WorkloadWeek
+	Total   WorkloadWeek
+}
+
+type Workload struct {
+	Start      time.Time
+	End        time.Time
+	Admins     []*AdminWorkload
+	Unassigned []*WorkloadWeek
+}
+
+// Виды назначаемых записей
+const (
+	AssignmentLecture = "lecture"
+	AssignmentMeet    = "meet"
+)
+
+// Assignment — запись, которой автоназначение подобрало админа.
+type Assignment struct {
+	Kind    string
+	ID      int
+	Start   time.Time
+	End     time.Time
+	AdminID uuid.UUID
+	Admin   string
+}
+
+// SkippedAssignment — запись, которой не нашёлся свободный техник.
+type SkippedAssignment struct {
+	Kind   string
+	ID     int
+	Reason string
+}
+
+type AutoAssignment struct {
+	Assigned []Assignment
+	Skipped  []SkippedAssignment
+}
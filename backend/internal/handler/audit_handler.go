@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"table-api/internal/entitys"
+	"table-api/internal/handler/dto"
+	"table-api/internal/mappers"
+	"table-api/internal/models"
+	"table-api/internal/visibility"
+	httprespond "table-api/pkg/http"
+	"table-api/pkg/timezone"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+)
+
+type AuditService interface {
+	History(ctx context.Context, entityType, entityID string) ([]*models.AuditLog, error)
+	List(ctx context.Context, page, limit int, filter dto.GetQueryAuditDto) ([]*models.AuditLog, *entitys.Pagination, error)
+}
+
+type AuditHandlers struct {
+	auditService AuditService
+	visibility   *visibility.Policy
+}
+
+func NewAuditHandlers(s AuditService, policy *visibility.Policy) *AuditHandlers {
+	return &AuditHandlers{auditService: s, visibility: policy}
+}
+
+func (a *AuditHandlers) LectureHistory(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	a.history(w, r, "lecture", ps.ByName("id"))
+}
+
+func (a *AuditHandlers) MeetHistory(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	a.history(w, r, "meet", ps.ByName("id"))
+}
+
+func (a *AuditHandlers) history(w http.ResponseWriter, r *http.Request, entityType, id string) {
+	ctx := r.Context()
+
+	if _, err := strconv.Atoi(id); err != nil {
+		httprespond.ErrorResponse(w, "Invalid "+entityType+" ID", http.StatusBadRequest)
+		return
+	}
+
+	logs, err := a.auditService.History(ctx, entityType, id)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	resp := dto.AuditHistoryResponse{Data: mappers.AuditLogsToDto(logs, a.view(ctx))}
+	httprespond.JsonResponse(w, resp, http.StatusOK)
+}
+
+func (a *AuditHandlers) FindMany(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+	q := r.URL.Query()
+
+	pageInt, err1 := strconv.Atoi(q.Get("page"))
+	limitInt, err2 := strconv.Atoi(q.Get("limit"))
+	if err1 != nil || err2 != nil {
+		httprespond.ErrorResponse(w, "Page and limit must be int", http.StatusBadRequest)
+		return
+	}
+
+	var filters dto.GetQueryAuditDto
+
+	if userIDStr := q.Get("userId"); userIDStr != "" {
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			httprespond.ErrorResponse(w, "Invalid user ID", http.StatusBadRequest)
+			return
+		}
+		filters.UserID = &userID
+	}
+
+	if entity := q.Get("entity"); entity != "" {
+		filters.EntityType = &entity
+	}
+
+	if entityID := q.Get("entityId"); entityID != "" {
+		filters.EntityID = &entityID
+	}
+
+	if action := q.Get("action"); action != "" {
+		filters.Action = &action
+	}
+
+	if fromStr := q.Get("from"); fromStr != "" {
+		from, err := timezone.ParseDate(fromStr)
+		if err != nil {
+			httprespond.ErrorResponse(w, "From must be date YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+		filters.From = &from
+	}
+
+	if toStr := q.Get("to"); toStr != "" {
+		to, err := timezone.ParseDate(toStr)
+		if err != nil {
+			httprespond.ErrorResponse(w, "To must be date YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+		// Конец дня включительно
+		to = to.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		filters.To = &to
+	}
+
+	if message, err := dto.Validate(filters); err != nil {
+		httprespond.ErrorResponse(w, message, http.StatusBadRequest)
+		return
+	}
+
+	logs, pagination, err := a.auditService.List(ctx, pageInt, limitInt, filters)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	resp := dto.PaginatedResponse[dto.AuditLogResponse]{
+		Data:       mappers.AuditLogsToDto(logs, a.view(ctx)),
+		Pagination: mappers.PaginationToDto(pagination),
+	}
+
+	httprespond.JsonResponse(w, resp, http.StatusOK)
+}
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"table-api/internal/handler/dto"
+	"table-api/internal/mappers"
+	"table-api/internal/models"
+	httprespond "table-api/pkg/http"
+	"table-api/pkg/ical"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type CalendarService interface {
+	CreateFeed(ctx context.Context, dto dto.CreateCalendarFeedRequest) (*models.CalendarFeed, error)
+	ListFeeds(ctx context.Context) ([]*models.CalendarFeed, error)
+	RemoveFeed(ctx context.Context, id int) (*models.CalendarFeed, error)
+	FeedURL(feed *models.CalendarFeed) string
+	Feed(ctx context.Context, kind, name, token string) (*ical.Calendar, error)
+}
+
+type CalendarHandlers struct {
+	calendarService CalendarService
+	logger          *slog.Logger
+}
+
+func NewCalendarHandlers(s CalendarService, logger *slog.Logger) *CalendarHandlers {
+	return &CalendarHandlers{calendarService: s, logger: logger}
+}
+
+func (c *CalendarHandlers) CreateFeed(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+
+	var req dto.CreateCalendarFeedRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httprespond.ErrorResponse(w, "Bad request", http.StatusBadRequest)
+		return
+	}
+
+	if message, err := dto.Validate(req); err != nil {
+		httprespond.ErrorResponse(w, message, http.StatusBadRequest)
+		return
+	}
+
+	feed, err := c.calendarService.CreateFeed(ctx, req)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	resp := mappers.CalendarFeedToDto(feed, c.calendarService.FeedURL(feed))
+	httprespond.JsonResponse(w, resp, http.StatusCreated)
+}
+
+func (c *CalendarHandlers) ListFeeds(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+
+	feeds, err := c.calendarService.ListFeeds(ctx)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	resp := make([]dto.CalendarFeedResponse, 0, len(feeds))
+	for _, feed := range feeds {
+		resp = append(resp, mappers.CalendarFeedToDto(feed, c.calendarService.FeedURL(feed)))
+	}
+
+	httprespond.JsonResponse(w, resp, http.StatusOK)
+}
+
+func (c *CalendarHandlers) RemoveFeed(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		httprespond.ErrorResponse(w, "Invalid feed ID", http.StatusBadRequest)
+		return
+	}
+
+	feed, err := c.calendarService.RemoveFeed(ctx, id)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	resp := mappers.CalendarFeedToDto(feed, c.calendarService.FeedURL(feed))
+	httprespond.JsonResponse(w, resp, http.StatusOK)
+}
+
+func (c *CalendarHandlers) GroupFeed(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	c.writeFeed(w, r, dto.CalendarFeedGroup, ps.ByName("name"))
+}
+
+func (c *CalendarHandlers) LectorFeed(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	c.writeFeed(w, r, dto.CalendarFeedLector, ps.ByName("name"))
+}
+
+func (c *CalendarHandlers) LocationFeed(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	c.writeFeed(w, r, dto.CalendarFeedLocation, ps.ByName("name"))
+}
+
+func (c *CalendarHandlers) writeFeed(w http.ResponseWriter, r *http.Request, kind, name string) {
+	ctx := r.Context()
+
+	name = strings.TrimSuffix(name, ".ics")
+	token := r.URL.Query().Get("token")
+
+	calendar, err := c.calendarService.Feed(ctx, kind, name, token)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="`+kind+`.ics"`)
+
+	// Заголовки уже отправлены, поэтому ошибку записи можно только залогировать
+	if err := calendar.Write(w); err != nil {
+		c.logger.Error(fmt.Sprintf("calendar feed %s/%s: %v", kind, name, err))
+	}
+}
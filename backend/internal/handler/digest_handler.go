@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"table-api/internal/entitys"
+	"table-api/internal/handler/dto"
+	"table-api/internal/mappers"
+	"table-api/internal/models"
+	httprespond "table-api/pkg/http"
+	"table-api/pkg/timezone"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+)
+
+type DigestService interface {
+	GetSubscription(ctx context.Context, userID uuid.UUID) (*models.DigestSubscription, error)
+	UpdateSubscription(ctx context.Context, userID uuid.UUID, dto dto.UpdateDigestSubscriptionRequest) (*models.DigestSubscription, error)
+	Preview(ctx context.Context, userID uuid.UUID, date time.Time) (*entitys.DigestMail, error)
+}
+
+type DigestHandlers struct {
+	digestService DigestService
+}
+
+func NewDigestHandlers(s DigestService) *DigestHandlers {
+	return &DigestHandlers{digestService: s}
+}
+
+func (h *DigestHandlers) GetSubscription(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	id, err := uuid.Parse(ps.ByName("id"))
+	if err != nil {
+		httprespond.ErrorResponse(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	subscription, err := h.digestService.GetSubscription(ctx, id)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	httprespond.JsonResponse(w, mappers.DigestSubscriptionToDto(subscription), http.StatusOK)
+}
+
+func (h *DigestHandlers) UpdateSubscription(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	id, err := uuid.Parse(ps.ByName("id"))
+	if err != nil {
+		httprespond.ErrorResponse(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	var req dto.UpdateDigestSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httprespond.ErrorResponse(w, "Bad request", http.StatusBadRequest)
+		return
+	}
+
+	if message, err := dto.Validate(req); err != nil {
+		httprespond.ErrorResponse(w, message, http.StatusBadRequest)
+		return
+	}
+
+	subscription, err := h.digestService.UpdateSubscription(ctx, id, req)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	httprespond.JsonResponse(w, mappers.DigestSubscriptionToDto(subscription), http.StatusOK)
+}
+
+// Preview показывает письмо на дату date, по умолчанию — на завтра.
+func (h *DigestHandlers) Preview(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	id, err := uuid.Parse(ps.ByName("id"))
+	if err != nil {
+		httprespond.ErrorResponse(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	date := timezone.Day(timezone.Now()).AddDate(0, 0, 1)
+	if dateStr := r.URL.Query().Get("date"); dateStr != "" {
+		date, err = timezone.ParseDate(dateStr)
+		if err != nil {
+			httprespond.ErrorResponse(w, "Date must be date YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+	}
+
+	mail, err := h.digestService.Preview(ctx, id, date)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	httprespond.JsonResponse(w, mappers.DigestMailToDto(mail), http.StatusOK)
+}
@@ -0,0 +1,252 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"table-api/internal/entitys"
+	"table-api/internal/handler/dto"
+	"table-api/internal/mappers"
+	"table-api/internal/models"
+	httprespond "table-api/pkg/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type DirectoryService interface {
+	Create(ctx context.Context, kind string, dto dto.CreateDirectoryEntryRequest) (*models.DirectoryEntry, error)
+	Get(ctx context.Context, kind string, id int) (*models.DirectoryEntry, error)
+	List(ctx context.Context, kind string, page, limit int, search *string) ([]*models.DirectoryEntry, *entitys.Pagination, error)
+	Update(ctx context.Context, kind string, id int, dto dto.UpdateDirectoryEntryRequest) (*models.DirectoryEntry, error)
+	Remove(ctx context.Context, kind string, id int) (*models.DirectoryEntry, error)
+	Merge(ctx context.Context, kind string, id int, ids []int) (*models.DirectoryEntry, []*models.DirectoryMerge, error)
+	ListMerges(ctx context.Context, kind *string, page, limit int) ([]*models.DirectoryMerge, *entitys.Pagination, error)
+}
+
+type DirectoryHandlers struct {
+	directoryService DirectoryService
+}
+
+func NewDirectoryHandlers(s DirectoryService) *DirectoryHandlers {
+	return &DirectoryHandlers{directoryService: s}
+}
+
+// Сегмент пути справочника -> вид записи
+var directoryKinds = map[string]string{
+	"groups":    models.DirectoryGroup,
+	"lectors":   models.DirectoryLector,
+	"units":     models.DirectoryUnit,
+	"locations": models.DirectoryLocation,
+}
+
+// directoryParams читает вид справочника и, если нужен, ID записи.
+func directoryParams(w http.ResponseWriter, ps httprouter.Params, withID bool) (string, int, bool) {
+	kind, ok := directoryKinds[ps.ByName("kind")]
+	if !ok {
+		httprespond.ErrorResponse(w, "Unknown directory", http.StatusNotFound)
+		return "", 0, false
+	}
+
+	if !withID {
+		return kind, 0, true
+	}
+
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		httprespond.ErrorResponse(w, "Invalid directory entry ID", http.StatusBadRequest)
+		return "", 0, false
+	}
+
+	return kind, id, true
+}
+
+func (d *DirectoryHandlers) FindMany(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	kind, _, ok := directoryParams(w, ps, false)
+	if !ok {
+		return
+	}
+
+	page, limit, ok := pageParams(r)
+	if !ok {
+		httprespond.ErrorResponse(w, "Page and limit must be int", http.StatusBadRequest)
+		return
+	}
+
+	var search *string
+	if q := r.URL.Query().Get("q"); q != "" {
+		search = &q
+	}
+
+	entries, pagination, err := d.directoryService.List(ctx, kind, page, limit, search)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	resp := dto.PaginatedResponse[dto.DirectoryEntryResponse]{
+		Data:       mappers.DirectoryEntriesToDto(entries),
+		Pagination: mappers.PaginationToDto(pagination),
+	}
+
+	httprespond.JsonResponse(w, resp, http.StatusOK)
+}
+
+func (d *DirectoryHandlers) Create(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	kind, _, ok := directoryParams(w, ps, false)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateDirectoryEntryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httprespond.ErrorResponse(w, "Bad request", http.StatusBadRequest)
+		return
+	}
+
+	if message, err := dto.Validate(req); err != nil {
+		httprespond.ErrorResponse(w, message, http.StatusBadRequest)
+		return
+	}
+
+	entry, err := d.directoryService.Create(ctx, kind, req)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	httprespond.JsonResponse(w, mappers.DirectoryEntryToDto(entry), http.StatusCreated)
+}
+
+func (d *DirectoryHandlers) Get(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	kind, id, ok := directoryParams(w, ps, true)
+	if !ok {
+		return
+	}
+
+	entry, err := d.directoryService.Get(ctx, kind, id)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	httprespond.JsonResponse(w, mappers.DirectoryEntryToDto(entry), http.StatusOK)
+}
+
+func (d *DirectoryHandlers) Update(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	kind, id, ok := directoryParams(w, ps, true)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateDirectoryEntryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httprespond.ErrorResponse(w, "Bad request", http.StatusBadRequest)
+		return
+	}
+
+	if message, err := dto.Validate(req); err != nil {
+		httprespond.ErrorResponse(w, message, http.StatusBadRequest)
+		return
+	}
+
+	entry, err := d.directoryService.Update(ctx, kind, id, req)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	httprespond.JsonResponse(w, mappers.DirectoryEntryToDto(entry), http.StatusOK)
+}
+
+func (d *DirectoryHandlers) Remove(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	kind, id, ok := directoryParams(w, ps, true)
+	if !ok {
+		return
+	}
+
+	entry, err := d.directoryService.Remove(ctx, kind, id)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	httprespond.JsonResponse(w, mappers.DirectoryEntryToDto(entry), http.StatusOK)
+}
+
+func (d *DirectoryHandlers) Merge(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	kind, id, ok := directoryParams(w, ps, true)
+	if !ok {
+		return
+	}
+
+	var req dto.MergeDirectoryEntriesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httprespond.ErrorResponse(w, "Bad request", http.StatusBadRequest)
+		return
+	}
+
+	if message, err := dto.Validate(req); err != nil {
+		httprespond.ErrorResponse(w, message, http.StatusBadRequest)
+		return
+	}
+
+	entry, merges, err := d.directoryService.Merge(ctx, kind, id, req.IDs)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	resp := dto.MergeDirectoryEntriesResponse{
+		Entry:  mappers.DirectoryEntryToDto(entry),
+		Merges: mappers.DirectoryMergesToDto(merges),
+	}
+
+	httprespond.JsonResponse(w, resp, http.StatusOK)
+}
+
+// Merges отдаёт отчёт о слияниях: и после миграции, и после ручных слияний.
+func (d *DirectoryHandlers) Merges(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+
+	page, limit, ok := pageParams(r)
+	if !ok {
+		httprespond.ErrorResponse(w, "Page and limit must be int", http.StatusBadRequest)
+		return
+	}
+
+	var kind *string
+	if k := r.URL.Query().Get("kind"); k != "" {
+		mapped, ok := directoryKinds[k]
+		if !ok {
+			httprespond.ErrorResponse(w, "Unknown directory", http.StatusBadRequest)
+			return
+		}
+		kind = &mapped
+	}
+
+	merges, pagination, err := d.directoryService.ListMerges(ctx, kind, page, limit)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	resp := dto.PaginatedResponse[dto.DirectoryMergeResponse]{
+		Data:       mappers.DirectoryMergesToDto(merges),
+		Pagination: mappers.PaginationToDto(pagination),
+	}
+
+	httprespond.JsonResponse(w, resp, http.StatusOK)
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	httprespond "table-api/pkg/http"
+)
+
+// downloadWriter запоминает, ушли ли клиенту первые байты файла.
+type downloadWriter struct {
+	http.ResponseWriter
+	started bool
+}
+
+func (d *downloadWriter) Write(p []byte) (int, error) {
+	d.started = true
+	return d.ResponseWriter.Write(p)
+}
+
+// download отдаёт файл, который пишет write. Пока клиенту ничего не ушло,
+// ошибка возвращается JSON-ответом. После начала потока JSON испортил бы файл,
+// поэтому ошибка пишется в лог, а соединение обрывается, чтобы клиент
+// не принял обрезанный файл за целый.
+func download(w http.ResponseWriter, logger *slog.Logger, name string, write func(http.ResponseWriter) error) {
+	writer := &downloadWriter{ResponseWriter: w}
+
+	err := write(writer)
+	if err == nil {
+		return
+	}
+
+	if !writer.started {
+		w.Header().Del("Content-Disposition")
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	logger.Error(fmt.Sprintf("download %s aborted: %v", name, err))
+	panic(http.ErrAbortHandler)
+}
@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GetQueryAuditDto struct {
+	UserID     *uuid.UUID
+	EntityType *string `validate:"omitempty,oneof=lecture meet"`
+	EntityID   *string `validate:"omitempty,max=64"`
+	Action     *string `validate:"omitempty,oneof=create update delete restore purge reveal"`
+	From       *time.Time
+	To         *time.Time
+}
+
+type AuditChangeResponse struct {
+	Field  string `json:"field"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+type AuditLogResponse struct {
+	ID         int                   `json:"id"`
+	EntityType string                `json:"entityType"`
+	EntityID   string                `json:"entityId"`
+	Action     string                `json:"action"`
+	UserID     *string               `json:"userId"`
+	Changes    []AuditChangeResponse `json:"changes"`
+	CreatedAt  time.Time             `json:"createdAt"`
+}
+
+type AuditHistoryResponse struct {
+	Data []AuditLogResponse `json:"data"`
+}
@@ -0,0 +1,23 @@
+package dto
+
+import "time"
+
+const (
+	CalendarFeedGroup    = "group"
+	CalendarFeedLector   = "lector"
+	CalendarFeedLocation = "location"
+)
+
+type CreateCalendarFeedRequest struct {
+	Kind string `json:"kind" validate:"required,oneof=group lector location"`
+	Name string `json:"name" validate:"required,max=150"`
+}
+
+type CalendarFeedResponse struct {
+	ID        int       `json:"id"`
+	Kind      string    `json:"kind"`
+	Name      string    `json:"name"`
+	Token     string    `json:"token"`
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"createdAt"`
+}
@@ -0,0 +1,28 @@
+package dto
+
+import "time"
+
+// UpdateDigestSubscriptionRequest меняет только переданные поля подписки.
+// Для охвата lector нужно название лектора из справочника.
+type UpdateDigestSubscriptionRequest struct {
+	Enabled *bool   `json:"enabled,omitempty"`
+	Scope   *string `json:"scope,omitempty"   validate:"omitempty,oneof=admin lector all"`
+	Lector  *string `json:"lector,omitempty"  validate:"omitempty,max=100"`
+}
+
+type DigestSubscriptionResponse struct {
+	UserID    string     `json:"userId"`
+	Enabled   bool       `json:"enabled"`
+	Scope     string     `json:"scope"`
+	LectorID  *int       `json:"lectorId"`
+	Lector    *string    `json:"lector"`
+	UpdatedAt *time.Time `json:"updatedAt"`
+}
+
+type DigestPreviewResponse struct {
+	Date    time.Time `json:"date"`
+	Count   int       `json:"count"`
+	Subject string    `json:"subject"`
+	Text    string    `json:"text"`
+	HTML    string    `json:"html"`
+}
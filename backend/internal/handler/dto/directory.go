@@ -0,0 +1,47 @@
+package dto
+
+import "time"
+
+type CreateDirectoryEntryRequest struct {
+	Name    string   `json:"name"              validate:"required,max=150"`
+	Email   *string  `json:"email,omitempty"   validate:"omitempty,email,max=150"`
+	Aliases []string `json:"aliases,omitempty" validate:"omitempty,max=50,dive,required,max=150"`
+}
+
+// UpdateDirectoryEntryRequest меняет только переданные поля.
+// Пустой email удаляет контакт, aliases заменяет список псевдонимов целиком.
+type UpdateDirectoryEntryRequest struct {
+	Name    *string   `json:"name,omitempty"    validate:"omitempty,min=1,max=150"`
+	Email   *string   `json:"email,omitempty"   validate:"omitempty,email,max=150"`
+	Aliases *[]string `json:"aliases,omitempty" validate:"omitempty,max=50,dive,required,max=150"`
+}
+
+type MergeDirectoryEntriesRequest struct {
+	IDs []int `json:"ids" validate:"required,min=1,max=100,dive,gt=0"`
+}
+
+type DirectoryEntryResponse struct {
+	ID        int        `json:"id"`
+	Kind      string     `json:"kind"`
+	Name      string     `json:"name"`
+	Email     *string    `json:"email"`
+	Aliases   []string   `json:"aliases"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt *time.Time `json:"updatedAt"`
+}
+
+type DirectoryMergeResponse struct {
+	ID        int       `json:"id"`
+	Kind      string    `json:"kind"`
+	EntryID   int       `json:"entryId"`
+	Variant   string    `json:"variant"`
+	Canonical string    `json:"canonical"`
+	Lectures  int64     `json:"lectures"`
+	Source    string    `json:"source"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type MergeDirectoryEntriesResponse struct {
+	Entry  DirectoryEntryResponse   `json:"entry"`
+	Merges []DirectoryMergeResponse `json:"merges"`
+}
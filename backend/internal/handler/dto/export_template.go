@@ -0,0 +1,38 @@
+package dto
+
+import "time"
+
+type ExportColumnDto struct {
+	Field string `json:"field"  validate:"required,max=50"`
+	// Пустая подпись — заголовок поля по умолчанию
+	Header string `json:"header" validate:"omitempty,max=100"`
+}
+
+type CreateExportTemplateRequest struct {
+	Name       string            `json:"name"                 validate:"required,max=100"`
+	Columns    []ExportColumnDto `json:"columns"              validate:"required,min=1,max=30,dive"`
+	DateFormat *string           `json:"dateFormat,omitempty" validate:"omitempty,oneof=YYYY-MM-DD DD.MM.YYYY DD/MM/YYYY MM/DD/YYYY"`
+	TimeFormat *string           `json:"timeFormat,omitempty" validate:"omitempty,oneof=HH:mm HH:mm:ss HH.mm"`
+}
+
+// UpdateExportTemplateRequest меняет только переданные поля, колонки заменяются целиком.
+type UpdateExportTemplateRequest struct {
+	Name       *string            `json:"name,omitempty"       validate:"omitempty,min=1,max=100"`
+	Columns    *[]ExportColumnDto `json:"columns,omitempty"    validate:"omitempty,min=1,max=30,dive"`
+	DateFormat *string            `json:"dateFormat,omitempty" validate:"omitempty,oneof=YYYY-MM-DD DD.MM.YYYY DD/MM/YYYY MM/DD/YYYY"`
+	TimeFormat *string            `json:"timeFormat,omitempty" validate:"omitempty,oneof=HH:mm HH:mm:ss HH.mm"`
+}
+
+type ExportTemplateResponse struct {
+	ID         int               `json:"id"`
+	Name       string            `json:"name"`
+	Columns    []ExportColumnDto `json:"columns"`
+	DateFormat string            `json:"dateFormat"`
+	TimeFormat string            `json:"timeFormat"`
+	CreatedAt  time.Time         `json:"createdAt"`
+	UpdatedAt  *time.Time        `json:"updatedAt"`
+}
+
+type ExportTemplatesResponse struct {
+	Data []ExportTemplateResponse `json:"data"`
+}
@@ -2,77 +2,185 @@ package dto
 
 import (
 	"table-api/internal/entitys"
+	"table-api/pkg/utils"
 	"time"
 )
 
 type CreateLectureRequest struct {
-	Group        *string   `json:"group,omitempty"       validate:"omitempty,max=100"`
-	Lector       *string   `json:"lector,omitempty"      validate:"omitempty,max=100"`
-	Platform     *string   `json:"platform,omitempty"    validate:"omitempty,max=100"`
-	Unit         *string   `json:"unit,omitempty"        validate:"omitempty,max=100"`
-	Location     *string   `json:"location,omitempty"    validate:"omitempty,max=150"`
-	URL          *string   `json:"url,omitempty"         validate:"omitempty,url"`
-	ShortURL     *string   `json:"shortUrl,omitempty"    validate:"omitempty,url"`
-	StreamKey    *string   `json:"streamKey,omitempty"   validate:"omitempty,max=100"`
-	Description  *string   `json:"description,omitempty" validate:"omitempty,max=2000"`
-	Admin        *string   `json:"admin,omitempty"       validate:"omitempty,max=100"`
-	Date         time.Time `json:"date"                  validate:"required"`
-	Start        *string   `json:"start,omitempty" validate:"omitempty,max=10"`
-	End          *string   `json:"end,omitempty"   validate:"omitempty,max=10"`
-	AbnormalTime *string   `json:"abnormalTime,omitempty" validate:"omitempty,max=100"`
+	Group        *string      `json:"group,omitempty"       validate:"omitempty,max=100"`
+	Lector       *string      `json:"lector,omitempty"      validate:"omitempty,max=100"`
+	Platform     *string      `json:"platform,omitempty"    validate:"omitempty,max=100"`
+	Unit         *string      `json:"unit,omitempty"        validate:"omitempty,max=100"`
+	Location     *string      `json:"location,omitempty"    validate:"omitempty,max=150"`
+	URL          *string      `json:"url,omitempty"         validate:"omitempty,url"`
+	ShortURL     *string      `json:"shortUrl,omitempty"    validate:"omitempty,url"`
+	StreamKey    *string      `json:"streamKey,omitempty"   validate:"omitempty,max=100"`
+	Description  *string      `json:"description,omitempty" validate:"omitempty,max=2000"`
+	Admin        *string      `json:"admin,omitempty"       validate:"omitempty,max=100"`
+	Date         time.Time    `json:"date"                  validate:"required"`
+	Start        *utils.Clock `json:"start,omitempty" validate:"omitempty"`
+	End          *utils.Clock `json:"end,omitempty"   validate:"omitempty"`
+	AbnormalTime *string      `json:"abnormalTime,omitempty" validate:"omitempty,max=100"`
 }
 
 type CreateLecturesRequest struct {
 	Lectures []CreateLectureRequest `json:"lectures" validate:"required,dive"`
 }
 
+// CreateLectureResult — итог по одной лекции пакета; index — её позиция в запросе.
+type CreateLectureResult struct {
+	Index     int                       `json:"index"`
+	Status    string                    `json:"status"`
+	Lecture   *LectureResponse          `json:"lecture,omitempty"`
+	Errors    []string                  `json:"errors,omitempty"`
+	Conflicts []entitys.LectureConflict `json:"conflicts,omitempty"`
+}
+
+// CreateLecturesResponse — ответ пакетного создания в режиме partial.
+type CreateLecturesResponse struct {
+	Total   int                   `json:"total"`
+	Created int                   `json:"created"`
+	Failed  int                   `json:"failed"`
+	Results []CreateLectureResult `json:"results"`
+}
+
 type UpdateLectureRequest struct {
-	Group        *string    `json:"group,omitempty"       validate:"omitempty,max=100"`
-	Lector       *string    `json:"lector,omitempty"      validate:"omitempty,max=100"`
-	Platform     *string    `json:"platform,omitempty"    validate:"omitempty,max=100"`
-	Unit         *string    `json:"unit,omitempty"        validate:"omitempty,max=100"`
-	Location     *string    `json:"location,omitempty"    validate:"omitempty,max=150"`
-	URL          *string    `json:"url,omitempty"         validate:"omitempty,url"`
-	ShortURL     *string    `json:"shortUrl,omitempty"    validate:"omitempty,url"`
-	StreamKey    *string    `json:"streamKey,omitempty"   validate:"omitempty,max=100"`
-	Description  *string    `json:"description,omitempty" validate:"omitempty,max=2000"`
-	Admin        *string    `json:"admin,omitempty"       validate:"omitempty,max=100"`
-	Date         *time.Time `json:"date,omitempty"        validate:"omitempty"`
-	Start        *string    `json:"start,omitempty"       validate:"omitempty"`
-	End          *string    `json:"end,omitempty"         validate:"omitempty"`
-	AbnormalTime *string    `json:"abnormalTime,omitempty" validate:"omitempty,max=100"`
+	Group        *string      `json:"group,omitempty"       validate:"omitempty,max=100"`
+	Lector       *string      `json:"lector,omitempty"      validate:"omitempty,max=100"`
+	Platform     *string      `json:"platform,omitempty"    validate:"omitempty,max=100"`
+	Unit         *string      `json:"unit,omitempty"        validate:"omitempty,max=100"`
+	Location     *string      `json:"location,omitempty"    validate:"omitempty,max=150"`
+	URL          *string      `json:"url,omitempty"         validate:"omitempty,url"`
+	ShortURL     *string      `json:"shortUrl,omitempty"    validate:"omitempty,url"`
+	StreamKey    *string      `json:"streamKey,omitempty"   validate:"omitempty,max=100"`
+	Description  *string      `json:"description,omitempty" validate:"omitempty,max=2000"`
+	Admin        *string      `json:"admin,omitempty"       validate:"omitempty,max=100"`
+	Date         *time.Time   `json:"date,omitempty"        validate:"omitempty"`
+	Start        *utils.Clock `json:"start,omitempty"       validate:"omitempty"`
+	End          *utils.Clock `json:"end,omitempty"         validate:"omitempty"`
+	AbnormalTime *string      `json:"abnormalTime,omitempty" validate:"omitempty,max=100"`
+}
+
+type GetQueryLectureDto struct {
+	Group    *string `validate:"omitempty,max=100"`
+	Lector   *string `validate:"omitempty,max=100"`
+	Platform *string `validate:"omitempty,max=100"`
+	Unit     *string `validate:"omitempty,max=100"`
+	Location *string `validate:"omitempty,max=150"`
+	Admin    *string `validate:"omitempty,max=100"`
+
+	DateFrom *time.Time
+	DateTo   *time.Time
+
+	NoURL       bool
+	NoStreamKey bool
+
+	Search *string `validate:"omitempty,max=200"`
+
+	SortBy *string `validate:"omitempty,oneof=date start end group lector platform unit location admin createdAt updatedAt"`
+
+	Order *SortOrder `validate:"omitempty,oneof=asc desc"`
 }
 
 type LectureResponse struct {
-	ID           int       `json:"id"`
-	Group        *string   `json:"group"`
-	Lector       *string   `json:"lector"`
-	Platform     *string   `json:"platform"`
-	Unit         *string   `json:"unit"`
-	Location     *string   `json:"location"`
-	URL          *string   `json:"url"`
-	ShortURL     *string   `json:"shortUrl"`
-	StreamKey    *string   `json:"streamKey"`
-	Description  *string   `json:"description"`
-	Admin        *string   `json:"admin"`
-	Date         time.Time `json:"date"`
-	Start        *string   `json:"start"`
-	End          *string   `json:"end"`
-	AbnormalTime *string   `json:"abnormalTime"`
+	ID           int          `json:"id"`
+	Group        *string      `json:"group"`
+	Lector       *string      `json:"lector"`
+	Platform     *string      `json:"platform"`
+	Unit         *string      `json:"unit"`
+	Location     *string      `json:"location"`
+	URL          *string      `json:"url"`
+	ShortURL     *string      `json:"shortUrl"`
+	StreamKey    *string      `json:"streamKey"`
+	Description  *string      `json:"description"`
+	Admin        *string      `json:"admin"`
+	AdminID      *string      `json:"adminId"`
+	Date         time.Time    `json:"date"`
+	Start        *utils.Clock `json:"start"`
+	End          *utils.Clock `json:"end"`
+	AbnormalTime *string      `json:"abnormalTime"`
+	GroupID      *int         `json:"groupId"`
+	LectorID     *int         `json:"lectorId"`
+	UnitID       *int         `json:"unitId"`
+	LocationID   *int         `json:"locationId"`
+	SeriesID     *int         `json:"seriesId"`
+	// Передаётся в If-Match при правке
+	Version int `json:"version"`
 
 	CreatedAt time.Time  `json:"createdAt"`
 	UpdatedAt *time.Time `json:"updatedAt"`
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+
+	Warnings []string `json:"warnings,omitempty"`
 }
 
+// UpdateManyLinksRequest проставляет ссылку лекциям группы. Без dateFrom
+// затрагиваются лекции с сегодняшнего дня, прошедшие остаются со своими ссылками.
+// skipLinked пропускает лекции, у которых ссылка уже есть.
 type UpdateManyLinksRequest struct {
-	GroupName string `json:"groupName"`
-	Url       string `json:"url"`
+	GroupName  string     `json:"groupName"            validate:"required,max=100"`
+	Url        string     `json:"url"                  validate:"required,url"`
+	DateFrom   *time.Time `json:"dateFrom,omitempty"`
+	DateTo     *time.Time `json:"dateTo,omitempty"`
+	Lector     *string    `json:"lector,omitempty"     validate:"omitempty,max=100"`
+	Platform   *string    `json:"platform,omitempty"   validate:"omitempty,max=100"`
+	SkipLinked bool       `json:"skipLinked,omitempty"`
+}
+
+// UndoLinksRequest отменяет массовую простановку ссылки с operationId из её ответа.
+type UndoLinksRequest struct {
+	OperationID int `json:"operationId" validate:"required,min=1"`
+}
+
+type LinkValuesResponse struct {
+	URL      *string `json:"url"`
+	ShortURL *string `json:"shortUrl"`
 }
 
+type LinkDiffRowResponse struct {
+	LectureID int                `json:"lectureId"`
+	Date      time.Time          `json:"date"`
+	Group     *string            `json:"group"`
+	Lector    *string            `json:"lector"`
+	Platform  *string            `json:"platform"`
+	Before    LinkValuesResponse `json:"before"`
+	After     LinkValuesResponse `json:"after"`
+}
+
+// LinkOperationResponse — изменённые лекции. operationId передаётся, если операцию можно отменить.
+type LinkOperationResponse struct {
+	OperationID *int                  `json:"operationId"`
+	DryRun      bool                  `json:"dryRun"`
+	Count       int                   `json:"count"`
+	Skipped     int                   `json:"skipped"`
+	Changes     []LinkDiffRowResponse `json:"changes"`
+}
+
+// Раскладка книги выгрузки
+type ExportProfile string
+
+const (
+	ExportProfileFlat   ExportProfile = "flat"
+	ExportProfileDays   ExportProfile = "days"
+	ExportProfileGroups ExportProfile = "groups"
+)
+
+// Формат файла выгрузки
+type ExportFormat string
+
+const (
+	ExportFormatXLSX ExportFormat = "xlsx"
+	ExportFormatCSV  ExportFormat = "csv"
+)
+
 type ExportLecturesExcelRequest struct {
 	Group     *string
 	StartDate time.Time
 	EndDate   time.Time
+	Profile   ExportProfile `validate:"required,oneof=flat days groups"`
+	Format    ExportFormat  `validate:"required,oneof=xlsx csv"`
+	// Имя шаблона колонок; пусто — встроенный шаблон
+	Template *string `validate:"omitempty,max=100"`
 }
 
 type AvailableDatesReponse struct {
@@ -82,3 +190,26 @@ type AvailableDatesReponse struct {
 type DailySchedulesResponse struct {
 	Data []*entitys.DailySchedule `json:"data"`
 }
+
+type LectureConflictsResponse struct {
+	Data []entitys.LectureConflict `json:"data"`
+}
+
+type ImportLectureRow struct {
+	Row       int                       `json:"row"`
+	ID        int                       `json:"id,omitempty"`
+	Status    string                    `json:"status"`
+	Errors    []string                  `json:"errors,omitempty"`
+	Conflicts []entitys.LectureConflict `json:"conflicts,omitempty"`
+	Lecture   *CreateLectureRequest     `json:"lecture,omitempty"`
+}
+
+type ImportLecturesResponse struct {
+	DryRun  bool               `json:"dryRun"`
+	Total   int                `json:"total"`
+	Valid   int                `json:"valid"`
+	Invalid int                `json:"invalid"`
+	Created int                `json:"created"`
+	Updated int                `json:"updated"`
+	Rows    []ImportLectureRow `json:"rows"`
+}
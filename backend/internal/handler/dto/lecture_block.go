@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"table-api/internal/entitys"
+	"time"
+)
+
+// CopyLecturesRequest копирует лекции за период источника в целевой период.
+// Даты сдвигаются на целое число недель, поэтому день недели сохраняется.
+type CopyLecturesRequest struct {
+	SourceFrom     time.Time  `json:"sourceFrom"          validate:"required"`
+	SourceTo       time.Time  `json:"sourceTo"            validate:"required"`
+	TargetFrom     time.Time  `json:"targetFrom"          validate:"required"`
+	TargetTo       *time.Time `json:"targetTo,omitempty"`
+	Group          *string    `json:"group,omitempty"     validate:"omitempty,max=100"`
+	KeepURLs       bool       `json:"keepUrls"`
+	KeepStreamKeys bool       `json:"keepStreamKeys"`
+	KeepAdmins     bool       `json:"keepAdmins"`
+}
+
+// ShiftLecturesRequest переносит лекции за период на заданное число дней.
+type ShiftLecturesRequest struct {
+	From  time.Time `json:"from"            validate:"required"`
+	To    time.Time `json:"to"              validate:"required"`
+	Group *string   `json:"group,omitempty" validate:"omitempty,max=100"`
+	Days  int       `json:"days"            validate:"required,min=-366,max=366"`
+}
+
+type LectureBlockResponse struct {
+	DryRun     bool                      `json:"dryRun"`
+	OffsetDays int                       `json:"offsetDays"`
+	Count      int                       `json:"count"`
+	Lectures   []LectureResponse         `json:"lectures"`
+	Conflicts  []entitys.LectureConflict `json:"conflicts,omitempty"`
+}
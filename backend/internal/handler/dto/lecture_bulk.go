@@ -0,0 +1,31 @@
+package dto
+
+import "time"
+
+// BulkLectureFilter выбирает лекции для массовой операции.
+// Строковые поля сравниваются без учёта регистра и пробелов по краям.
+type BulkLectureFilter struct {
+	Group    *string    `json:"group,omitempty"    validate:"omitempty,max=100"`
+	Lector   *string    `json:"lector,omitempty"   validate:"omitempty,max=100"`
+	Location *string    `json:"location,omitempty" validate:"omitempty,max=150"`
+	Platform *string    `json:"platform,omitempty" validate:"omitempty,max=100"`
+	DateFrom *time.Time `json:"dateFrom,omitempty"`
+	DateTo   *time.Time `json:"dateTo,omitempty"`
+}
+
+type BulkUpdateLecturesRequest struct {
+	IDs    []int                `json:"ids,omitempty"    validate:"omitempty,max=1000,dive,gt=0"`
+	Filter *BulkLectureFilter   `json:"filter,omitempty"`
+	Update UpdateLectureRequest `json:"update"           validate:"required"`
+}
+
+type BulkDeleteLecturesRequest struct {
+	IDs    []int              `json:"ids,omitempty"    validate:"omitempty,max=1000,dive,gt=0"`
+	Filter *BulkLectureFilter `json:"filter,omitempty"`
+}
+
+type BulkLecturesResponse struct {
+	DryRun   bool              `json:"dryRun"`
+	Count    int               `json:"count"`
+	Lectures []LectureResponse `json:"lectures"`
+}
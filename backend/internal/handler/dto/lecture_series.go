@@ -0,0 +1,61 @@
+package dto
+
+import (
+	"table-api/pkg/utils"
+	"time"
+)
+
+type SeriesScope string
+
+const (
+	SeriesScopeThis      SeriesScope = "this"
+	SeriesScopeFollowing SeriesScope = "following"
+	SeriesScopeAll       SeriesScope = "all"
+)
+
+type SeriesScopeQuery struct {
+	Scope SeriesScope `validate:"required,oneof=this following all"`
+}
+
+type CreateLectureSeriesRequest struct {
+	RRule        string       `json:"rrule"                 validate:"required,max=255"`
+	StartDate    time.Time    `json:"startDate"             validate:"required"`
+	ExDates      []time.Time  `json:"exDates,omitempty"`
+	Group        *string      `json:"group,omitempty"       validate:"omitempty,max=100"`
+	Lector       *string      `json:"lector,omitempty"      validate:"omitempty,max=100"`
+	Platform     *string      `json:"platform,omitempty"    validate:"omitempty,max=100"`
+	Unit         *string      `json:"unit,omitempty"        validate:"omitempty,max=100"`
+	Location     *string      `json:"location,omitempty"    validate:"omitempty,max=150"`
+	URL          *string      `json:"url,omitempty"         validate:"omitempty,url"`
+	StreamKey    *string      `json:"streamKey,omitempty"   validate:"omitempty,max=100"`
+	Description  *string      `json:"description,omitempty" validate:"omitempty,max=2000"`
+	Admin        *string      `json:"admin,omitempty"       validate:"omitempty,max=100"`
+	Start        *utils.Clock `json:"start,omitempty"       validate:"omitempty"`
+	End          *utils.Clock `json:"end,omitempty"         validate:"omitempty"`
+	AbnormalTime *string      `json:"abnormalTime,omitempty" validate:"omitempty,max=100"`
+}
+
+type LectureSeriesResponse struct {
+	ID           int          `json:"id"`
+	RRule        string       `json:"rrule"`
+	StartDate    time.Time    `json:"startDate"`
+	ExDates      []time.Time  `json:"exDates"`
+	Group        *string      `json:"group"`
+	Lector       *string      `json:"lector"`
+	Platform     *string      `json:"platform"`
+	Unit         *string      `json:"unit"`
+	Location     *string      `json:"location"`
+	URL          *string      `json:"url"`
+	ShortURL     *string      `json:"shortUrl"`
+	StreamKey    *string      `json:"streamKey"`
+	Description  *string      `json:"description"`
+	Admin        *string      `json:"admin"`
+	Start        *utils.Clock `json:"start"`
+	End          *utils.Clock `json:"end"`
+	AbnormalTime *string      `json:"abnormalTime"`
+
+	Lectures []LectureResponse `json:"lectures"`
+
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt *time.Time `json:"updatedAt"`
+}
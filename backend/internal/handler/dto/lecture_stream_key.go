@@ -0,0 +1,6 @@
+package dto
+
+type StreamKeyResponse struct {
+	LectureID int    `json:"lectureId"`
+	StreamKey string `json:"streamKey"`
+}
@@ -1,6 +1,9 @@
 package dto
 
-import "time"
+import (
+	"table-api/pkg/timezone"
+	"time"
+)
 
 type Status string
 
@@ -34,8 +37,9 @@ type CreateMeetRequest struct {
 
 	Admin *string `json:"admin,omitempty" validate:"omitempty,max=100"`
 
-	Start *time.Time `json:"start,omitempty" validate:"omitempty"`
-	End   *time.Time `json:"end,omitempty"   validate:"omitempty"`
+	// Время без смещения читается в поясе места проведения
+	Start *timezone.Time `json:"start,omitempty" validate:"omitempty"`
+	End   *timezone.Time `json:"end,omitempty"   validate:"omitempty"`
 }
 
 type UpdateMeetRequest struct {
@@ -53,8 +57,8 @@ type UpdateMeetRequest struct {
 	Description *string `json:"description,omitempty" validate:"omitempty,max=2000"`
 	Admin       *string `json:"admin,omitempty"       validate:"omitempty,max=100"`
 
-	Start *time.Time `json:"start,omitempty"`
-	End   *time.Time `json:"end,omitempty"`
+	Start *timezone.Time `json:"start,omitempty"`
+	End   *timezone.Time `json:"end,omitempty"`
 }
 
 type MeetResponse struct {
@@ -72,10 +76,16 @@ type MeetResponse struct {
 	Status      *string `json:"status"`
 	Description *string `json:"description"`
 
-	Admin *string `json:"admin"`
+	Admin   *string `json:"admin"`
+	AdminID *string `json:"adminId"`
+	// Передаётся в If-Match при правке
+	Version int `json:"version"`
 
 	Start     *time.Time `json:"start"`
 	End       *time.Time `json:"end"`
 	CreatedAt time.Time  `gorm:"createdAt"`
 	UpdatedAt *time.Time `gorm:"updatedAt"`
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+
+	Warnings []string `json:"warnings,omitempty"`
 }
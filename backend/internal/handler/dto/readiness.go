@@ -0,0 +1,28 @@
+package dto
+
+import "time"
+
+type ReadinessIssueResponse struct {
+	Rule     string   `json:"rule"`
+	Severity string   `json:"severity"`
+	Message  string   `json:"message"`
+	Details  []string `json:"details,omitempty"`
+}
+
+type ReadinessItemResponse struct {
+	Kind   string                   `json:"kind"`
+	ID     int                      `json:"id"`
+	Title  string                   `json:"title"`
+	Date   time.Time                `json:"date"`
+	Time   string                   `json:"time,omitempty"`
+	Admin  string                   `json:"admin,omitempty"`
+	Issues []ReadinessIssueResponse `json:"issues"`
+}
+
+type ReadinessResponse struct {
+	Start   time.Time               `json:"start"`
+	End     time.Time               `json:"end"`
+	Checked int                     `json:"checked"`
+	Count   int                     `json:"count"`
+	Data    []ReadinessItemResponse `json:"data"`
+}
@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"table-api/pkg/utils"
+	"time"
+)
+
+type CreateRoomRequest struct {
+	Location  string   `json:"location"            validate:"required,max=150"`
+	Building  *string  `json:"building,omitempty"  validate:"omitempty,max=100"`
+	Capacity  int      `json:"capacity"            validate:"gte=0,lte=10000"`
+	Equipment []string `json:"equipment,omitempty" validate:"omitempty,max=50,dive,required,max=100"`
+	Platforms []string `json:"platforms,omitempty" validate:"omitempty,max=20,dive,required,max=100"`
+	TimeZone  *string  `json:"timeZone,omitempty"  validate:"omitempty,timezone"`
+}
+
+// UpdateRoomRequest меняет только переданные поля, списки заменяются целиком.
+type UpdateRoomRequest struct {
+	Location  *string   `json:"location,omitempty"  validate:"omitempty,min=1,max=150"`
+	Building  *string   `json:"building,omitempty"  validate:"omitempty,max=100"`
+	Capacity  *int      `json:"capacity,omitempty"  validate:"omitempty,gte=0,lte=10000"`
+	Equipment *[]string `json:"equipment,omitempty" validate:"omitempty,max=50,dive,required,max=100"`
+	Platforms *[]string `json:"platforms,omitempty" validate:"omitempty,max=20,dive,required,max=100"`
+	// Пустая строка возвращает месту пояс организации
+	TimeZone *string `json:"timeZone,omitempty" validate:"omitempty,timezone"`
+}
+
+// GetQueryRoomDto — требования к аудитории. Если задан слот (date, start, end),
+// возвращаются только аудитории, свободные от лекций и мероприятий в это время.
+type GetQueryRoomDto struct {
+	Building    *string  `validate:"omitempty,max=100"`
+	MinCapacity *int     `validate:"omitempty,gte=0"`
+	Equipment   []string `validate:"omitempty,max=50,dive,required,max=100"`
+	Platform    *string  `validate:"omitempty,max=100"`
+
+	Date  *time.Time
+	Start *utils.Clock
+	End   *utils.Clock
+}
+
+type RoomResponse struct {
+	ID         int        `json:"id"`
+	LocationID int        `json:"locationId"`
+	Location   string     `json:"location"`
+	Building   *string    `json:"building"`
+	Capacity   int        `json:"capacity"`
+	Equipment  []string   `json:"equipment"`
+	Platforms  []string   `json:"platforms"`
+	TimeZone   *string    `json:"timeZone"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	UpdatedAt  *time.Time `json:"updatedAt"`
+}
+
+type RoomsResponse struct {
+	Data []RoomResponse `json:"data"`
+}
@@ -0,0 +1,39 @@
+package dto
+
+import "time"
+
+// Разрез статистики лекций
+type StatsDimension string
+
+const (
+	StatsByLector   StatsDimension = "lector"
+	StatsByGroup    StatsDimension = "group"
+	StatsByPlatform StatsDimension = "platform"
+	StatsByUnit     StatsDimension = "unit"
+	StatsByMonth    StatsDimension = "month"
+)
+
+type LectureStatsRequest struct {
+	Group     *string
+	StartDate time.Time
+	EndDate   time.Time
+	By        []StatsDimension `validate:"max=5,unique,dive,oneof=lector group platform unit month"`
+	// Пустой формат — ответ JSON
+	Format ExportFormat `validate:"omitempty,oneof=xlsx csv"`
+}
+
+// LectureStatRow — значения разрезов в порядке By; null — значение не указано.
+type LectureStatRow struct {
+	Keys     map[StatsDimension]*string `json:"keys"`
+	Lectures int64                      `json:"lectures"`
+	Hours    float64                    `json:"hours"`
+}
+
+type LectureStatsResponse struct {
+	Start    time.Time        `json:"start"`
+	End      time.Time        `json:"end"`
+	By       []StatsDimension `json:"by"`
+	Lectures int64            `json:"lectures"`
+	Hours    float64          `json:"hours"`
+	Data     []LectureStatRow `json:"data"`
+}
@@ -0,0 +1,76 @@
+package dto
+
+import (
+	"table-api/pkg/utils"
+	"time"
+)
+
+// AvailabilityDto — окно доступности техника: день недели (0 — воскресенье)
+// и время суток в поясе организации.
+type AvailabilityDto struct {
+	Weekday int         `json:"weekday" validate:"min=0,max=6"`
+	Start   utils.Clock `json:"start"`
+	End     utils.Clock `json:"end"`
+}
+
+// SetAvailabilityRequest заменяет все окна техника; пустой список снимает ограничения.
+type SetAvailabilityRequest struct {
+	Slots []AvailabilityDto `json:"slots" validate:"max=50,dive"`
+}
+
+type AvailabilityResponse struct {
+	UserID string            `json:"userId"`
+	Slots  []AvailabilityDto `json:"slots"`
+}
+
+// AutoAssignRequest раздаёт техникам лекции и мероприятия без админа за период.
+type AutoAssignRequest struct {
+	From time.Time `json:"from" validate:"required"`
+	To   time.Time `json:"to"   validate:"required"`
+}
+
+type WorkloadWeekResponse struct {
+	Week     string    `json:"week"`
+	Start    time.Time `json:"start"`
+	Lectures int       `json:"lectures"`
+	Meets    int       `json:"meets"`
+	Hours    float64   `json:"hours"`
+}
+
+type AdminWorkloadResponse struct {
+	AdminID  *string                `json:"adminId"`
+	Admin    string                 `json:"admin"`
+	Lectures int                    `json:"lectures"`
+	Meets    int                    `json:"meets"`
+	Hours    float64                `json:"hours"`
+	Weeks    []WorkloadWeekResponse `json:"weeks"`
+}
+
+type WorkloadResponse struct {
+	Start      time.Time               `json:"start"`
+	End        time.Time               `json:"end"`
+	Admins     []AdminWorkloadResponse `json:"admins"`
+	Unassigned []WorkloadWeekResponse  `json:"unassigned"`
+}
+
+type AssignmentResponse struct {
+	Kind    string    `json:"kind"`
+	ID      int       `json:"id"`
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	AdminID string    `json:"adminId"`
+	Admin   string    `json:"admin"`
+}
+
+type SkippedAssignmentResponse struct {
+	Kind   string `json:"kind"`
+	ID     int    `json:"id"`
+	Reason string `json:"reason"`
+}
+
+type AutoAssignResponse struct {
+	DryRun   bool                        `json:"dryRun"`
+	Count    int                         `json:"count"`
+	Assigned []AssignmentResponse        `json:"assigned"`
+	Skipped  []SkippedAssignmentResponse `json:"skipped"`
+}
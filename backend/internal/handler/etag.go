@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var errInvalidIfMatch = errors.New("invalid If-Match")
+
+// setETag отдаёт версию записи заголовком ETag.
+func setETag(w http.ResponseWriter, version int) {
+	w.Header().Set("ETag", `"`+strconv.Itoa(version)+`"`)
+}
+
+// ifMatch читает из If-Match версию, которую клиент видел перед правкой.
+// Без заголовка или со "*" версия не проверяется.
+func ifMatch(r *http.Request) (*int, error) {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	if value == "" || value == "*" {
+		return nil, nil
+	}
+
+	value = strings.TrimPrefix(value, "W/")
+	if len(value) < 2 || value[0] != '"' || value[len(value)-1] != '"' {
+		return nil, errInvalidIfMatch
+	}
+
+	version, err := strconv.Atoi(value[1 : len(value)-1])
+	if err != nil {
+		return nil, errInvalidIfMatch
+	}
+
+	return &version, nil
+}
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"table-api/internal/handler/dto"
+	"table-api/internal/mappers"
+	"table-api/internal/models"
+	httprespond "table-api/pkg/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type ExportTemplateService interface {
+	Create(ctx context.Context, dto dto.CreateExportTemplateRequest) (*models.ExportTemplate, error)
+	Get(ctx context.Context, id int) (*models.ExportTemplate, error)
+	List(ctx context.Context) ([]*models.ExportTemplate, error)
+	Update(ctx context.Context, id int, dto dto.UpdateExportTemplateRequest) (*models.ExportTemplate, error)
+	Remove(ctx context.Context, id int) (*models.ExportTemplate, error)
+}
+
+type ExportTemplateHandlers struct {
+	templateService ExportTemplateService
+}
+
+func NewExportTemplateHandlers(s ExportTemplateService) *ExportTemplateHandlers {
+	return &ExportTemplateHandlers{templateService: s}
+}
+
+func (h *ExportTemplateHandlers) Create(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+
+	var req dto.CreateExportTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httprespond.ErrorResponse(w, "Bad request", http.StatusBadRequest)
+		return
+	}
+
+	if message, err := dto.Validate(req); err != nil {
+		httprespond.ErrorResponse(w, message, http.StatusBadRequest)
+		return
+	}
+
+	template, err := h.templateService.Create(ctx, req)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	httprespond.JsonResponse(w, mappers.ExportTemplateToDto(template), http.StatusCreated)
+}
+
+func (h *ExportTemplateHandlers) FindMany(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	templates, err := h.templateService.List(r.Context())
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	httprespond.JsonResponse(w, dto.ExportTemplatesResponse{Data: mappers.ExportTemplatesToDto(templates)}, http.StatusOK)
+}
+
+func (h *ExportTemplateHandlers) Get(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		httprespond.ErrorResponse(w, "Invalid template ID", http.StatusBadRequest)
+		return
+	}
+
+	template, err := h.templateService.Get(ctx, id)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	httprespond.JsonResponse(w, mappers.ExportTemplateToDto(template), http.StatusOK)
+}
+
+func (h *ExportTemplateHandlers) Update(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		httprespond.ErrorResponse(w, "Invalid template ID", http.StatusBadRequest)
+		return
+	}
+
+	var req dto.UpdateExportTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httprespond.ErrorResponse(w, "Bad request", http.StatusBadRequest)
+		return
+	}
+
+	if message, err := dto.Validate(req); err != nil {
+		httprespond.ErrorResponse(w, message, http.StatusBadRequest)
+		return
+	}
+
+	template, err := h.templateService.Update(ctx, id, req)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	httprespond.JsonResponse(w, mappers.ExportTemplateToDto(template), http.StatusOK)
+}
+
+func (h *ExportTemplateHandlers) Remove(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		httprespond.ErrorResponse(w, "Invalid template ID", http.StatusBadRequest)
+		return
+	}
+
+	template, err := h.templateService.Remove(ctx, id)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	httprespond.JsonResponse(w, mappers.ExportTemplateToDto(template), http.StatusOK)
+}
@@ -3,14 +3,20 @@ package handler
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
+	"log/slog"
 	"net/http"
 	"strconv"
+	"strings"
 	"table-api/internal/entitys"
 	"table-api/internal/handler/dto"
 	"table-api/internal/mappers"
 	"table-api/internal/models"
+	"table-api/internal/visibility"
+	common "table-api/pkg"
 	httprespond "table-api/pkg/http"
+	"table-api/pkg/timezone"
 	"table-api/pkg/utils"
 	"time"
 
@@ -18,23 +24,54 @@ import (
 )
 
 type LectureService interface {
-	Create(ctx context.Context, dto dto.CreateLectureRequest) (*models.Lecture, error)
-	CreateMany(ctx context.Context, dtos []dto.CreateLectureRequest) ([]*models.Lecture, error)
-	CreateManyLinks(ctx context.Context, dto dto.UpdateManyLinksRequest) ([]*models.Lecture, error)
+	Create(ctx context.Context, dto dto.CreateLectureRequest, force bool) (*models.Lecture, error)
+	CreateMany(ctx context.Context, dtos []dto.CreateLectureRequest, force bool) ([]*models.Lecture, error)
+	CreateManyPartial(ctx context.Context, dtos []dto.CreateLectureRequest, force bool) ([]entitys.LectureBatchRow, error)
+	CreateManyLinks(ctx context.Context, dto dto.UpdateManyLinksRequest, dryRun bool) (*entitys.LinkDiff, error)
+	UndoLinks(ctx context.Context, operationID int) (*entitys.LinkDiff, error)
 	GetDates(ctx context.Context) (*entitys.LectureDates, error)
 	GetSchedule(ctx context.Context, year, month int) ([]*entitys.DailySchedule, error)
 	GetByDate(ctx context.Context, date time.Time) ([]*models.Lecture, error)
-	Update(ctx context.Context, id int, dto dto.UpdateLectureRequest) (*models.Lecture, error)
-	Export(ctx context.Context, filter dto.ExportLecturesExcelRequest, writer io.Writer) error
+	List(ctx context.Context, page, limit int, filter dto.GetQueryLectureDto) ([]*models.Lecture, *entitys.Pagination, error)
+	Update(ctx context.Context, id int, version *int, dto dto.UpdateLectureRequest, force bool) (*models.Lecture, error)
+	Export(ctx context.Context, filter dto.ExportLecturesExcelRequest, view visibility.View, writer io.Writer) error
 	Remove(ctx context.Context, id int) (*models.Lecture, error)
+	CreateSeries(ctx context.Context, dto dto.CreateLectureSeriesRequest, force bool) (*models.LectureSeries, []*models.Lecture, error)
+	GetSeries(ctx context.Context, id int) (*models.LectureSeries, []*models.Lecture, error)
+	UpdateSeries(ctx context.Context, id int, scope dto.SeriesScope, dto dto.UpdateLectureRequest, force bool) ([]*models.Lecture, error)
+	RemoveSeries(ctx context.Context, id int, scope dto.SeriesScope) ([]*models.Lecture, error)
+	FindConflicts(ctx context.Context, start, end time.Time) ([]entitys.LectureConflict, error)
+	Stats(ctx context.Context, filter dto.LectureStatsRequest) ([]entitys.LectureStat, error)
+	ExportStats(ctx context.Context, filter dto.LectureStatsRequest, writer io.Writer) error
+	Import(ctx context.Context, reader io.Reader, dryRun bool, force bool) (*dto.ImportLecturesResponse, error)
+	ListTrash(ctx context.Context, page, limit int) ([]*models.Lecture, *entitys.Pagination, error)
+	Restore(ctx context.Context, id int, force bool) (*models.Lecture, error)
+	Purge(ctx context.Context, id int) (*models.Lecture, error)
+	BulkUpdate(ctx context.Context, dto dto.BulkUpdateLecturesRequest, dryRun bool, force bool) ([]*models.Lecture, error)
+	BulkRemove(ctx context.Context, dto dto.BulkDeleteLecturesRequest, dryRun bool) ([]*models.Lecture, error)
+	CopyBlock(ctx context.Context, dto dto.CopyLecturesRequest, dryRun bool, force bool) (*entitys.LectureBlock, error)
+	ShiftBlock(ctx context.Context, dto dto.ShiftLecturesRequest, dryRun bool, force bool) (*entitys.LectureBlock, error)
+	RevealStreamKey(ctx context.Context, id int) (*models.Lecture, error)
+	RotateStreamKey(ctx context.Context, id int) (*models.Lecture, error)
+	RotateGroupStreamKeys(ctx context.Context, group string) ([]*models.Lecture, error)
 }
 
 type LectureHandlers struct {
 	lectureService LectureService
+	visibility     *visibility.Policy
+	logger         *slog.Logger
 }
 
-func NewLectureHandlers(s LectureService) *LectureHandlers {
-	return &LectureHandlers{lectureService: s}
+func NewLectureHandlers(s LectureService, policy *visibility.Policy, logger *slog.Logger) *LectureHandlers {
+	return &LectureHandlers{lectureService: s, visibility: policy, logger: logger}
+}
+
+// Максимальный размер загружаемой книги
+const maxImportSize = 10 << 20
+
+// isForced сообщает, что модератор явно разрешил сохранить лекцию несмотря на пересечения
+func isForced(r *http.Request) bool {
+	return r.URL.Query().Get("force") == "true"
 }
 
 func (l *LectureHandlers) Create(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
@@ -51,13 +88,13 @@ func (l *LectureHandlers) Create(w http.ResponseWriter, r *http.Request, _ httpr
 		return
 	}
 
-	newLecture, err := l.lectureService.Create(ctx, req)
+	newLecture, err := l.lectureService.Create(ctx, req, isForced(r))
 	if err != nil {
 		httprespond.HandleErrorResponse(w, err)
 		return
 	}
 
-	resp := mappers.LectureToDto(newLecture)
+	resp := mappers.LectureToDto(newLecture, l.view(ctx))
 	httprespond.JsonResponse(w, resp, 201)
 }
 
@@ -70,18 +107,43 @@ func (l *LectureHandlers) CreateMany(w http.ResponseWriter, r *http.Request, _ h
 		return
 	}
 
+	// В режиме partial лекции проверяются по одной, и ошибки попадают в итог строки
+	if r.URL.Query().Get("partial") == "true" {
+		if len(req.Lectures) == 0 {
+			httprespond.ErrorResponse(w, "Lectures are required", http.StatusBadRequest)
+			return
+		}
+
+		rows, err := l.lectureService.CreateManyPartial(ctx, req.Lectures, isForced(r))
+		if err != nil {
+			httprespond.HandleErrorResponse(w, err)
+			return
+		}
+
+		resp := mappers.LectureBatchToDto(rows, l.view(ctx))
+
+		// Если не создано ни одной лекции, ответ 200: итоги строк в теле
+		status := http.StatusCreated
+		if resp.Created == 0 {
+			status = http.StatusOK
+		}
+
+		httprespond.JsonResponse(w, resp, status)
+		return
+	}
+
 	if message, err := dto.Validate(req); err != nil {
 		httprespond.ErrorResponse(w, message, http.StatusBadRequest)
 		return
 	}
 
-	newLectures, err := l.lectureService.CreateMany(ctx, req.Lectures)
+	newLectures, err := l.lectureService.CreateMany(ctx, req.Lectures, isForced(r))
 	if err != nil {
 		httprespond.HandleErrorResponse(w, err)
 		return
 	}
 
-	resp := mappers.ManyLectureToDto(newLectures)
+	resp := mappers.ManyLectureToDto(newLectures, l.view(ctx))
 	httprespond.JsonResponse(w, resp, 201)
 }
 
@@ -102,7 +164,7 @@ func (l *LectureHandlers) GetByDates(w http.ResponseWriter, r *http.Request, ps
 	ctx := r.Context()
 
 	dateStr := ps.ByName("date")
-	parsedDate, err := time.Parse("2006-01-02", dateStr)
+	parsedDate, err := timezone.ParseDate(dateStr)
 	if err != nil {
 		httprespond.ErrorResponse(w, "Bad request", http.StatusBadRequest)
 		return
@@ -114,10 +176,95 @@ func (l *LectureHandlers) GetByDates(w http.ResponseWriter, r *http.Request, ps
 		return
 	}
 
-	resp := mappers.ManyLectureToDto(data)
+	resp := mappers.ManyLectureToDto(data, l.view(ctx))
 	httprespond.JsonResponse(w, resp, 200)
 }
 
+func (l *LectureHandlers) FindMany(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+	q := r.URL.Query()
+
+	pageInt, err1 := strconv.Atoi(q.Get("page"))
+	limitInt, err2 := strconv.Atoi(q.Get("limit"))
+	if err1 != nil || err2 != nil {
+		httprespond.ErrorResponse(w, "Page and limit must be int", http.StatusBadRequest)
+		return
+	}
+
+	var filters dto.GetQueryLectureDto
+
+	text := map[string]**string{
+		"group":    &filters.Group,
+		"lector":   &filters.Lector,
+		"platform": &filters.Platform,
+		"unit":     &filters.Unit,
+		"location": &filters.Location,
+		"admin":    &filters.Admin,
+		"q":        &filters.Search,
+		"sortBy":   &filters.SortBy,
+	}
+
+	for param, field := range text {
+		if value := q.Get(param); value != "" {
+			*field = &value
+		}
+	}
+
+	dates := map[string]**time.Time{
+		"dateFrom": &filters.DateFrom,
+		"dateTo":   &filters.DateTo,
+	}
+
+	for param, field := range dates {
+		value := q.Get(param)
+		if value == "" {
+			continue
+		}
+
+		parsed, err := timezone.ParseDate(value)
+		if err != nil {
+			httprespond.ErrorResponse(w, param+" must be date YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+		*field = &parsed
+	}
+
+	filters.NoURL = q.Get("noUrl") == "true"
+	filters.NoStreamKey = q.Get("noStreamKey") == "true"
+
+	if orderStr := q.Get("order"); orderStr != "" {
+		order := dto.SortOrder(orderStr)
+		filters.Order = &order
+	}
+
+	if message, err := dto.Validate(filters); err != nil {
+		httprespond.ErrorResponse(w, message, http.StatusBadRequest)
+		return
+	}
+
+	lectures, pagination, err := l.lectureService.List(ctx, pageInt, limitInt, filters)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	paginationData := dto.PaginationResponse{
+		CurrentPage:     pagination.CurrentPage,
+		TotalItems:      pagination.TotalItems,
+		TotalPages:      pagination.TotalPages,
+		ItemsPerPage:    pagination.ItemsPerPage,
+		HasNextPage:     pagination.HasNextPage,
+		HasPreviousPage: pagination.HasPreviousPage,
+	}
+
+	resp := dto.PaginatedResponse[dto.LectureResponse]{
+		Data:       mappers.ManyLectureToDto(lectures, l.view(ctx)),
+		Pagination: paginationData,
+	}
+
+	httprespond.JsonResponse(w, resp, http.StatusOK)
+}
+
 func (l *LectureHandlers) GetSchedule(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 	ctx := r.Context()
 
@@ -158,14 +305,32 @@ func (l *LectureHandlers) Update(w http.ResponseWriter, r *http.Request, ps http
 		return
 	}
 
-	data, err := l.lectureService.Update(ctx, id, req)
+	version, err := ifMatch(r)
+	if err != nil {
+		httprespond.ErrorResponse(w, "If-Match must be a lecture version ETag", http.StatusBadRequest)
+		return
+	}
+
+	data, err := l.lectureService.Update(ctx, id, version, req, isForced(r))
+
+	// Правка устарела: отдаём текущую лекцию, чтобы клиент мог свести изменения
+	var stale *common.StaleError
+	if errors.As(err, &stale) {
+		if current, ok := stale.Current.(*models.Lecture); ok {
+			setETag(w, current.Version)
+			httprespond.DetailedErrorResponse(w, stale.Error(), mappers.LectureToDto(current, l.view(ctx)), http.StatusPreconditionFailed)
+			return
+		}
+	}
+
 	if err != nil {
 		httprespond.HandleErrorResponse(w, err)
 		return
 	}
 
-	resp := mappers.LectureToDto(data)
+	resp := mappers.LectureToDto(data, l.view(ctx))
 
+	setETag(w, data.Version)
 	httprespond.JsonResponse(w, resp, http.StatusOK)
 }
 
@@ -183,13 +348,38 @@ func (l *LectureHandlers) CreateManyLinks(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	resp, err := l.lectureService.CreateManyLinks(ctx, req)
+	dryRun := r.URL.Query().Get("dryRun") == "true"
+
+	diff, err := l.lectureService.CreateManyLinks(ctx, req, dryRun)
 	if err != nil {
 		httprespond.HandleErrorResponse(w, err)
 		return
 	}
 
-	httprespond.JsonResponse(w, resp, http.StatusOK)
+	httprespond.JsonResponse(w, mappers.LinkDiffToDto(diff, dryRun), http.StatusOK)
+}
+
+func (l *LectureHandlers) UndoLinks(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+
+	var req dto.UndoLinksRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httprespond.ErrorResponse(w, "Bad request", http.StatusBadRequest)
+		return
+	}
+
+	if message, err := dto.Validate(req); err != nil {
+		httprespond.ErrorResponse(w, message, http.StatusBadRequest)
+		return
+	}
+
+	diff, err := l.lectureService.UndoLinks(ctx, req.OperationID)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	httprespond.JsonResponse(w, mappers.LinkDiffToDto(diff, false), http.StatusOK)
 }
 
 func (l *LectureHandlers) Remove(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
@@ -211,6 +401,123 @@ func (l *LectureHandlers) Remove(w http.ResponseWriter, r *http.Request, ps http
 	httprespond.JsonResponse(w, resp, http.StatusOK)
 }
 
+func (l *LectureHandlers) BulkUpdate(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+
+	var req dto.BulkUpdateLecturesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httprespond.ErrorResponse(w, "Bad request", http.StatusBadRequest)
+		return
+	}
+
+	if message, err := dto.Validate(req); err != nil {
+		httprespond.ErrorResponse(w, message, http.StatusBadRequest)
+		return
+	}
+
+	dryRun := r.URL.Query().Get("dryRun") == "true"
+
+	data, err := l.lectureService.BulkUpdate(ctx, req, dryRun, isForced(r))
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	resp := dto.BulkLecturesResponse{
+		DryRun:   dryRun,
+		Count:    len(data),
+		Lectures: mappers.ManyLectureToDto(data, l.view(ctx)),
+	}
+
+	httprespond.JsonResponse(w, resp, http.StatusOK)
+}
+
+func (l *LectureHandlers) BulkRemove(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+
+	var req dto.BulkDeleteLecturesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httprespond.ErrorResponse(w, "Bad request", http.StatusBadRequest)
+		return
+	}
+
+	if message, err := dto.Validate(req); err != nil {
+		httprespond.ErrorResponse(w, message, http.StatusBadRequest)
+		return
+	}
+
+	dryRun := r.URL.Query().Get("dryRun") == "true"
+
+	data, err := l.lectureService.BulkRemove(ctx, req, dryRun)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	resp := dto.BulkLecturesResponse{
+		DryRun:   dryRun,
+		Count:    len(data),
+		Lectures: mappers.ManyLectureToDto(data, l.view(ctx)),
+	}
+
+	httprespond.JsonResponse(w, resp, http.StatusOK)
+}
+
+func (l *LectureHandlers) CopyBlock(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+
+	var req dto.CopyLecturesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httprespond.ErrorResponse(w, "Bad request", http.StatusBadRequest)
+		return
+	}
+
+	if message, err := dto.Validate(req); err != nil {
+		httprespond.ErrorResponse(w, message, http.StatusBadRequest)
+		return
+	}
+
+	dryRun := r.URL.Query().Get("dryRun") == "true"
+
+	block, err := l.lectureService.CopyBlock(ctx, req, dryRun, isForced(r))
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	code := http.StatusCreated
+	if dryRun {
+		code = http.StatusOK
+	}
+
+	httprespond.JsonResponse(w, mappers.LectureBlockToDto(block, dryRun, l.view(ctx)), code)
+}
+
+func (l *LectureHandlers) ShiftBlock(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+
+	var req dto.ShiftLecturesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httprespond.ErrorResponse(w, "Bad request", http.StatusBadRequest)
+		return
+	}
+
+	if message, err := dto.Validate(req); err != nil {
+		httprespond.ErrorResponse(w, message, http.StatusBadRequest)
+		return
+	}
+
+	dryRun := r.URL.Query().Get("dryRun") == "true"
+
+	block, err := l.lectureService.ShiftBlock(ctx, req, dryRun, isForced(r))
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	httprespond.JsonResponse(w, mappers.LectureBlockToDto(block, dryRun, l.view(ctx)), http.StatusOK)
+}
+
 func (h *LectureHandlers) ExportExcel(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 	ctx := r.Context()
 
@@ -218,12 +525,12 @@ func (h *LectureHandlers) ExportExcel(w http.ResponseWriter, r *http.Request, _
 	end := r.URL.Query().Get("end")
 	group := r.URL.Query().Get("group")
 
-	startDate, err := time.Parse("2006-01-02", start)
+	startDate, err := timezone.ParseDate(start)
 	if err != nil {
 		httprespond.ErrorResponse(w, "Start must be date YYYY-MM-DD", http.StatusBadRequest)
 		return
 	}
-	endDate, err := time.Parse("2006-01-02", end)
+	endDate, err := timezone.ParseDate(end)
 	if err != nil {
 		httprespond.ErrorResponse(w, "End must be date YYYY-MM-DD", http.StatusBadRequest)
 		return
@@ -234,16 +541,215 @@ func (h *LectureHandlers) ExportExcel(w http.ResponseWriter, r *http.Request, _
 		groupPtr = &group
 	}
 
+	profile := dto.ExportProfile(r.URL.Query().Get("profile"))
+	if profile == "" {
+		profile = dto.ExportProfileFlat
+	}
+
+	format := dto.ExportFormat(r.URL.Query().Get("format"))
+	if format == "" {
+		format = dto.ExportFormatXLSX
+	}
+
 	filter := dto.ExportLecturesExcelRequest{
 		StartDate: startDate,
 		EndDate:   endDate,
 		Group:     groupPtr,
+		Profile:   profile,
+		Format:    format,
 	}
 
-	w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
-	w.Header().Set(`Content-Disposition`, `attachment; filename="lectures.xlsx"`)
+	if template := r.URL.Query().Get("template"); template != "" {
+		filter.Template = &template
+	}
+
+	if message, err := dto.Validate(filter); err != nil {
+		httprespond.ErrorResponse(w, message, http.StatusBadRequest)
+		return
+	}
+
+	if format == dto.ExportFormatCSV {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set(`Content-Disposition`, `attachment; filename="lectures.csv"`)
+	} else {
+		w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+		w.Header().Set(`Content-Disposition`, `attachment; filename="lectures.xlsx"`)
+	}
+
+	download(w, h.logger, "lectures", func(w http.ResponseWriter) error {
+		return h.lectureService.Export(ctx, filter, h.view(ctx), w)
+	})
+}
+
+func (l *LectureHandlers) Conflicts(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+
+	startDate, err := timezone.ParseDate(r.URL.Query().Get("start"))
+	if err != nil {
+		httprespond.ErrorResponse(w, "Start must be date YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+	endDate, err := timezone.ParseDate(r.URL.Query().Get("end"))
+	if err != nil {
+		httprespond.ErrorResponse(w, "End must be date YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
 
-	if err := h.lectureService.Export(ctx, filter, w); err != nil {
+	conflicts, err := l.lectureService.FindConflicts(ctx, startDate, endDate)
+	if err != nil {
 		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	resp := dto.LectureConflictsResponse{Data: conflicts}
+	httprespond.JsonResponse(w, resp, http.StatusOK)
+}
+
+func (l *LectureHandlers) Stats(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+
+	startDate, err := timezone.ParseDate(r.URL.Query().Get("start"))
+	if err != nil {
+		httprespond.ErrorResponse(w, "Start must be date YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+	endDate, err := timezone.ParseDate(r.URL.Query().Get("end"))
+	if err != nil {
+		httprespond.ErrorResponse(w, "End must be date YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+
+	filter := dto.LectureStatsRequest{
+		StartDate: startDate,
+		EndDate:   endDate,
+		By:        []dto.StatsDimension{},
+		Format:    dto.ExportFormat(r.URL.Query().Get("format")),
+	}
+
+	if group := r.URL.Query().Get("group"); group != "" {
+		filter.Group = &group
+	}
+
+	for _, dimension := range strings.Split(r.URL.Query().Get("by"), ",") {
+		if dimension = strings.TrimSpace(dimension); dimension != "" {
+			filter.By = append(filter.By, dto.StatsDimension(dimension))
+		}
+	}
+
+	if message, err := dto.Validate(filter); err != nil {
+		httprespond.ErrorResponse(w, message, http.StatusBadRequest)
+		return
 	}
+
+	if filter.Format == "" {
+		stats, err := l.lectureService.Stats(ctx, filter)
+		if err != nil {
+			httprespond.HandleErrorResponse(w, err)
+			return
+		}
+
+		httprespond.JsonResponse(w, mappers.LectureStatsToDto(filter, stats), http.StatusOK)
+		return
+	}
+
+	if filter.Format == dto.ExportFormatCSV {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set(`Content-Disposition`, `attachment; filename="lecture-stats.csv"`)
+	} else {
+		w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+		w.Header().Set(`Content-Disposition`, `attachment; filename="lecture-stats.xlsx"`)
+	}
+
+	download(w, l.logger, "lecture stats", func(w http.ResponseWriter) error {
+		return l.lectureService.ExportStats(ctx, filter, w)
+	})
+}
+
+func (l *LectureHandlers) Import(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		httprespond.ErrorResponse(w, "File is required", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	dryRun := r.URL.Query().Get("dryRun") == "true"
+
+	report, err := l.lectureService.Import(ctx, file, dryRun, isForced(r))
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	code := http.StatusCreated
+	if dryRun {
+		code = http.StatusOK
+	}
+
+	httprespond.JsonResponse(w, report, code)
+}
+
+func (l *LectureHandlers) Trash(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+
+	page, limit, ok := pageParams(r)
+	if !ok {
+		httprespond.ErrorResponse(w, "Page and limit must be int", http.StatusBadRequest)
+		return
+	}
+
+	lectures, pagination, err := l.lectureService.ListTrash(ctx, page, limit)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	resp := dto.PaginatedResponse[dto.LectureResponse]{
+		Data:       mappers.ManyLectureToDto(lectures, l.view(ctx)),
+		Pagination: mappers.PaginationToDto(pagination),
+	}
+
+	httprespond.JsonResponse(w, resp, http.StatusOK)
+}
+
+func (l *LectureHandlers) Restore(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		httprespond.ErrorResponse(w, "Invalid lecture ID", http.StatusBadRequest)
+		return
+	}
+
+	restored, err := l.lectureService.Restore(ctx, id, isForced(r))
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	resp := mappers.LectureToDto(restored, l.view(ctx))
+	httprespond.JsonResponse(w, resp, http.StatusOK)
+}
+
+func (l *LectureHandlers) Purge(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		httprespond.ErrorResponse(w, "Invalid lecture ID", http.StatusBadRequest)
+		return
+	}
+
+	purged, err := l.lectureService.Purge(ctx, id)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	resp := mappers.LectureToDto(purged, l.view(ctx))
+	httprespond.JsonResponse(w, resp, http.StatusOK)
 }
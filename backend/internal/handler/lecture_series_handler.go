@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"table-api/internal/handler/dto"
+	"table-api/internal/mappers"
+	httprespond "table-api/pkg/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+func (l *LectureHandlers) CreateSeries(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+
+	var req dto.CreateLectureSeriesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httprespond.ErrorResponse(w, "Bad request", http.StatusBadRequest)
+		return
+	}
+
+	if message, err := dto.Validate(req); err != nil {
+		httprespond.ErrorResponse(w, message, http.StatusBadRequest)
+		return
+	}
+
+	series, lectures, err := l.lectureService.CreateSeries(ctx, req, isForced(r))
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	resp := mappers.LectureSeriesToDto(series, lectures, l.view(ctx))
+	httprespond.JsonResponse(w, resp, http.StatusCreated)
+}
+
+func (l *LectureHandlers) GetSeries(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		httprespond.ErrorResponse(w, "Invalid series ID", http.StatusBadRequest)
+		return
+	}
+
+	series, lectures, err := l.lectureService.GetSeries(ctx, id)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	resp := mappers.LectureSeriesToDto(series, lectures, l.view(ctx))
+	httprespond.JsonResponse(w, resp, http.StatusOK)
+}
+
+func (l *LectureHandlers) UpdateSeries(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		httprespond.ErrorResponse(w, "Invalid lecture ID", http.StatusBadRequest)
+		return
+	}
+
+	query := dto.SeriesScopeQuery{Scope: dto.SeriesScope(r.URL.Query().Get("scope"))}
+	if message, err := dto.Validate(query); err != nil {
+		httprespond.ErrorResponse(w, message, http.StatusBadRequest)
+		return
+	}
+
+	var req dto.UpdateLectureRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httprespond.ErrorResponse(w, "Bad request", http.StatusBadRequest)
+		return
+	}
+
+	if message, err := dto.Validate(req); err != nil {
+		httprespond.ErrorResponse(w, message, http.StatusBadRequest)
+		return
+	}
+
+	data, err := l.lectureService.UpdateSeries(ctx, id, query.Scope, req, isForced(r))
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	resp := mappers.ManyLectureToDto(data, l.view(ctx))
+	httprespond.JsonResponse(w, resp, http.StatusOK)
+}
+
+func (l *LectureHandlers) RemoveSeries(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		httprespond.ErrorResponse(w, "Invalid lecture ID", http.StatusBadRequest)
+		return
+	}
+
+	query := dto.SeriesScopeQuery{Scope: dto.SeriesScope(r.URL.Query().Get("scope"))}
+	if message, err := dto.Validate(query); err != nil {
+		httprespond.ErrorResponse(w, message, http.StatusBadRequest)
+		return
+	}
+
+	data, err := l.lectureService.RemoveSeries(ctx, id, query.Scope)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	resp := mappers.ManyLectureToDto(data, l.view(ctx))
+	httprespond.JsonResponse(w, resp, http.StatusOK)
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"table-api/internal/handler/dto"
+	"table-api/internal/mappers"
+	httprespond "table-api/pkg/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+func (l *LectureHandlers) RevealStreamKey(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		httprespond.ErrorResponse(w, "Invalid lecture ID", http.StatusBadRequest)
+		return
+	}
+
+	lecture, err := l.lectureService.RevealStreamKey(ctx, id)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	resp := dto.StreamKeyResponse{
+		LectureID: lecture.ID,
+		StreamKey: string(*lecture.StreamKey),
+	}
+
+	httprespond.JsonResponse(w, resp, http.StatusOK)
+}
+
+func (l *LectureHandlers) RotateStreamKey(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		httprespond.ErrorResponse(w, "Invalid lecture ID", http.StatusBadRequest)
+		return
+	}
+
+	lecture, err := l.lectureService.RotateStreamKey(ctx, id)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	resp := mappers.LectureToDto(lecture, l.view(ctx))
+	httprespond.JsonResponse(w, resp, http.StatusOK)
+}
+
+func (l *LectureHandlers) RotateGroupStreamKeys(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	lectures, err := l.lectureService.RotateGroupStreamKeys(ctx, ps.ByName("name"))
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	resp := mappers.ManyLectureToDto(lectures, l.view(ctx))
+	httprespond.JsonResponse(w, resp, http.StatusOK)
+}
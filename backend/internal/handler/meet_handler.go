@@ -3,12 +3,15 @@ package handler
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 	"table-api/internal/entitys"
 	"table-api/internal/handler/dto"
 	"table-api/internal/mappers"
 	"table-api/internal/models"
+	"table-api/internal/visibility"
+	common "table-api/pkg"
 	httprespond "table-api/pkg/http"
 
 	"github.com/julienschmidt/httprouter"
@@ -16,16 +19,21 @@ import (
 
 type MeetService interface {
 	Create(ctx context.Context, dto dto.CreateMeetRequest) (*models.Meet, error)
-	Update(ctx context.Context, id int, dto dto.UpdateMeetRequest) (*models.Meet, error)
+	Update(ctx context.Context, id int, version *int, dto dto.UpdateMeetRequest) (*models.Meet, error)
 	List(ctx context.Context, page, limit int, filter dto.GetQueryMeetDto) ([]*models.Meet, *entitys.Pagination, error)
+	Remove(ctx context.Context, id int) (*models.Meet, error)
+	ListTrash(ctx context.Context, page, limit int) ([]*models.Meet, *entitys.Pagination, error)
+	Restore(ctx context.Context, id int) (*models.Meet, error)
+	Purge(ctx context.Context, id int) (*models.Meet, error)
 }
 
 type MeetHandlers struct {
 	meetService MeetService
+	visibility  *visibility.Policy
 }
 
-func NewMeetHandlers(s MeetService) *MeetHandlers {
-	return &MeetHandlers{meetService: s}
+func NewMeetHandlers(s MeetService, policy *visibility.Policy) *MeetHandlers {
+	return &MeetHandlers{meetService: s, visibility: policy}
 }
 
 func (m *MeetHandlers) Create(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
@@ -48,7 +56,7 @@ func (m *MeetHandlers) Create(w http.ResponseWriter, r *http.Request, _ httprout
 		return
 	}
 
-	resp := mappers.MeetToDto(newMeet)
+	resp := mappers.MeetToDto(newMeet, m.view(ctx))
 	httprespond.JsonResponse(w, resp, 201)
 }
 
@@ -93,7 +101,7 @@ func (m *MeetHandlers) FindMany(w http.ResponseWriter, r *http.Request, _ httpro
 		return
 	}
 
-	meetsData := mappers.MeetsToDto(meets)
+	meetsData := mappers.MeetsToDto(meets, m.view(ctx))
 	paginationData := dto.PaginationResponse{
 		CurrentPage:  pagination.CurrentPage,
 		TotalItems:   pagination.TotalItems,
@@ -131,13 +139,111 @@ func (m *MeetHandlers) Update(w http.ResponseWriter, r *http.Request, ps httprou
 		return
 	}
 
-	updatedMeet, err := m.meetService.Update(ctx, id, req)
+	version, err := ifMatch(r)
+	if err != nil {
+		httprespond.ErrorResponse(w, "If-Match must be a meet version ETag", http.StatusBadRequest)
+		return
+	}
+
+	updatedMeet, err := m.meetService.Update(ctx, id, version, req)
+
+	// Правка устарела: отдаём текущее мероприятие, чтобы клиент мог свести изменения
+	var stale *common.StaleError
+	if errors.As(err, &stale) {
+		if current, ok := stale.Current.(*models.Meet); ok {
+			setETag(w, current.Version)
+			httprespond.DetailedErrorResponse(w, stale.Error(), mappers.MeetToDto(current, m.view(ctx)), http.StatusPreconditionFailed)
+			return
+		}
+	}
+
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	resp := mappers.MeetToDto(updatedMeet, m.view(ctx))
+
+	setETag(w, updatedMeet.Version)
+	httprespond.JsonResponse(w, resp, http.StatusOK)
+}
+
+func (m *MeetHandlers) Remove(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		httprespond.ErrorResponse(w, "Invalid meet ID", http.StatusBadRequest)
+		return
+	}
+
+	deleted, err := m.meetService.Remove(ctx, id)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	resp := mappers.MeetToDto(deleted, m.view(ctx))
+	httprespond.JsonResponse(w, resp, http.StatusOK)
+}
+
+func (m *MeetHandlers) Trash(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+
+	page, limit, ok := pageParams(r)
+	if !ok {
+		httprespond.ErrorResponse(w, "Page and limit must be int", http.StatusBadRequest)
+		return
+	}
+
+	meets, pagination, err := m.meetService.ListTrash(ctx, page, limit)
 	if err != nil {
 		httprespond.HandleErrorResponse(w, err)
 		return
 	}
 
-	resp := mappers.MeetToDto(updatedMeet)
+	resp := dto.PaginatedResponse[dto.MeetResponse]{
+		Data:       mappers.MeetsToDto(meets, m.view(ctx)),
+		Pagination: mappers.PaginationToDto(pagination),
+	}
+
+	httprespond.JsonResponse(w, resp, http.StatusOK)
+}
+
+func (m *MeetHandlers) Restore(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		httprespond.ErrorResponse(w, "Invalid meet ID", http.StatusBadRequest)
+		return
+	}
+
+	restored, err := m.meetService.Restore(ctx, id)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	resp := mappers.MeetToDto(restored, m.view(ctx))
+	httprespond.JsonResponse(w, resp, http.StatusOK)
+}
+
+func (m *MeetHandlers) Purge(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		httprespond.ErrorResponse(w, "Invalid meet ID", http.StatusBadRequest)
+		return
+	}
+
+	purged, err := m.meetService.Purge(ctx, id)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
 
+	resp := mappers.MeetToDto(purged, m.view(ctx))
 	httprespond.JsonResponse(w, resp, http.StatusOK)
 }
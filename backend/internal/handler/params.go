@@ -0,0 +1,14 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// pageParams читает page и limit для постраничных списков.
+func pageParams(r *http.Request) (int, int, bool) {
+	page, err1 := strconv.Atoi(r.URL.Query().Get("page"))
+	limit, err2 := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	return page, limit, err1 == nil && err2 == nil
+}
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"table-api/internal/entitys"
+	"table-api/internal/mappers"
+	httprespond "table-api/pkg/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// Наибольший период проверки готовности в днях
+const maxReadinessDays = 31
+
+type ReadinessService interface {
+	Check(ctx context.Context, days int) (*entitys.Readiness, error)
+}
+
+type ReadinessHandlers struct {
+	readinessService ReadinessService
+}
+
+func NewReadinessHandlers(s ReadinessService) *ReadinessHandlers {
+	return &ReadinessHandlers{readinessService: s}
+}
+
+func (h *ReadinessHandlers) Check(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+
+	var days int
+	if daysStr := r.URL.Query().Get("days"); daysStr != "" {
+		var err error
+		days, err = strconv.Atoi(daysStr)
+		if err != nil || days < 1 || days > maxReadinessDays {
+			httprespond.ErrorResponse(w, "Days must be a number from 1 to 31", http.StatusBadRequest)
+			return
+		}
+	}
+
+	result, err := h.readinessService.Check(ctx, days)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	httprespond.JsonResponse(w, mappers.ReadinessToDto(result), http.StatusOK)
+}
@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"table-api/internal/handler/dto"
+	"table-api/internal/mappers"
+	"table-api/internal/models"
+	httprespond "table-api/pkg/http"
+	"table-api/pkg/timezone"
+	"table-api/pkg/utils"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type RoomService interface {
+	Create(ctx context.Context, dto dto.CreateRoomRequest) (*models.Room, error)
+	Get(ctx context.Context, id int) (*models.Room, error)
+	Update(ctx context.Context, id int, dto dto.UpdateRoomRequest) (*models.Room, error)
+	Remove(ctx context.Context, id int) (*models.Room, error)
+	List(ctx context.Context, filter dto.GetQueryRoomDto) ([]*models.Room, error)
+}
+
+type RoomHandlers struct {
+	roomService RoomService
+}
+
+func NewRoomHandlers(s RoomService) *RoomHandlers {
+	return &RoomHandlers{roomService: s}
+}
+
+func (h *RoomHandlers) Create(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+
+	var req dto.CreateRoomRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httprespond.ErrorResponse(w, "Bad request", http.StatusBadRequest)
+		return
+	}
+
+	if message, err := dto.Validate(req); err != nil {
+		httprespond.ErrorResponse(w, message, http.StatusBadRequest)
+		return
+	}
+
+	room, err := h.roomService.Create(ctx, req)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	httprespond.JsonResponse(w, mappers.RoomToDto(room), http.StatusCreated)
+}
+
+func (h *RoomHandlers) FindMany(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+	q := r.URL.Query()
+
+	var filters dto.GetQueryRoomDto
+
+	if building := q.Get("building"); building != "" {
+		filters.Building = &building
+	}
+
+	if capacityStr := q.Get("capacity"); capacityStr != "" {
+		capacity, err := strconv.Atoi(capacityStr)
+		if err != nil {
+			httprespond.ErrorResponse(w, "Capacity must be int", http.StatusBadRequest)
+			return
+		}
+		filters.MinCapacity = &capacity
+	}
+
+	if equipment := q.Get("equipment"); equipment != "" {
+		filters.Equipment = strings.Split(equipment, ",")
+	}
+
+	if platform := q.Get("platform"); platform != "" {
+		filters.Platform = &platform
+	}
+
+	if dateStr := q.Get("date"); dateStr != "" {
+		date, err := timezone.ParseDate(dateStr)
+		if err != nil {
+			httprespond.ErrorResponse(w, "Date must be date YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+		filters.Date = &date
+	}
+
+	clocks := map[string]**utils.Clock{
+		"start": &filters.Start,
+		"end":   &filters.End,
+	}
+
+	for param, field := range clocks {
+		value := q.Get(param)
+		if value == "" {
+			continue
+		}
+
+		minutes, err := utils.ParseClock(value)
+		if err != nil {
+			httprespond.ErrorResponse(w, param+" must be time HH:MM", http.StatusBadRequest)
+			return
+		}
+
+		clock := utils.Clock(minutes)
+		*field = &clock
+	}
+
+	if message, err := dto.Validate(filters); err != nil {
+		httprespond.ErrorResponse(w, message, http.StatusBadRequest)
+		return
+	}
+
+	rooms, err := h.roomService.List(ctx, filters)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	httprespond.JsonResponse(w, dto.RoomsResponse{Data: mappers.RoomsToDto(rooms)}, http.StatusOK)
+}
+
+func (h *RoomHandlers) Get(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		httprespond.ErrorResponse(w, "Invalid room ID", http.StatusBadRequest)
+		return
+	}
+
+	room, err := h.roomService.Get(ctx, id)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	httprespond.JsonResponse(w, mappers.RoomToDto(room), http.StatusOK)
+}
+
+func (h *RoomHandlers) Update(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		httprespond.ErrorResponse(w, "Invalid room ID", http.StatusBadRequest)
+		return
+	}
+
+	var req dto.UpdateRoomRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httprespond.ErrorResponse(w, "Bad request", http.StatusBadRequest)
+		return
+	}
+
+	if message, err := dto.Validate(req); err != nil {
+		httprespond.ErrorResponse(w, message, http.StatusBadRequest)
+		return
+	}
+
+	room, err := h.roomService.Update(ctx, id, req)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	httprespond.JsonResponse(w, mappers.RoomToDto(room), http.StatusOK)
+}
+
+func (h *RoomHandlers) Remove(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		httprespond.ErrorResponse(w, "Invalid room ID", http.StatusBadRequest)
+		return
+	}
+
+	room, err := h.roomService.Remove(ctx, id)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	httprespond.JsonResponse(w, mappers.RoomToDto(room), http.StatusOK)
+}
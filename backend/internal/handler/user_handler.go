@@ -27,6 +27,9 @@ type UserService interface {
 	Search(ctx context.Context, searchTerm string) ([]*models.User, error)
 	Update(ctx context.Context, id uuid.UUID, dto dto.UpdateUserRequest) (*models.User, error)
 	Remove(ctx context.Context, id uuid.UUID) (*models.User, error)
+	ListTrash(ctx context.Context, page, limit int) ([]*models.User, *entitys.Pagination, error)
+	Restore(ctx context.Context, id uuid.UUID) (*models.User, error)
+	Purge(ctx context.Context, id uuid.UUID) (*models.User, error)
 }
 
 func NewUserHandlers(service UserService) *UserHandlers {
@@ -162,3 +165,64 @@ func (u *UserHandlers) Remove(w http.ResponseWriter, r *http.Request, ps httprou
 
 	httprespond.JsonResponse(w, resp, http.StatusOK)
 }
+
+func (u *UserHandlers) Trash(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+
+	page, limit, ok := pageParams(r)
+	if !ok {
+		httprespond.ErrorResponse(w, "Page and limit must be int", http.StatusBadRequest)
+		return
+	}
+
+	users, pagination, err := u.service.ListTrash(ctx, page, limit)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	resp := dto.PaginatedResponse[dto.UserResponse]{
+		Data:       mappers.ToUsersResponse(users),
+		Pagination: mappers.PaginationToDto(pagination),
+	}
+
+	httprespond.JsonResponse(w, resp, http.StatusOK)
+}
+
+func (u *UserHandlers) Restore(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	id, err := uuid.Parse(ps.ByName("id"))
+	if err != nil {
+		httprespond.ErrorResponse(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	restored, err := u.service.Restore(ctx, id)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	resp := mappers.ToUserResponse(*restored)
+	httprespond.JsonResponse(w, resp, http.StatusOK)
+}
+
+func (u *UserHandlers) Purge(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	id, err := uuid.Parse(ps.ByName("id"))
+	if err != nil {
+		httprespond.ErrorResponse(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	purged, err := u.service.Purge(ctx, id)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	resp := mappers.ToUserResponse(*purged)
+	httprespond.JsonResponse(w, resp, http.StatusOK)
+}
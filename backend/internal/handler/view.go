@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"context"
+	"table-api/internal/visibility"
+)
+
+// viewFromContext выбирает правила видимости полей по роли из токена.
+func viewFromContext(ctx context.Context, policy *visibility.Policy) visibility.View {
+	role, _ := ctx.Value("role").(string)
+
+	return policy.For(role)
+}
+
+func (l *LectureHandlers) view(ctx context.Context) visibility.View {
+	return viewFromContext(ctx, l.visibility)
+}
+
+func (m *MeetHandlers) view(ctx context.Context) visibility.View {
+	return viewFromContext(ctx, m.visibility)
+}
+
+func (a *AuditHandlers) view(ctx context.Context) visibility.View {
+	return viewFromContext(ctx, a.visibility)
+}
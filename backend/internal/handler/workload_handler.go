@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"table-api/internal/entitys"
+	"table-api/internal/handler/dto"
+	"table-api/internal/mappers"
+	"table-api/internal/models"
+	httprespond "table-api/pkg/http"
+	"table-api/pkg/timezone"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+)
+
+type WorkloadService interface {
+	Report(ctx context.Context, start, end time.Time) (*entitys.Workload, error)
+	AutoAssign(ctx context.Context, start, end time.Time, dryRun bool) (*entitys.AutoAssignment, error)
+	GetAvailability(ctx context.Context, userID uuid.UUID) ([]*models.Availability, error)
+	SetAvailability(ctx context.Context, userID uuid.UUID, dto dto.SetAvailabilityRequest) ([]*models.Availability, error)
+}
+
+type WorkloadHandlers struct {
+	workloadService WorkloadService
+}
+
+func NewWorkloadHandlers(s WorkloadService) *WorkloadHandlers {
+	return &WorkloadHandlers{workloadService: s}
+}
+
+func (h *WorkloadHandlers) Report(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+
+	startDate, err := timezone.ParseDate(r.URL.Query().Get("start"))
+	if err != nil {
+		httprespond.ErrorResponse(w, "Start must be date YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+	endDate, err := timezone.ParseDate(r.URL.Query().Get("end"))
+	if err != nil {
+		httprespond.ErrorResponse(w, "End must be date YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+
+	workload, err := h.workloadService.Report(ctx, startDate, endDate)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	httprespond.JsonResponse(w, mappers.WorkloadToDto(workload), http.StatusOK)
+}
+
+func (h *WorkloadHandlers) AutoAssign(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+
+	var req dto.AutoAssignRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httprespond.ErrorResponse(w, "Bad request", http.StatusBadRequest)
+		return
+	}
+
+	if message, err := dto.Validate(req); err != nil {
+		httprespond.ErrorResponse(w, message, http.StatusBadRequest)
+		return
+	}
+
+	dryRun := r.URL.Query().Get("dryRun") == "true"
+
+	result, err := h.workloadService.AutoAssign(ctx, timezone.Day(req.From), timezone.Day(req.To), dryRun)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	httprespond.JsonResponse(w, mappers.AutoAssignmentToDto(result, dryRun), http.StatusOK)
+}
+
+func (h *WorkloadHandlers) GetAvailability(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	id, err := uuid.Parse(ps.ByName("id"))
+	if err != nil {
+		httprespond.ErrorResponse(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	slots, err := h.workloadService.GetAvailability(ctx, id)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	httprespond.JsonResponse(w, mappers.AvailabilityToDto(id, slots), http.StatusOK)
+}
+
+func (h *WorkloadHandlers) SetAvailability(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	id, err := uuid.Parse(ps.ByName("id"))
+	if err != nil {
+		httprespond.ErrorResponse(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	var req dto.SetAvailabilityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httprespond.ErrorResponse(w, "Bad request", http.StatusBadRequest)
+		return
+	}
+
+	if message, err := dto.Validate(req); err != nil {
+		httprespond.ErrorResponse(w, message, http.StatusBadRequest)
+		return
+	}
+
+	slots, err := h.workloadService.SetAvailability(ctx, id, req)
+	if err != nil {
+		httprespond.HandleErrorResponse(w, err)
+		return
+	}
+
+	httprespond.JsonResponse(w, mappers.AvailabilityToDto(id, slots), http.StatusOK)
+}
@@ -0,0 +1,52 @@
+package mappers
+
+import (
+	"table-api/internal/handler/dto"
+	"table-api/internal/models"
+	"table-api/internal/visibility"
+)
+
+// AuditLogToDto собирает ответ и закрывает значения полей по правилам видимости роли.
+// Изменения скрытых полей в ответ не попадают.
+func AuditLogToDto(log *models.AuditLog, view visibility.View) dto.AuditLogResponse {
+	resp := dto.AuditLogResponse{
+		ID:         log.ID,
+		EntityType: log.EntityType,
+		EntityID:   log.EntityID,
+		Action:     log.Action,
+		Changes:    make([]dto.AuditChangeResponse, 0, len(log.Changes)),
+		CreatedAt:  log.CreatedAt,
+	}
+
+	if log.UserID != nil {
+		userID := log.UserID.String()
+		resp.UserID = &userID
+	}
+
+	rules := view.Entity(log.EntityType)
+
+	for _, c := range log.Changes {
+		before, visible := visibility.Value(rules, c.Field, c.Before)
+		if !visible {
+			continue
+		}
+		after, _ := visibility.Value(rules, c.Field, c.After)
+
+		resp.Changes = append(resp.Changes, dto.AuditChangeResponse{
+			Field:  c.Field,
+			Before: before,
+			After:  after,
+		})
+	}
+
+	return resp
+}
+
+func AuditLogsToDto(logs []*models.AuditLog, view visibility.View) []dto.AuditLogResponse {
+	resp := make([]dto.AuditLogResponse, 0, len(logs))
+	for _, log := range logs {
+		resp = append(resp, AuditLogToDto(log, view))
+	}
+
+	return resp
+}
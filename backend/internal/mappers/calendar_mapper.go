@@ -0,0 +1,17 @@
+package mappers
+
+import (
+	"table-api/internal/handler/dto"
+	"table-api/internal/models"
+)
+
+func CalendarFeedToDto(feed *models.CalendarFeed, url string) dto.CalendarFeedResponse {
+	return dto.CalendarFeedResponse{
+		ID:        feed.ID,
+		Kind:      feed.Kind,
+		Name:      feed.Name,
+		Token:     feed.Token,
+		URL:       url,
+		CreatedAt: feed.CreatedAt,
+	}
+}
@@ -0,0 +1,28 @@
+package mappers
+
+import (
+	"table-api/internal/entitys"
+	"table-api/internal/handler/dto"
+	"table-api/internal/models"
+)
+
+func DigestSubscriptionToDto(subscription *models.DigestSubscription) dto.DigestSubscriptionResponse {
+	return dto.DigestSubscriptionResponse{
+		UserID:    subscription.UserID.String(),
+		Enabled:   subscription.Enabled,
+		Scope:     subscription.Scope,
+		LectorID:  subscription.LectorID,
+		Lector:    subscription.Lector,
+		UpdatedAt: subscription.UpdatedAt,
+	}
+}
+
+func DigestMailToDto(mail *entitys.DigestMail) dto.DigestPreviewResponse {
+	return dto.DigestPreviewResponse{
+		Date:    mail.Digest.Date,
+		Count:   len(mail.Digest.Entries),
+		Subject: mail.Subject,
+		Text:    mail.Text,
+		HTML:    mail.HTML,
+	}
+}
@@ -0,0 +1,50 @@
+package mappers
+
+import (
+	"table-api/internal/handler/dto"
+	"table-api/internal/models"
+)
+
+func DirectoryEntryToDto(entry *models.DirectoryEntry) dto.DirectoryEntryResponse {
+	aliases := entry.Aliases
+	if aliases == nil {
+		aliases = []string{}
+	}
+
+	return dto.DirectoryEntryResponse{
+		ID:        entry.ID,
+		Kind:      entry.Kind,
+		Name:      entry.Name,
+		Email:     entry.Email,
+		Aliases:   aliases,
+		CreatedAt: entry.CreatedAt,
+		UpdatedAt: entry.UpdatedAt,
+	}
+}
+
+func DirectoryEntriesToDto(entries []*models.DirectoryEntry) []dto.DirectoryEntryResponse {
+	resp := make([]dto.DirectoryEntryResponse, 0, len(entries))
+	for _, entry := range entries {
+		resp = append(resp, DirectoryEntryToDto(entry))
+	}
+
+	return resp
+}
+
+func DirectoryMergesToDto(merges []*models.DirectoryMerge) []dto.DirectoryMergeResponse {
+	resp := make([]dto.DirectoryMergeResponse, 0, len(merges))
+	for _, m := range merges {
+		resp = append(resp, dto.DirectoryMergeResponse{
+			ID:        m.ID,
+			Kind:      m.Kind,
+			EntryID:   m.EntryID,
+			Variant:   m.Variant,
+			Canonical: m.Canonical,
+			Lectures:  m.Lectures,
+			Source:    m.Source,
+			CreatedAt: m.CreatedAt,
+		})
+	}
+
+	return resp
+}
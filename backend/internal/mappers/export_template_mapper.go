@@ -0,0 +1,32 @@
+package mappers
+
+import (
+	"table-api/internal/handler/dto"
+	"table-api/internal/models"
+)
+
+func ExportTemplateToDto(template *models.ExportTemplate) dto.ExportTemplateResponse {
+	columns := make([]dto.ExportColumnDto, 0, len(template.Columns))
+	for _, column := range template.Columns {
+		columns = append(columns, dto.ExportColumnDto{Field: column.Field, Header: column.Header})
+	}
+
+	return dto.ExportTemplateResponse{
+		ID:         template.ID,
+		Name:       template.Name,
+		Columns:    columns,
+		DateFormat: template.DateFormat,
+		TimeFormat: template.TimeFormat,
+		CreatedAt:  template.CreatedAt,
+		UpdatedAt:  template.UpdatedAt,
+	}
+}
+
+func ExportTemplatesToDto(templates []*models.ExportTemplate) []dto.ExportTemplateResponse {
+	resp := make([]dto.ExportTemplateResponse, 0, len(templates))
+	for _, template := range templates {
+		resp = append(resp, ExportTemplateToDto(template))
+	}
+
+	return resp
+}
@@ -0,0 +1,33 @@
+package mappers
+
+import (
+	"table-api/internal/entitys"
+	"table-api/internal/handler/dto"
+)
+
+func LinkDiffToDto(diff *entitys.LinkDiff, dryRun bool) dto.LinkOperationResponse {
+	resp := dto.LinkOperationResponse{
+		DryRun:  dryRun,
+		Count:   len(diff.Changes),
+		Skipped: diff.Skipped,
+		Changes: make([]dto.LinkDiffRowResponse, 0, len(diff.Changes)),
+	}
+
+	if diff.OperationID != 0 {
+		resp.OperationID = &diff.OperationID
+	}
+
+	for _, row := range diff.Changes {
+		resp.Changes = append(resp.Changes, dto.LinkDiffRowResponse{
+			LectureID: row.LectureID,
+			Date:      row.Date,
+			Group:     row.Group,
+			Lector:    row.Lector,
+			Platform:  row.Platform,
+			Before:    dto.LinkValuesResponse{URL: row.Before.URL, ShortURL: row.Before.ShortURL},
+			After:     dto.LinkValuesResponse{URL: row.After.URL, ShortURL: row.After.ShortURL},
+		})
+	}
+
+	return resp
+}
@@ -1,8 +1,12 @@
 package mappers
 
 import (
+	"table-api/internal/entitys"
 	"table-api/internal/handler/dto"
 	"table-api/internal/models"
+	"table-api/internal/visibility"
+	"table-api/pkg/timezone"
+	"table-api/pkg/vault"
 )
 
 func DtoToLecture(dto dto.CreateLectureRequest) (*models.Lecture, error) {
@@ -14,18 +18,19 @@ func DtoToLecture(dto dto.CreateLectureRequest) (*models.Lecture, error) {
 		Location:     dto.Location,
 		URL:          dto.URL,
 		ShortURL:     dto.ShortURL,
-		StreamKey:    dto.StreamKey,
+		StreamKey:    vault.New(dto.StreamKey),
 		Description:  dto.Description,
 		Admin:        dto.Admin,
-		Date:         dto.Date,
+		Date:         timezone.Day(dto.Date),
 		Start:        dto.Start,
 		End:          dto.End,
 		AbnormalTime: dto.AbnormalTime,
 	}, nil
 }
 
-func LectureToDto(lecture *models.Lecture) *dto.LectureResponse {
-	return &dto.LectureResponse{
+// LectureToDto собирает ответ и применяет к нему правила видимости роли.
+func LectureToDto(lecture *models.Lecture, view visibility.View) *dto.LectureResponse {
+	resp := &dto.LectureResponse{
 		ID:           lecture.ID,
 		Group:        lecture.Group,
 		Lector:       lecture.Lector,
@@ -34,16 +39,29 @@ func LectureToDto(lecture *models.Lecture) *dto.LectureResponse {
 		Location:     lecture.Location,
 		URL:          lecture.URL,
 		ShortURL:     lecture.ShortURL,
-		StreamKey:    lecture.StreamKey,
+		StreamKey:    vault.Plain(lecture.StreamKey),
 		Description:  lecture.Description,
 		Admin:        lecture.Admin,
+		AdminID:      uuidToString(lecture.AdminID),
 		Date:         lecture.Date,
 		Start:        lecture.Start,
 		End:          lecture.End,
 		AbnormalTime: lecture.AbnormalTime,
+		GroupID:      lecture.GroupID,
+		LectorID:     lecture.LectorID,
+		UnitID:       lecture.UnitID,
+		LocationID:   lecture.LocationID,
+		SeriesID:     lecture.SeriesID,
+		Version:      lecture.Version,
 		CreatedAt:    lecture.CreatedAt,
 		UpdatedAt:    lecture.UpdatedAt,
+		DeletedAt:    deletedAtToTime(lecture.DeletedAt),
+		Warnings:     lecture.Warnings,
 	}
+
+	visibility.Apply(resp, view.Lecture)
+
+	return resp
 }
 
 func DtoToManyLecture(dtos []dto.CreateLectureRequest) ([]*models.Lecture, error) {
@@ -60,13 +78,133 @@ func DtoToManyLecture(dtos []dto.CreateLectureRequest) ([]*models.Lecture, error
 	return lectures, nil
 }
 
-func ManyLectureToDto(lectures []*models.Lecture) []dto.LectureResponse {
+func ManyLectureToDto(lectures []*models.Lecture, view visibility.View) []dto.LectureResponse {
 	var dtos []dto.LectureResponse
 	for _, v := range lectures {
-		lecture := LectureToDto(v)
+		lecture := LectureToDto(v, view)
 
 		dtos = append(dtos, *lecture)
 	}
 
 	return dtos
 }
+
+// ApplyUpdateToLecture возвращает копию лекции с применёнными полями обновления.
+func ApplyUpdateToLecture(lecture *models.Lecture, dto dto.UpdateLectureRequest) *models.Lecture {
+	updated := *lecture
+
+	if dto.Group != nil {
+		updated.Group = dto.Group
+	}
+	if dto.Lector != nil {
+		updated.Lector = dto.Lector
+	}
+	if dto.Platform != nil {
+		updated.Platform = dto.Platform
+	}
+	if dto.Unit != nil {
+		updated.Unit = dto.Unit
+	}
+	if dto.Location != nil {
+		updated.Location = dto.Location
+	}
+	if dto.URL != nil {
+		updated.URL = dto.URL
+	}
+	if dto.ShortURL != nil {
+		updated.ShortURL = dto.ShortURL
+	}
+	if key := vault.New(dto.StreamKey); key != nil {
+		updated.StreamKey = key
+	}
+	if dto.Description != nil {
+		updated.Description = dto.Description
+	}
+	if dto.Admin != nil {
+		updated.Admin = dto.Admin
+	}
+	if dto.Date != nil {
+		updated.Date = timezone.Day(*dto.Date)
+	}
+	if dto.Start != nil {
+		updated.Start = dto.Start
+	}
+	if dto.End != nil {
+		updated.End = dto.End
+	}
+	if dto.AbnormalTime != nil {
+		updated.AbnormalTime = dto.AbnormalTime
+	}
+
+	return &updated
+}
+
+func LectureBlockToDto(block *entitys.LectureBlock, dryRun bool, view visibility.View) dto.LectureBlockResponse {
+	return dto.LectureBlockResponse{
+		DryRun:     dryRun,
+		OffsetDays: block.OffsetDays,
+		Count:      len(block.Lectures),
+		Lectures:   ManyLectureToDto(block.Lectures, view),
+		Conflicts:  block.Conflicts,
+	}
+}
+
+func LectureStatsToDto(filter dto.LectureStatsRequest, stats []entitys.LectureStat) dto.LectureStatsResponse {
+	resp := dto.LectureStatsResponse{
+		Start: filter.StartDate,
+		End:   filter.EndDate,
+		By:    filter.By,
+		Data:  make([]dto.LectureStatRow, 0, len(stats)),
+	}
+
+	var minutes int64
+	for _, stat := range stats {
+		keys := make(map[dto.StatsDimension]*string, len(filter.By))
+		for i, dimension := range filter.By {
+			keys[dimension] = stat.Keys[i]
+		}
+
+		resp.Lectures += stat.Lectures
+		minutes += stat.Minutes
+
+		resp.Data = append(resp.Data, dto.LectureStatRow{
+			Keys:     keys,
+			Lectures: stat.Lectures,
+			Hours:    minutesToHours(int(stat.Minutes)),
+		})
+	}
+
+	resp.Hours = minutesToHours(int(minutes))
+
+	return resp
+}
+
+func LectureBatchToDto(rows []entitys.LectureBatchRow, view visibility.View) dto.CreateLecturesResponse {
+	resp := dto.CreateLecturesResponse{
+		Total:   len(rows),
+		Results: make([]dto.CreateLectureResult, 0, len(rows)),
+	}
+
+	for _, row := range rows {
+		result := dto.CreateLectureResult{
+			Index:     row.Index,
+			Status:    row.Status,
+			Errors:    row.Errors,
+			Conflicts: row.Conflicts,
+		}
+
+		if row.Lecture != nil {
+			result.Lecture = LectureToDto(row.Lecture, view)
+		}
+
+		if row.Status == entitys.BatchRowCreated {
+			resp.Created++
+		} else {
+			resp.Failed++
+		}
+
+		resp.Results = append(resp.Results, result)
+	}
+
+	return resp
+}
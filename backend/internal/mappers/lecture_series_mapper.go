@@ -0,0 +1,144 @@
+package mappers
+
+import (
+	"table-api/internal/handler/dto"
+	"table-api/internal/models"
+	"table-api/internal/visibility"
+	"table-api/pkg/timezone"
+	"table-api/pkg/vault"
+	"time"
+)
+
+func DtoToLectureSeries(dto dto.CreateLectureSeriesRequest) *models.LectureSeries {
+	return &models.LectureSeries{
+		RRule:        dto.RRule,
+		StartDate:    timezone.Day(dto.StartDate),
+		ExDates:      days(dto.ExDates),
+		Group:        dto.Group,
+		Lector:       dto.Lector,
+		Platform:     dto.Platform,
+		Unit:         dto.Unit,
+		Location:     dto.Location,
+		URL:          dto.URL,
+		StreamKey:    vault.New(dto.StreamKey),
+		Description:  dto.Description,
+		Admin:        dto.Admin,
+		Start:        dto.Start,
+		End:          dto.End,
+		AbnormalTime: dto.AbnormalTime,
+	}
+}
+
+// SeriesToLecture создаёт лекцию серии на указанную дату.
+func SeriesToLecture(series *models.LectureSeries, date time.Time) *models.Lecture {
+	var seriesID *int
+	if series.ID != 0 {
+		id := series.ID
+		seriesID = &id
+	}
+
+	return &models.Lecture{
+		Group:        series.Group,
+		Lector:       series.Lector,
+		Platform:     series.Platform,
+		Unit:         series.Unit,
+		Location:     series.Location,
+		URL:          series.URL,
+		ShortURL:     series.ShortURL,
+		StreamKey:    series.StreamKey,
+		Description:  series.Description,
+		Admin:        series.Admin,
+		Date:         date,
+		Start:        series.Start,
+		End:          series.End,
+		AbnormalTime: series.AbnormalTime,
+		SeriesID:     seriesID,
+	}
+}
+
+// ApplyUpdateToSeries переносит заполненные поля обновления в шаблон серии.
+func ApplyUpdateToSeries(series *models.LectureSeries, dto dto.UpdateLectureRequest) {
+	if dto.Group != nil {
+		series.Group = dto.Group
+	}
+	if dto.Lector != nil {
+		series.Lector = dto.Lector
+	}
+	if dto.Platform != nil {
+		series.Platform = dto.Platform
+	}
+	if dto.Unit != nil {
+		series.Unit = dto.Unit
+	}
+	if dto.Location != nil {
+		series.Location = dto.Location
+	}
+	if dto.URL != nil {
+		series.URL = dto.URL
+	}
+	if dto.ShortURL != nil {
+		series.ShortURL = dto.ShortURL
+	}
+	if key := vault.New(dto.StreamKey); key != nil {
+		series.StreamKey = key
+	}
+	if dto.Description != nil {
+		series.Description = dto.Description
+	}
+	if dto.Admin != nil {
+		series.Admin = dto.Admin
+	}
+	if dto.Start != nil {
+		series.Start = dto.Start
+	}
+	if dto.End != nil {
+		series.End = dto.End
+	}
+	if dto.AbnormalTime != nil {
+		series.AbnormalTime = dto.AbnormalTime
+	}
+}
+
+func LectureSeriesToDto(series *models.LectureSeries, lectures []*models.Lecture, view visibility.View) *dto.LectureSeriesResponse {
+	resp := &dto.LectureSeriesResponse{
+		ID:           series.ID,
+		RRule:        series.RRule,
+		StartDate:    series.StartDate,
+		ExDates:      series.ExDates,
+		Group:        series.Group,
+		Lector:       series.Lector,
+		Platform:     series.Platform,
+		Unit:         series.Unit,
+		Location:     series.Location,
+		URL:          series.URL,
+		ShortURL:     series.ShortURL,
+		StreamKey:    vault.Plain(series.StreamKey),
+		Description:  series.Description,
+		Admin:        series.Admin,
+		Start:        series.Start,
+		End:          series.End,
+		AbnormalTime: series.AbnormalTime,
+		Lectures:     ManyLectureToDto(lectures, view),
+		CreatedAt:    series.CreatedAt,
+		UpdatedAt:    series.UpdatedAt,
+	}
+
+	// Поля шаблона серии называются так же, как поля лекции
+	visibility.Apply(resp, view.Lecture)
+
+	return resp
+}
+
+// days переносит календарные дни запроса в пояс организации.
+func days(dates []time.Time) []time.Time {
+	if dates == nil {
+		return nil
+	}
+
+	result := make([]time.Time, 0, len(dates))
+	for _, d := range dates {
+		result = append(result, timezone.Day(d))
+	}
+
+	return result
+}
@@ -3,9 +3,13 @@ package mappers
 import (
 	"table-api/internal/handler/dto"
 	"table-api/internal/models"
+	"table-api/internal/visibility"
+	"table-api/pkg/timezone"
+	"time"
 )
 
-func DtoToMeet(dto *dto.CreateMeetRequest) *models.Meet {
+// DtoToMeet читает время без смещения в поясе zone.
+func DtoToMeet(dto *dto.CreateMeetRequest, zone *time.Location) *models.Meet {
 	if dto == nil {
 		return nil
 	}
@@ -24,17 +28,18 @@ func DtoToMeet(dto *dto.CreateMeetRequest) *models.Meet {
 		Description: dto.Description,
 		Admin:       dto.Admin,
 
-		Start: dto.Start,
-		End:   dto.End,
+		Start: zonedTime(dto.Start, zone),
+		End:   zonedTime(dto.End, zone),
 	}
 }
 
-func MeetToDto(meet *models.Meet) *dto.MeetResponse {
+// MeetToDto собирает ответ и применяет к нему правила видимости роли.
+func MeetToDto(meet *models.Meet, view visibility.View) *dto.MeetResponse {
 	if meet == nil {
 		return nil
 	}
 
-	return &dto.MeetResponse{
+	resp := &dto.MeetResponse{
 		Id:           meet.ID,
 		EventName:    meet.EventName,
 		CustomerName: meet.CustomerName,
@@ -49,21 +54,38 @@ func MeetToDto(meet *models.Meet) *dto.MeetResponse {
 		Status:      &meet.Status,
 		Description: meet.Description,
 		Admin:       meet.Admin,
+		AdminID:     uuidToString(meet.AdminID),
+		Version:     meet.Version,
 
 		Start:     meet.Start,
 		End:       meet.End,
 		CreatedAt: meet.CreatedAt,
 		UpdatedAt: meet.UpdatedAt,
+		DeletedAt: deletedAtToTime(meet.DeletedAt),
+		Warnings:  meet.Warnings,
 	}
+
+	visibility.Apply(resp, view.Meet)
+
+	return resp
 }
 
-func MeetsToDto(meets []*models.Meet) []dto.MeetResponse {
+func MeetsToDto(meets []*models.Meet, view visibility.View) []dto.MeetResponse {
 	var result []dto.MeetResponse
 
 	for _, m := range meets {
-		mr := MeetToDto(m)
+		mr := MeetToDto(m, view)
 		result = append(result, *mr)
 	}
 
 	return result
 }
+
+func zonedTime(t *timezone.Time, zone *time.Location) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	zoned := t.In(zone)
+	return &zoned
+}
@@ -0,0 +1,17 @@
+package mappers
+
+import (
+	"table-api/internal/entitys"
+	"table-api/internal/handler/dto"
+)
+
+func PaginationToDto(pagination *entitys.Pagination) dto.PaginationResponse {
+	return dto.PaginationResponse{
+		CurrentPage:     pagination.CurrentPage,
+		TotalItems:      pagination.TotalItems,
+		TotalPages:      pagination.TotalPages,
+		ItemsPerPage:    pagination.ItemsPerPage,
+		HasNextPage:     pagination.HasNextPage,
+		HasPreviousPage: pagination.HasPreviousPage,
+	}
+}
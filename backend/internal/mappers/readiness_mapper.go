@@ -0,0 +1,40 @@
+package mappers
+
+import (
+	"table-api/internal/entitys"
+	"table-api/internal/handler/dto"
+)
+
+func ReadinessToDto(result *entitys.Readiness) dto.ReadinessResponse {
+	resp := dto.ReadinessResponse{
+		Start:   result.Start,
+		End:     result.End,
+		Checked: result.Checked,
+		Count:   len(result.Items),
+		Data:    make([]dto.ReadinessItemResponse, 0, len(result.Items)),
+	}
+
+	for _, item := range result.Items {
+		issues := make([]dto.ReadinessIssueResponse, 0, len(item.Issues))
+		for _, issue := range item.Issues {
+			issues = append(issues, dto.ReadinessIssueResponse{
+				Rule:     issue.Rule,
+				Severity: issue.Severity,
+				Message:  issue.Message,
+				Details:  issue.Details,
+			})
+		}
+
+		resp.Data = append(resp.Data, dto.ReadinessItemResponse{
+			Kind:   item.Kind,
+			ID:     item.ID,
+			Title:  item.Title,
+			Date:   item.Date,
+			Time:   item.Time,
+			Admin:  item.Admin,
+			Issues: issues,
+		})
+	}
+
+	return resp
+}
@@ -0,0 +1,38 @@
+package mappers
+
+import (
+	"table-api/internal/handler/dto"
+	"table-api/internal/models"
+)
+
+func RoomToDto(room *models.Room) dto.RoomResponse {
+	equipment, platforms := room.Equipment, room.Platforms
+	if equipment == nil {
+		equipment = []string{}
+	}
+	if platforms == nil {
+		platforms = []string{}
+	}
+
+	return dto.RoomResponse{
+		ID:         room.ID,
+		LocationID: room.LocationID,
+		Location:   room.Location.Name,
+		Building:   room.Building,
+		Capacity:   room.Capacity,
+		Equipment:  equipment,
+		Platforms:  platforms,
+		TimeZone:   room.TimeZone,
+		CreatedAt:  room.CreatedAt,
+		UpdatedAt:  room.UpdatedAt,
+	}
+}
+
+func RoomsToDto(rooms []*models.Room) []dto.RoomResponse {
+	resp := make([]dto.RoomResponse, 0, len(rooms))
+	for _, room := range rooms {
+		resp = append(resp, RoomToDto(room))
+	}
+
+	return resp
+}
@@ -0,0 +1,17 @@
+package mappers
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// deletedAtToTime возвращает время попадания записи в корзину или nil.
+func deletedAtToTime(deletedAt gorm.DeletedAt) *time.Time {
+	if !deletedAt.Valid {
+		return nil
+	}
+
+	t := deletedAt.Time
+	return &t
+}
@@ -4,6 +4,8 @@ import (
 	"table-api/internal/entitys"
 	"table-api/internal/handler/dto"
 	"table-api/internal/models"
+
+	"github.com/google/uuid"
 )
 
 func ToUserResponse(u models.User) dto.UserResponse {
@@ -11,12 +13,24 @@ func ToUserResponse(u models.User) dto.UserResponse {
 		ID:        u.ID.String(),
 		Login:     u.Login,
 		Name:      u.Name,
+		Email:     u.Email,
 		Role:      u.Role,
 		Password:  u.Password,
 		CreatedAt: u.CreatedAt,
+		DeletedAt: deletedAtToTime(u.DeletedAt),
 	}
 }
 
+// uuidToString — ссылка на пользователя в ответе.
+func uuidToString(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+
+	s := id.String()
+	return &s
+}
+
 func ToUsersResponse(users []*models.User) []dto.UserResponse {
 	resp := make([]dto.UserResponse, 0, len(users))
 	for _, u := range users {
@@ -37,6 +51,7 @@ func DtoCreateRequestToUser(user dto.CreateUserRequest) entitys.User {
 	return entitys.User{
 		Login:    user.Login,
 		Name:     user.Name,
+		Email:    user.Email,
 		Role:     role,
 		Password: user.Password,
 	}
@@ -46,6 +61,7 @@ func UserToModel(user entitys.User) models.User {
 	return models.User{
 		Login:    user.Login,
 		Name:     user.Name,
+		Email:    user.Email,
 		Role:     user.Role,
 		Password: user.Password,
 	}
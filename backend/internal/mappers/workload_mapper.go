@@ -0,0 +1,95 @@
+package mappers
+
+import (
+	"math"
+	"table-api/internal/entitys"
+	"table-api/internal/handler/dto"
+	"table-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+func WorkloadToDto(workload *entitys.Workload) dto.WorkloadResponse {
+	resp := dto.WorkloadResponse{
+		Start:      workload.Start,
+		End:        workload.End,
+		Admins:     make([]dto.AdminWorkloadResponse, 0, len(workload.Admins)),
+		Unassigned: workloadWeeksToDto(workload.Unassigned),
+	}
+
+	for _, admin := range workload.Admins {
+		resp.Admins = append(resp.Admins, dto.AdminWorkloadResponse{
+			AdminID:  uuidToString(admin.AdminID),
+			Admin:    admin.Admin,
+			Lectures: admin.Total.Lectures,
+			Meets:    admin.Total.Meets,
+			Hours:    minutesToHours(admin.Total.Minutes),
+			Weeks:    workloadWeeksToDto(admin.Weeks),
+		})
+	}
+
+	return resp
+}
+
+func workloadWeeksToDto(weeks []*entitys.WorkloadWeek) []dto.WorkloadWeekResponse {
+	resp := make([]dto.WorkloadWeekResponse, 0, len(weeks))
+	for _, week := range weeks {
+		resp = append(resp, dto.WorkloadWeekResponse{
+			Week:     week.Week,
+			Start:    week.Start,
+			Lectures: week.Lectures,
+			Meets:    week.Meets,
+			Hours:    minutesToHours(week.Minutes),
+		})
+	}
+
+	return resp
+}
+
+func AutoAssignmentToDto(result *entitys.AutoAssignment, dryRun bool) dto.AutoAssignResponse {
+	resp := dto.AutoAssignResponse{
+		DryRun:   dryRun,
+		Count:    len(result.Assigned),
+		Assigned: make([]dto.AssignmentResponse, 0, len(result.Assigned)),
+		Skipped:  make([]dto.SkippedAssignmentResponse, 0, len(result.Skipped)),
+	}
+
+	for _, assignment := range result.Assigned {
+		resp.Assigned = append(resp.Assigned, dto.AssignmentResponse{
+			Kind:    assignment.Kind,
+			ID:      assignment.ID,
+			Start:   assignment.Start,
+			End:     assignment.End,
+			AdminID: assignment.AdminID.String(),
+			Admin:   assignment.Admin,
+		})
+	}
+
+	for _, skipped := range result.Skipped {
+		resp.Skipped = append(resp.Skipped, dto.SkippedAssignmentResponse{
+			Kind:   skipped.Kind,
+			ID:     skipped.ID,
+			Reason: skipped.Reason,
+		})
+	}
+
+	return resp
+}
+
+func AvailabilityToDto(userID uuid.UUID, slots []*models.Availability) dto.AvailabilityResponse {
+	resp := dto.AvailabilityResponse{
+		UserID: userID.String(),
+		Slots:  make([]dto.AvailabilityDto, 0, len(slots)),
+	}
+
+	for _, slot := range slots {
+		resp.Slots = append(resp.Slots, dto.AvailabilityDto{Weekday: slot.Weekday, Start: slot.Start, End: slot.End})
+	}
+
+	return resp
+}
+
+// minutesToHours округляет часы до сотых.
+func minutesToHours(minutes int) float64 {
+	return math.Round(float64(minutes)/60*100) / 100
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditLog — запись журнала изменений сущности.
+type AuditLog struct {
+	ID         int           `gorm:"primaryKey;autoIncrement"`
+	EntityType string        `gorm:"type:text;not null;index:idx_audit_entity"`
+	EntityID   string        `gorm:"type:text;not null;index:idx_audit_entity"`
+	Action     string        `gorm:"type:text;not null"`
+	UserID     *uuid.UUID    `gorm:"type:uuid;index"`
+	Changes    []AuditChange `gorm:"type:jsonb;serializer:json"`
+	CreatedAt  time.Time     `gorm:"autoCreateTime;index"`
+}
+
+// AuditChange — значение поля до и после изменения.
+type AuditChange struct {
+	Field  string `json:"field"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"table-api/pkg/utils"
+
+	"github.com/google/uuid"
+)
+
+// Availability — окно, в которое техник готов работать: день недели
+// (0 — воскресенье, как в time.Weekday) и время суток в поясе организации.
+type Availability struct {
+	ID      int         `gorm:"primaryKey;autoIncrement"`
+	UserID  uuid.UUID   `gorm:"type:uuid;not null;index"`
+	Weekday int         `gorm:"not null"`
+	Start   utils.Clock `gorm:"type:time;not null"`
+	End     utils.Clock `gorm:"type:time;not null"`
+}
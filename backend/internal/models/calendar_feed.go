@@ -0,0 +1,15 @@
+package models
+
+import (
+	"time"
+)
+
+// CalendarFeed — подписка на расписание группы, преподавателя или аудитории.
+// Доступ к ленте выдаётся по секретному токену вместо JWT.
+type CalendarFeed struct {
+	ID        int       `gorm:"primaryKey;autoIncrement"`
+	Kind      string    `gorm:"type:text;not null;index:idx_calendar_feed_kind_name"`
+	Name      string    `gorm:"type:text;not null;index:idx_calendar_feed_kind_name"`
+	Token     string    `gorm:"not null;unique"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
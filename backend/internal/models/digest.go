@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Что попадает в сводку расписания на завтра
+const (
+	// Лекции и мероприятия, где пользователь назначен админом
+	DigestScopeAdmin = "admin"
+	// Лекции лектора из справочника
+	DigestScopeLector = "lector"
+	// Всё расписание; доступно админам и модераторам
+	DigestScopeAll = "all"
+)
+
+// DigestSubscription — подписка пользователя на вечернюю сводку расписания на завтра.
+type DigestSubscription struct {
+	ID       int       `gorm:"primaryKey;autoIncrement"`
+	UserID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
+	Enabled  bool      `gorm:"not null;default:false"`
+	Scope    string    `gorm:"type:text;not null"`
+	LectorID *int      `gorm:"index"`
+	// Название лектора на момент подписки
+	Lector *string `gorm:"type:text"`
+
+	CreatedAt time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt *time.Time `gorm:"autoUpdateTime"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+)
+
+// Виды справочников
+const (
+	DirectoryGroup    = "group"
+	DirectoryLector   = "lector"
+	DirectoryUnit     = "unit"
+	DirectoryLocation = "location"
+)
+
+// DirectoryEntry — запись справочника групп, преподавателей, подразделений или мест.
+// Лекции ссылаются на запись по ID и хранят её название для выборок и выгрузки.
+type DirectoryEntry struct {
+	ID        int        `gorm:"primaryKey;autoIncrement"`
+	Kind      string     `gorm:"type:text;not null;uniqueIndex:idx_directory_kind_key"`
+	Name      string     `gorm:"type:text;not null"`
+	Key       string     `gorm:"type:text;not null;uniqueIndex:idx_directory_kind_key"`
+	Email     *string    `gorm:"type:text"`
+	Aliases   []string   `gorm:"type:jsonb;serializer:json"`
+	AliasKeys []string   `gorm:"type:jsonb;serializer:json"`
+	CreatedAt time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt *time.Time `gorm:"autoUpdateTime"`
+}
+
+// DirectoryMerge — строка отчёта о слиянии: вариант написания, сведённый к записи справочника.
+type DirectoryMerge struct {
+	ID        int       `gorm:"primaryKey;autoIncrement"`
+	Kind      string    `gorm:"type:text;not null;index"`
+	EntryID   int       `gorm:"not null;index"`
+	Variant   string    `gorm:"type:text;not null"`
+	Canonical string    `gorm:"type:text;not null"`
+	Lectures  int64     `gorm:"not null"`
+	Source    string    `gorm:"type:text;not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+// Источники строк отчёта о слиянии
+const (
+	DirectoryMergeMigration = "migration"
+	DirectoryMergeManual    = "merge"
+	// Сведение, найденное миграцией и ожидающее DIRECTORY_MERGE_APPLY
+	DirectoryMergePlanned = "planned"
+)
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+)
+
+// ExportColumn — колонка выгрузки: поле лекции и подпись в заголовке.
+type ExportColumn struct {
+	Field  string `json:"field"`
+	Header string `json:"header"`
+}
+
+// ExportTemplate — именованный набор колонок и форматов выгрузки лекций.
+// Форматы даты и времени хранятся токенами вида "DD.MM.YYYY" и "HH:mm".
+type ExportTemplate struct {
+	ID         int            `gorm:"primaryKey;autoIncrement"`
+	Name       string         `gorm:"type:text;not null;uniqueIndex"`
+	Columns    []ExportColumn `gorm:"type:jsonb;serializer:json"`
+	DateFormat string         `gorm:"type:text;not null"`
+	TimeFormat string         `gorm:"type:text;not null"`
+	CreatedAt  time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt  *time.Time     `gorm:"autoUpdateTime"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IdempotencyKey — ответ на запрос с заголовком Idempotency-Key, который отдаётся
+// повторно при ретрае. Пока исходный запрос выполняется, Status равен нулю.
+type IdempotencyKey struct {
+	ID     int       `gorm:"primaryKey;autoIncrement"`
+	UserID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_idempotency_user_key"`
+	Key    string    `gorm:"type:text;not null;uniqueIndex:idx_idempotency_user_key"`
+	// Хеш метода, пути и тела запроса
+	Fingerprint string `gorm:"type:text;not null"`
+	Status      int    `gorm:"not null;default:0"`
+	Body        []byte `gorm:"type:bytea"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime;index"`
+}
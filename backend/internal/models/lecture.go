@@ -1,28 +1,48 @@
 package models
 
 import (
+	"table-api/pkg/utils"
+	"table-api/pkg/vault"
 	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type Lecture struct {
-	ID        int     `gorm:"primaryKey;autoIncrement"`
-	Group     *string `gorm:"type:text"`
-	Lector    *string `gorm:"type:text"`
-	Platform  *string `gorm:"type:text"`
-	Unit      *string `gorm:"type:text"`
-	Location  *string `gorm:"type:text"`
-	URL       *string `gorm:"type:text"`
-	ShortURL  *string `gorm:"type:text"`
-	StreamKey *string `gorm:"type:text"`
+	ID        int           `gorm:"primaryKey;autoIncrement"`
+	Group     *string       `gorm:"type:text"`
+	Lector    *string       `gorm:"type:text"`
+	Platform  *string       `gorm:"type:text"`
+	Unit      *string       `gorm:"type:text"`
+	Location  *string       `gorm:"type:text"`
+	URL       *string       `gorm:"type:text"`
+	ShortURL  *string       `gorm:"type:text"`
+	StreamKey *vault.Secret `gorm:"type:text"`
 
 	Description *string `gorm:"type:text"`
 	Admin       *string `gorm:"type:text;"`
+	// Пользователь-админ; Admin хранит его имя для выборок и выгрузки
+	AdminID *uuid.UUID `gorm:"type:uuid;index"`
+
+	Date         time.Time    `gorm:"not null"`
+	Start        *utils.Clock `gorm:"type:time"`
+	End          *utils.Clock `gorm:"type:time"`
+	AbnormalTime *string      `gorm:"type:text"`
+
+	GroupID    *int `gorm:"index"`
+	LectorID   *int `gorm:"index"`
+	UnitID     *int `gorm:"index"`
+	LocationID *int `gorm:"index"`
+
+	SeriesID *int `gorm:"index"`
 
-	Date         time.Time `gorm:"not null"`
-	Start        *string   `gorm:"type:text"`
-	End          *string   `gorm:"type:text"`
-	AbnormalTime *string   `gorm:"type:text"`
+	CreatedAt time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt *time.Time     `gorm:"autoUpdateTime"`
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+	// Растёт при каждом изменении; по ней отклоняются устаревшие правки
+	Version int `gorm:"not null;default:1"`
 
-	CreatedAt time.Time  `gorm:"autoCreateTime"`
-	UpdatedAt *time.Time `gorm:"autoUpdateTime"`
+	// Предупреждения о несоответствии аудитории, не сохраняются
+	Warnings []string `gorm:"-"`
 }
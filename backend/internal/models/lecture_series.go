@@ -0,0 +1,35 @@
+package models
+
+import (
+	"table-api/pkg/utils"
+	"table-api/pkg/vault"
+	"time"
+)
+
+// LectureSeries — серия повторяющихся лекций.
+// Поля шаблона копируются в каждую сгенерированную лекцию.
+type LectureSeries struct {
+	ID        int         `gorm:"primaryKey;autoIncrement"`
+	RRule     string      `gorm:"column:rrule;type:text;not null"`
+	StartDate time.Time   `gorm:"not null"`
+	ExDates   []time.Time `gorm:"type:text;serializer:json"`
+
+	Group     *string       `gorm:"type:text"`
+	Lector    *string       `gorm:"type:text"`
+	Platform  *string       `gorm:"type:text"`
+	Unit      *string       `gorm:"type:text"`
+	Location  *string       `gorm:"type:text"`
+	URL       *string       `gorm:"type:text"`
+	ShortURL  *string       `gorm:"type:text"`
+	StreamKey *vault.Secret `gorm:"type:text"`
+
+	Description *string `gorm:"type:text"`
+	Admin       *string `gorm:"type:text;"`
+
+	Start        *utils.Clock `gorm:"type:time"`
+	End          *utils.Clock `gorm:"type:time"`
+	AbnormalTime *string      `gorm:"type:text"`
+
+	CreatedAt time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt *time.Time `gorm:"autoUpdateTime"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LinkOperation — массовая простановка ссылки лекциям группы.
+// Хранит прежние ссылки изменённых лекций, чтобы операцию можно было отменить.
+type LinkOperation struct {
+	ID       int          `gorm:"primaryKey;autoIncrement"`
+	GroupID  int          `gorm:"not null;index"`
+	Group    string       `gorm:"type:text;not null"`
+	URL      string       `gorm:"type:text;not null"`
+	ShortURL string       `gorm:"type:text;not null"`
+	UserID   *uuid.UUID   `gorm:"type:uuid;index"`
+	Changes  []LinkChange `gorm:"type:jsonb;serializer:json"`
+
+	CreatedAt time.Time `gorm:"autoCreateTime;index"`
+	UndoneAt  *time.Time
+}
+
+// LinkChange — ссылки лекции до операции.
+type LinkChange struct {
+	LectureID int     `json:"lectureId"`
+	URL       *string `json:"url"`
+	ShortURL  *string `json:"shortUrl"`
+}
@@ -2,6 +2,9 @@ package models
 
 import (
 	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 // Meet — мероприятие
@@ -20,10 +23,17 @@ type Meet struct {
 	Status      string  `gorm:"type:text;default:'new'"`
 	Description *string `gorm:"type:text"`
 
-	Admin *string `gorm:"type:text;"`
+	Admin   *string    `gorm:"type:text;"`
+	AdminID *uuid.UUID `gorm:"type:uuid;index"`
 
 	Start     *time.Time
 	End       *time.Time
-	CreatedAt time.Time  `gorm:"autoCreateTime"`
-	UpdatedAt *time.Time `gorm:"autoUpdateTime"`
+	CreatedAt time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt *time.Time     `gorm:"autoUpdateTime"`
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+	// Растёт при каждом изменении; по ней отклоняются устаревшие правки
+	Version int `gorm:"not null;default:1"`
+
+	// Предупреждения о несоответствии аудитории, не сохраняются
+	Warnings []string `gorm:"-"`
 }
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+)
+
+// Room — аудитория из каталога. Привязана к записи справочника мест,
+// поэтому лекции находят свою аудиторию по LocationID.
+type Room struct {
+	ID         int            `gorm:"primaryKey;autoIncrement"`
+	LocationID int            `gorm:"not null;uniqueIndex"`
+	Location   DirectoryEntry `gorm:"foreignKey:LocationID;constraint:OnDelete:RESTRICT"`
+	Building   *string        `gorm:"type:text"`
+	Capacity   int            `gorm:"not null;default:0"`
+	Equipment  []string       `gorm:"type:jsonb;serializer:json"`
+	Platforms  []string       `gorm:"type:jsonb;serializer:json"`
+	// Часовой пояс места, если он отличается от пояса организации
+	TimeZone  *string    `gorm:"type:text"`
+	CreatedAt time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt *time.Time `gorm:"autoUpdateTime"`
+}
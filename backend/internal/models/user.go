@@ -4,14 +4,19 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type User struct {
-	ID    uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
-	Login string    `gorm:"unique;not null"`
-	Name  *string   `gorm:"type:text"`
-	Role  string    `gorm:"not null"`
+	ID uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	// Логин уникален среди пользователей вне корзины: удалённый логин можно занять снова
+	Login string  `gorm:"not null;uniqueIndex:idx_users_login,where:deleted_at IS NULL"`
+	Name  *string `gorm:"type:text"`
+	// Адрес для рассылок: отчёты о готовности и сводки
+	Email *string `gorm:"type:text"`
+	Role  string  `gorm:"not null"`
 	// TODO: hash
-	Password  string    `gorm:"not null"`
-	CreatedAt time.Time `gorm:"autoCreateTime"`
+	Password  string         `gorm:"not null"`
+	CreatedAt time.Time      `gorm:"autoCreateTime"`
+	DeletedAt gorm.DeletedAt `gorm:"index"`
 }
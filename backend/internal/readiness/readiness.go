@@ -0,0 +1,296 @@
+package readiness
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"table-api/internal/handler/dto"
+)
+
+// Записи, к которым применяются правила
+const (
+	EntityLecture = "lecture"
+	EntityMeet    = "meet"
+)
+
+// Важность замечания
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Проверки, которые не сводятся к списку обязательных полей
+const (
+	// Конец позже начала
+	CheckTimeOrder = "timeOrder"
+	// Короткая ссылка ведёт на url записи
+	CheckShortLink = "shortLink"
+	// Платформа и оборудование подходят аудитории
+	CheckRoom = "room"
+)
+
+var checks = []string{CheckTimeOrder, CheckShortLink, CheckRoom}
+
+// Condition выполняется, если поле Field заполнено, совпадает с одним из In
+// (если список задан) и не совпадает ни с одним из NotIn. Регистр не учитывается.
+type Condition struct {
+	Field string   `json:"field"`
+	In    []string `json:"in,omitempty"`
+	NotIn []string `json:"notIn,omitempty"`
+}
+
+// Rule — правило готовности. Запись, подходящая под все условия When,
+// должна иметь заполненными поля Require и проходить проверку Check.
+// Поля называются так же, как в JSON-ответе записи.
+type Rule struct {
+	Name     string      `json:"name"`
+	Entity   string      `json:"entity"`
+	When     []Condition `json:"when,omitempty"`
+	Require  []string    `json:"require,omitempty"`
+	Check    string      `json:"check,omitempty"`
+	Severity string      `json:"severity"`
+	Message  string      `json:"message"`
+}
+
+type Rules struct {
+	Rules []Rule `json:"rules"`
+}
+
+// Платформы очных занятий: для остальных по умолчанию нужны ссылки
+var offlinePlatforms = []string{"Очно", "Офлайн", "Offline"}
+
+// DefaultRules действуют, пока правила не заданы файлом.
+func DefaultRules() *Rules {
+	online := []Condition{{Field: "platform", NotIn: offlinePlatforms}}
+
+	return &Rules{Rules: []Rule{
+		{
+			Name: "lecture-admin", Entity: EntityLecture, Require: []string{"admin"},
+			Severity: SeverityError, Message: "no admin assigned",
+		},
+		{
+			Name: "lecture-time", Entity: EntityLecture, Require: []string{"start", "end"},
+			Severity: SeverityWarning, Message: "start or end time is not set",
+		},
+		{
+			Name: "lecture-time-order", Entity: EntityLecture, Check: CheckTimeOrder,
+			Severity: SeverityError, Message: "lecture ends before it starts",
+		},
+		{
+			Name: "lecture-online-links", Entity: EntityLecture, When: online, Require: []string{"url", "shortUrl"},
+			Severity: SeverityError, Message: "online platform requires url and short link",
+		},
+		{
+			Name: "lecture-online-stream-key", Entity: EntityLecture, When: online, Require: []string{"streamKey"},
+			Severity: SeverityWarning, Message: "online platform requires stream key",
+		},
+		{
+			Name: "lecture-short-link", Entity: EntityLecture, When: []Condition{{Field: "shortUrl"}}, Check: CheckShortLink,
+			Severity: SeverityError, Message: "short link does not lead to lecture url",
+		},
+		{
+			Name: "lecture-room", Entity: EntityLecture, Check: CheckRoom,
+			Severity: SeverityWarning, Message: "room does not match platform",
+		},
+		{
+			Name: "meet-admin", Entity: EntityMeet, Require: []string{"admin"},
+			Severity: SeverityError, Message: "no admin assigned",
+		},
+		{
+			Name: "meet-time-order", Entity: EntityMeet, Check: CheckTimeOrder,
+			Severity: SeverityError, Message: "meet ends before it starts",
+		},
+		{
+			Name: "meet-online-links", Entity: EntityMeet, When: online, Require: []string{"url", "shortUrl"},
+			Severity: SeverityError, Message: "online platform requires url and short link",
+		},
+		{
+			Name: "meet-short-link", Entity: EntityMeet, When: []Condition{{Field: "shortUrl"}}, Check: CheckShortLink,
+			Severity: SeverityError, Message: "short link does not lead to meet url",
+		},
+		{
+			Name: "meet-room", Entity: EntityMeet, Check: CheckRoom,
+			Severity: SeverityWarning, Message: "room does not match platform or devices",
+		},
+	}}
+}
+
+// Load читает правила из JSON-файла. Пустой путь — правила по умолчанию.
+func Load(path string) (*Rules, error) {
+	if path == "" {
+		return DefaultRules(), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read readiness rules: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var rules Rules
+	if err := decoder.Decode(&rules); err != nil {
+		return nil, fmt.Errorf("invalid readiness rules: %w", err)
+	}
+
+	if err := rules.validate(); err != nil {
+		return nil, fmt.Errorf("invalid readiness rules: %w", err)
+	}
+
+	return &rules, nil
+}
+
+// For возвращает правила для вида записи.
+func (r *Rules) For(entity string) []Rule {
+	var rules []Rule
+	for _, rule := range r.Rules {
+		if rule.Entity == entity {
+			rules = append(rules, rule)
+		}
+	}
+
+	return rules
+}
+
+func (r *Rules) validate() error {
+	fields := map[string]map[string]struct{}{
+		EntityLecture: jsonFields(reflect.TypeOf(dto.LectureResponse{})),
+		EntityMeet:    jsonFields(reflect.TypeOf(dto.MeetResponse{})),
+	}
+
+	names := make(map[string]struct{}, len(r.Rules))
+
+	for i, rule := range r.Rules {
+		if rule.Name == "" {
+			return fmt.Errorf("rule %d: name is required", i+1)
+		}
+		if _, ok := names[rule.Name]; ok {
+			return fmt.Errorf("duplicate rule %q", rule.Name)
+		}
+		names[rule.Name] = struct{}{}
+
+		known, ok := fields[rule.Entity]
+		if !ok {
+			return fmt.Errorf("%s: unknown entity %q", rule.Name, rule.Entity)
+		}
+
+		if rule.Severity != SeverityError && rule.Severity != SeverityWarning {
+			return fmt.Errorf("%s: unknown severity %q", rule.Name, rule.Severity)
+		}
+
+		if len(rule.Require) == 0 && rule.Check == "" {
+			return fmt.Errorf("%s: require or check is needed", rule.Name)
+		}
+
+		if rule.Check != "" && !slices.Contains(checks, rule.Check) {
+			return fmt.Errorf("%s: unknown check %q", rule.Name, rule.Check)
+		}
+
+		for _, condition := range rule.When {
+			if _, ok := known[condition.Field]; !ok {
+				return fmt.Errorf("%s: unknown field %q", rule.Name, condition.Field)
+			}
+		}
+
+		for _, field := range rule.Require {
+			if _, ok := known[field]; !ok {
+				return fmt.Errorf("%s: unknown field %q", rule.Name, field)
+			}
+		}
+
+		if strings.TrimSpace(rule.Message) == "" {
+			return fmt.Errorf("%s: message is required", rule.Name)
+		}
+	}
+
+	return nil
+}
+
+// Applies проверяет условия правила на DTO ответа по указателю.
+func (r Rule) Applies(resp any) bool {
+	for _, condition := range r.When {
+		value := fieldValue(resp, condition.Field)
+		if value == "" {
+			return false
+		}
+
+		if len(condition.In) > 0 && !containsFold(condition.In, value) {
+			return false
+		}
+
+		if containsFold(condition.NotIn, value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Missing возвращает незаполненные обязательные поля.
+func (r Rule) Missing(resp any) []string {
+	var missing []string
+	for _, field := range r.Require {
+		if fieldValue(resp, field) == "" {
+			missing = append(missing, field)
+		}
+	}
+
+	return missing
+}
+
+func containsFold(values []string, value string) bool {
+	return slices.ContainsFunc(values, func(v string) bool {
+		return strings.EqualFold(strings.TrimSpace(v), value)
+	})
+}
+
+// fieldValue возвращает значение поля по JSON-имени строкой; пустая строка — поле не заполнено.
+func fieldValue(resp any, name string) string {
+	v := reflect.ValueOf(resp).Elem()
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		if jsonName(t.Field(i)) != name {
+			continue
+		}
+
+		field := v.Field(i)
+		switch {
+		case field.Kind() == reflect.Pointer && field.IsNil():
+			return ""
+		case field.Kind() == reflect.Pointer:
+			field = field.Elem()
+		case field.IsZero():
+			return ""
+		}
+
+		return strings.TrimSpace(fmt.Sprint(field.Interface()))
+	}
+
+	return ""
+}
+
+func jsonFields(t reflect.Type) map[string]struct{} {
+	fields := make(map[string]struct{}, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		if name := jsonName(t.Field(i)); name != "" {
+			fields[name] = struct{}{}
+		}
+	}
+
+	return fields
+}
+
+func jsonName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "-" {
+		return ""
+	}
+
+	return name
+}
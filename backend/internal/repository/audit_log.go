@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"table-api/internal/entitys"
+	"table-api/internal/handler/dto"
+	"table-api/internal/models"
+	"table-api/internal/repository/gormerrors"
+
+	"gorm.io/gorm"
+)
+
+type auditLogRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditLogRepository(db *gorm.DB) *auditLogRepository {
+	return &auditLogRepository{db: db}
+}
+
+func (a *auditLogRepository) CreateMany(ctx context.Context, logs []*models.AuditLog) error {
+	if len(logs) == 0 {
+		return nil
+	}
+
+	if err := dbFromContext(ctx, a.db).Create(logs).Error; err != nil {
+		return gormerrors.Map(err)
+	}
+
+	return nil
+}
+
+func (a *auditLogRepository) FindByEntity(ctx context.Context, entityType, entityID string) ([]*models.AuditLog, error) {
+	var logs []*models.AuditLog
+
+	err := dbFromContext(ctx, a.db).
+		Where("entity_type = ? AND entity_id = ?", entityType, entityID).
+		Order("created_at DESC, id DESC").
+		Find(&logs).
+		Error
+
+	if err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return logs, nil
+}
+
+func (a *auditLogRepository) List(
+	ctx context.Context,
+	page int,
+	limit int,
+	filter dto.GetQueryAuditDto,
+) ([]*models.AuditLog, *entitys.Pagination, error) {
+	offset := (page - 1) * limit
+
+	var (
+		logs       []*models.AuditLog
+		totalItems int64
+	)
+
+	query := dbFromContext(ctx, a.db).Model(&models.AuditLog{})
+
+	if filter.UserID != nil {
+		query = query.Where("user_id = ?", *filter.UserID)
+	}
+
+	if filter.EntityType != nil {
+		query = query.Where("entity_type = ?", *filter.EntityType)
+	}
+
+	if filter.EntityID != nil {
+		query = query.Where("entity_id = ?", *filter.EntityID)
+	}
+
+	if filter.Action != nil {
+		query = query.Where("action = ?", *filter.Action)
+	}
+
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+
+	if filter.To != nil {
+		query = query.Where("created_at <= ?", *filter.To)
+	}
+
+	if err := query.Count(&totalItems).Error; err != nil {
+		return nil, nil, gormerrors.Map(err)
+	}
+
+	if err := query.
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&logs).
+		Error; err != nil {
+		return nil, nil, gormerrors.Map(err)
+	}
+
+	pagination := entitys.BuildPagination(page, limit, totalItems)
+	return logs, &pagination, nil
+}
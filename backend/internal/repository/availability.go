@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"table-api/internal/models"
+	"table-api/internal/repository/gormerrors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type availabilityRepository struct {
+	db *gorm.DB
+}
+
+func NewAvailabilityRepository(db *gorm.DB) *availabilityRepository {
+	return &availabilityRepository{db: db}
+}
+
+func (r *availabilityRepository) ListByUsers(ctx context.Context, userIDs []uuid.UUID) ([]*models.Availability, error) {
+	var slots []*models.Availability
+
+	if len(userIDs) == 0 {
+		return slots, nil
+	}
+
+	err := dbFromContext(ctx, r.db).
+		Where("user_id IN ?", userIDs).
+		Order("weekday ASC, start ASC").
+		Find(&slots).
+		Error
+
+	if err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return slots, nil
+}
+
+// Replace заменяет все окна пользователя на переданные.
+func (r *availabilityRepository) Replace(ctx context.Context, userID uuid.UUID, slots []*models.Availability) ([]*models.Availability, error) {
+	db := dbFromContext(ctx, r.db)
+
+	if err := db.Where("user_id = ?", userID).Delete(&models.Availability{}).Error; err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	if len(slots) == 0 {
+		return slots, nil
+	}
+
+	for _, slot := range slots {
+		slot.UserID = userID
+	}
+
+	if err := db.Create(&slots).Error; err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return slots, nil
+}
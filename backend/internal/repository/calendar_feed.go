@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"table-api/internal/models"
+	"table-api/internal/repository/gormerrors"
+
+	"gorm.io/gorm"
+)
+
+type calendarFeedRepository struct {
+	db *gorm.DB
+}
+
+func NewCalendarFeedRepository(db *gorm.DB) *calendarFeedRepository {
+	return &calendarFeedRepository{db: db}
+}
+
+func (c *calendarFeedRepository) Create(ctx context.Context, feed *models.CalendarFeed) (*models.CalendarFeed, error) {
+	if err := dbFromContext(ctx, c.db).Create(feed).Error; err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return feed, nil
+}
+
+func (c *calendarFeedRepository) GetByToken(ctx context.Context, token string) (*models.CalendarFeed, error) {
+	var feed models.CalendarFeed
+
+	if err := dbFromContext(ctx, c.db).Where("token = ?", token).First(&feed).Error; err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return &feed, nil
+}
+
+func (c *calendarFeedRepository) List(ctx context.Context) ([]*models.CalendarFeed, error) {
+	var feeds []*models.CalendarFeed
+
+	if err := dbFromContext(ctx, c.db).Order("kind ASC, name ASC").Find(&feeds).Error; err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return feeds, nil
+}
+
+func (c *calendarFeedRepository) Delete(ctx context.Context, id int) (*models.CalendarFeed, error) {
+	var feed models.CalendarFeed
+
+	if err := dbFromContext(ctx, c.db).First(&feed, id).Error; err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	if err := dbFromContext(ctx, c.db).Delete(&feed).Error; err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return &feed, nil
+}
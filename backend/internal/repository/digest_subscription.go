@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"table-api/internal/models"
+	"table-api/internal/repository/gormerrors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type digestSubscriptionRepository struct {
+	db *gorm.DB
+}
+
+func NewDigestSubscriptionRepository(db *gorm.DB) *digestSubscriptionRepository {
+	return &digestSubscriptionRepository{db: db}
+}
+
+func (r *digestSubscriptionRepository) GetByUser(ctx context.Context, userID uuid.UUID) (*models.DigestSubscription, error) {
+	var subscription models.DigestSubscription
+
+	if err := dbFromContext(ctx, r.db).Where("user_id = ?", userID).First(&subscription).Error; err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return &subscription, nil
+}
+
+// ListEnabled возвращает включённые подписки пользователей, которые не удалены.
+func (r *digestSubscriptionRepository) ListEnabled(ctx context.Context) ([]*models.DigestSubscription, error) {
+	var subscriptions []*models.DigestSubscription
+
+	err := dbFromContext(ctx, r.db).
+		Where("enabled").
+		Where("user_id IN (?)", dbFromContext(ctx, r.db).Model(&models.User{}).Select("id")).
+		Order("id ASC").
+		Find(&subscriptions).
+		Error
+
+	if err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return subscriptions, nil
+}
+
+func (r *digestSubscriptionRepository) Save(ctx context.Context, subscription *models.DigestSubscription) (*models.DigestSubscription, error) {
+	if err := dbFromContext(ctx, r.db).Save(subscription).Error; err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return subscription, nil
+}
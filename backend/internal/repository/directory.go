@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"table-api/internal/entitys"
+	"table-api/internal/models"
+	"table-api/internal/repository/gormerrors"
+
+	"gorm.io/gorm"
+)
+
+type directoryRepository struct {
+	db *gorm.DB
+}
+
+func NewDirectoryRepository(db *gorm.DB) *directoryRepository {
+	return &directoryRepository{db: db}
+}
+
+func (d *directoryRepository) Create(ctx context.Context, entry *models.DirectoryEntry) (*models.DirectoryEntry, error) {
+	if err := dbFromContext(ctx, d.db).Create(entry).Error; err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return entry, nil
+}
+
+func (d *directoryRepository) GetByID(ctx context.Context, kind string, id int) (*models.DirectoryEntry, error) {
+	var entry models.DirectoryEntry
+
+	if err := dbFromContext(ctx, d.db).Where("kind = ?", kind).First(&entry, id).Error; err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return &entry, nil
+}
+
+func (d *directoryRepository) FindByIDs(ctx context.Context, kind string, ids []int) ([]*models.DirectoryEntry, error) {
+	var entries []*models.DirectoryEntry
+
+	if len(ids) == 0 {
+		return entries, nil
+	}
+
+	if err := dbFromContext(ctx, d.db).Where("kind = ? AND id IN ?", kind, ids).Find(&entries).Error; err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return entries, nil
+}
+
+// FindByKey ищет запись по ключу названия или одного из псевдонимов.
+func (d *directoryRepository) FindByKey(ctx context.Context, kind, key string) (*models.DirectoryEntry, error) {
+	var entry models.DirectoryEntry
+
+	err := dbFromContext(ctx, d.db).
+		Where("kind = ? AND (key = ? OR jsonb_exists(alias_keys, ?))", kind, key, key).
+		Order("id ASC").
+		First(&entry).
+		Error
+
+	if err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return &entry, nil
+}
+
+func (d *directoryRepository) List(
+	ctx context.Context,
+	kind string,
+	page, limit int,
+	search *string,
+) ([]*models.DirectoryEntry, *entitys.Pagination, error) {
+	offset := (page - 1) * limit
+
+	var (
+		entries    []*models.DirectoryEntry
+		totalItems int64
+	)
+
+	query := dbFromContext(ctx, d.db).Model(&models.DirectoryEntry{}).Where("kind = ?", kind)
+
+	if search != nil && *search != "" {
+		pattern := "%" + escapeLike(*search) + "%"
+		query = query.Where("(name ILIKE ? OR aliases::text ILIKE ?)", pattern, pattern)
+	}
+
+	if err := query.Count(&totalItems).Error; err != nil {
+		return nil, nil, gormerrors.Map(err)
+	}
+
+	if err := query.
+		Order("key ASC, id ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&entries).
+		Error; err != nil {
+		return nil, nil, gormerrors.Map(err)
+	}
+
+	pagination := entitys.BuildPagination(page, limit, totalItems)
+	return entries, &pagination, nil
+}
+
+func (d *directoryRepository) Save(ctx context.Context, entry *models.DirectoryEntry) (*models.DirectoryEntry, error) {
+	if err := dbFromContext(ctx, d.db).Save(entry).Error; err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return entry, nil
+}
+
+func (d *directoryRepository) Delete(ctx context.Context, ids ...int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if err := dbFromContext(ctx, d.db).Delete(&models.DirectoryEntry{}, ids).Error; err != nil {
+		return gormerrors.Map(err)
+	}
+
+	return nil
+}
+
+func (d *directoryRepository) CreateMerges(ctx context.Context, merges []*models.DirectoryMerge) error {
+	if len(merges) == 0 {
+		return nil
+	}
+
+	if err := dbFromContext(ctx, d.db).Create(&merges).Error; err != nil {
+		return gormerrors.Map(err)
+	}
+
+	return nil
+}
+
+func (d *directoryRepository) ListMerges(
+	ctx context.Context,
+	kind *string,
+	page, limit int,
+) ([]*models.DirectoryMerge, *entitys.Pagination, error) {
+	offset := (page - 1) * limit
+
+	var (
+		merges     []*models.DirectoryMerge
+		totalItems int64
+	)
+
+	query := dbFromContext(ctx, d.db).Model(&models.DirectoryMerge{})
+
+	if kind != nil {
+		query = query.Where("kind = ?", *kind)
+	}
+
+	if err := query.Count(&totalItems).Error; err != nil {
+		return nil, nil, gormerrors.Map(err)
+	}
+
+	if err := query.
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&merges).
+		Error; err != nil {
+		return nil, nil, gormerrors.Map(err)
+	}
+
+	pagination := entitys.BuildPagination(page, limit, totalItems)
+	return merges, &pagination, nil
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"table-api/internal/models"
+	"table-api/internal/repository/gormerrors"
+
+	"gorm.io/gorm"
+)
+
+type exportTemplateRepository struct {
+	db *gorm.DB
+}
+
+func NewExportTemplateRepository(db *gorm.DB) *exportTemplateRepository {
+	return &exportTemplateRepository{db: db}
+}
+
+func (r *exportTemplateRepository) Create(ctx context.Context, template *models.ExportTemplate) (*models.ExportTemplate, error) {
+	if err := dbFromContext(ctx, r.db).Create(template).Error; err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return template, nil
+}
+
+func (r *exportTemplateRepository) GetByID(ctx context.Context, id int) (*models.ExportTemplate, error) {
+	var template models.ExportTemplate
+
+	if err := dbFromContext(ctx, r.db).First(&template, id).Error; err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return &template, nil
+}
+
+func (r *exportTemplateRepository) GetByName(ctx context.Context, name string) (*models.ExportTemplate, error) {
+	var template models.ExportTemplate
+
+	if err := dbFromContext(ctx, r.db).Where("name = ?", name).First(&template).Error; err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return &template, nil
+}
+
+func (r *exportTemplateRepository) List(ctx context.Context) ([]*models.ExportTemplate, error) {
+	var templates []*models.ExportTemplate
+
+	if err := dbFromContext(ctx, r.db).Order("name ASC").Find(&templates).Error; err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return templates, nil
+}
+
+func (r *exportTemplateRepository) Save(ctx context.Context, template *models.ExportTemplate) (*models.ExportTemplate, error) {
+	if err := dbFromContext(ctx, r.db).Save(template).Error; err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return template, nil
+}
+
+func (r *exportTemplateRepository) Delete(ctx context.Context, id int) (*models.ExportTemplate, error) {
+	template, err := r.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := dbFromContext(ctx, r.db).Delete(&models.ExportTemplate{}, id).Error; err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return template, nil
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"table-api/internal/models"
+	"table-api/internal/repository/gormerrors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type idempotencyKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyKeyRepository(db *gorm.DB) *idempotencyKeyRepository {
+	return &idempotencyKeyRepository{db: db}
+}
+
+// Reserve сохраняет ключ, если у пользователя такого ещё нет. false — ключ уже занят.
+func (r *idempotencyKeyRepository) Reserve(ctx context.Context, record *models.IdempotencyKey) (bool, error) {
+	result := dbFromContext(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(record)
+
+	if result.Error != nil {
+		return false, gormerrors.Map(result.Error)
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (r *idempotencyKeyRepository) Get(ctx context.Context, userID uuid.UUID, key string) (*models.IdempotencyKey, error) {
+	var record models.IdempotencyKey
+
+	err := dbFromContext(ctx, r.db).
+		Where("user_id = ? AND key = ?", userID, key).
+		First(&record).
+		Error
+
+	if err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return &record, nil
+}
+
+// Complete сохраняет ответ на запрос.
+func (r *idempotencyKeyRepository) Complete(ctx context.Context, id int, status int, body []byte) error {
+	err := dbFromContext(ctx, r.db).
+		Model(&models.IdempotencyKey{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"status": status, "body": body}).
+		Error
+
+	return gormerrors.Map(err)
+}
+
+func (r *idempotencyKeyRepository) Delete(ctx context.Context, id int) error {
+	return gormerrors.Map(dbFromContext(ctx, r.db).Delete(&models.IdempotencyKey{}, id).Error)
+}
+
+// DeleteBefore удаляет ключи, созданные раньше before.
+func (r *idempotencyKeyRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := dbFromContext(ctx, r.db).
+		Where("created_at < ?", before).
+		Delete(&models.IdempotencyKey{})
+
+	if result.Error != nil {
+		return 0, gormerrors.Map(result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
@@ -2,10 +2,15 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"strings"
+	"table-api/internal/entitys"
+	"table-api/internal/handler/dto"
 	"table-api/internal/models"
 	"table-api/internal/repository/gormerrors"
 	common "table-api/pkg"
+	"table-api/pkg/timezone"
 	"time"
 
 	"gorm.io/gorm"
@@ -20,7 +25,7 @@ func NewLectureRepository(db *gorm.DB) *lectureRepository {
 }
 
 func (l *lectureRepository) Create(ctx context.Context, lecture *models.Lecture) (*models.Lecture, error) {
-	if err := l.db.WithContext(ctx).Create(lecture).Error; err != nil {
+	if err := dbFromContext(ctx, l.db).Create(lecture).Error; err != nil {
 		return nil, gormerrors.Map(err)
 	}
 
@@ -32,7 +37,7 @@ func (l *lectureRepository) CreateMany(ctx context.Context, lectures []*models.L
 		return nil, nil
 	}
 
-	result := l.db.WithContext(ctx).Create(lectures)
+	result := dbFromContext(ctx, l.db).Create(lectures)
 	if result.Error != nil {
 		return nil, gormerrors.Map(result.Error)
 	}
@@ -43,92 +48,88 @@ func (l *lectureRepository) CreateMany(ctx context.Context, lectures []*models.L
 func (l *lectureRepository) GetByID(ctx context.Context, id int) (*models.Lecture, error) {
 	var lecture models.Lecture
 
-	if err := l.db.WithContext(ctx).First(&lecture, id).Error; err != nil {
+	if err := dbFromContext(ctx, l.db).First(&lecture, id).Error; err != nil {
 		return nil, gormerrors.Map(err)
 	}
 
 	return &lecture, nil
 }
 
-func (l *lectureRepository) Update(ctx context.Context, id int, updates map[string]interface{}) (*models.Lecture, error) {
+// Update применяет изменения к лекции. Если задана version, изменения сохраняются,
+// только пока версия лекции не изменилась; иначе возвращается StaleError с текущей лекцией.
+func (l *lectureRepository) Update(
+	ctx context.Context,
+	id int,
+	version *int,
+	updates map[string]interface{},
+) (*models.Lecture, error) {
 	if len(updates) == 0 {
-		return l.GetByID(ctx, id)
+		return l.checkVersion(ctx, id, version)
 	}
 
-	result := l.db.
-		WithContext(ctx).
+	query := dbFromContext(ctx, l.db).
 		Model(&models.Lecture{}).
-		Where("id = ?", id).
-		Updates(updates)
+		Where("id = ?", id)
+
+	if version != nil {
+		query = query.Where("version = ?", *version)
+	}
+
+	result := query.Updates(versioned(updates))
 
 	if result.Error != nil {
 		return nil, gormerrors.Map(result.Error)
 	}
 
 	if result.RowsAffected == 0 {
-		return nil, common.ErrNotFound
+		return l.checkVersion(ctx, id, version)
 	}
 
 	return l.GetByID(ctx, id)
 }
 
+// checkVersion возвращает лекцию, если её версия совпадает с version или version не задана.
+func (l *lectureRepository) checkVersion(ctx context.Context, id int, version *int) (*models.Lecture, error) {
+	lecture, err := l.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if version != nil && lecture.Version != *version {
+		return nil, &common.StaleError{Current: lecture}
+	}
+
+	return lecture, nil
+}
+
 func (l *lectureRepository) Delete(ctx context.Context, id int) (*models.Lecture, error) {
 	var lecture models.Lecture
 
-	if err := l.db.WithContext(ctx).First(&lecture, id).Error; err != nil {
+	if err := dbFromContext(ctx, l.db).First(&lecture, id).Error; err != nil {
 		return nil, gormerrors.Map(err)
 	}
 
-	if err := l.db.Delete(&lecture).Error; err != nil {
+	if err := dbFromContext(ctx, l.db).Delete(&lecture).Error; err != nil {
 		return nil, gormerrors.Map(err)
 	}
 
 	return &lecture, nil
 }
 
-func (l *lectureRepository) UpdateURLsByGroup(
-	ctx context.Context,
-	groupName string,
-	url string,
-	shortURL string,
-) ([]*models.Lecture, error) {
-	updates := map[string]interface{}{
-		"URL":      url,
-		"ShortURL": shortURL,
-	}
-
-	result := l.db.
-		WithContext(ctx).
-		Model(&models.Lecture{}).
-		Where(`"group" = ?`, groupName).
-		Updates(updates)
-
-	if result.Error != nil {
-		return nil, gormerrors.Map(result.Error)
-	}
-
-	if result.RowsAffected == 0 {
-		return nil, common.ErrNotFound
-	}
-
-	var updatedLectures []*models.Lecture
-	err := l.db.
-		WithContext(ctx).
-		Where(`"group" = ?`, groupName).
-		Find(&updatedLectures).Error
+func (l *lectureRepository) FindByGroup(ctx context.Context, groupID int) ([]*models.Lecture, error) {
+	var lectures []*models.Lecture
 
-	if err != nil {
+	if err := dbFromContext(ctx, l.db).Where("group_id = ?", groupID).Find(&lectures).Error; err != nil {
 		return nil, gormerrors.Map(err)
 	}
 
-	return updatedLectures, nil
+	return lectures, nil
 }
 
 func (l *lectureRepository) FindByDateRange(ctx context.Context, startDate, endDate time.Time) ([]*models.Lecture, error) {
 	var lectures []*models.Lecture
 
-	err := l.db.
-		WithContext(ctx).
+	err := dbFromContext(ctx, l.db).
 		Where("date >= ? AND date <= ?", startDate, endDate).
 		Order("date ASC, start ASC").
 		Find(&lectures).
@@ -142,13 +143,14 @@ func (l *lectureRepository) FindByDateRange(ctx context.Context, startDate, endD
 }
 
 func (l *lectureRepository) FindByExactDate(ctx context.Context, date time.Time) ([]*models.Lecture, error) {
-	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
-	endOfDay := startOfDay.Add(24*time.Hour - time.Second)
+	// Сутки считаются в поясе организации: в дни перехода на летнее время они не 24 часа
+	startOfDay := timezone.Day(date)
+	nextDay := startOfDay.AddDate(0, 0, 1)
 
 	var lectures []*models.Lecture
 
-	err := l.db.WithContext(ctx).
-		Where("date >= ? AND date <= ?", startOfDay, endOfDay).
+	err := dbFromContext(ctx, l.db).
+		Where("date >= ? AND date < ?", startOfDay, nextDay).
 		Order("start ASC, id ASC").
 		Find(&lectures).
 		Error
@@ -165,13 +167,12 @@ func (l *lectureRepository) FindForSchedule(
 	year, month int,
 ) ([]*models.Lecture, error) {
 	fmt.Println("Repo: Month: ", month, " Year: ", year)
-	start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
-	end := start.AddDate(0, 1, 0).Add(-time.Second)
+	start, end := timezone.Month(year, month)
 
 	var lectures []*models.Lecture
 
-	err := l.db.WithContext(ctx).
-		Where("date >= ? AND date <= ?", start, end).
+	err := dbFromContext(ctx, l.db).
+		Where("date >= ? AND date < ?", start, end).
 		Order("date ASC, start ASC").
 		Find(&lectures).
 		Error
@@ -186,7 +187,7 @@ func (l *lectureRepository) FindForSchedule(
 func (l *lectureRepository) FindWithUniqueDates(ctx context.Context) ([]*models.Lecture, error) {
 	var lectures []*models.Lecture
 
-	err := l.db.WithContext(ctx).
+	err := dbFromContext(ctx, l.db).
 		Select("DISTINCT ON (date) *").
 		Order("date ASC, start ASC").
 		Find(&lectures).
@@ -202,27 +203,690 @@ func (l *lectureRepository) FindWithUniqueDates(ctx context.Context) ([]*models.
 func (l *lectureRepository) FindByDatesAndGroup(
 	ctx context.Context,
 	startDate, endDate time.Time,
-	groupName *string,
+	groupID *int,
 ) ([]*models.Lecture, error) {
 
 	var lectures []*models.Lecture
 
-	query := l.db.WithContext(ctx).Model(&models.Lecture{})
+	query := byDatesAndGroup(dbFromContext(ctx, l.db).Model(&models.Lecture{}), startDate, endDate, groupID)
+
+	query = query.Debug() // Для дебага, потом можно убрать
+
+	if err := query.Find(&lectures).Error; err != nil {
+		return nil, err
+	}
+
+	return lectures, nil
+}
 
+// EachByDatesAndGroup проходит лекции периода курсором БД и отдаёт их fn по одной,
+// не загружая выборку в память. Лекции идут по дате и началу, при byGroup — сначала по группе.
+// Ошибка fn или отмена ctx прерывают обход.
+func (l *lectureRepository) EachByDatesAndGroup(
+	ctx context.Context,
+	startDate, endDate time.Time,
+	groupID *int,
+	byGroup bool,
+	fn func(lecture *models.Lecture) error,
+) error {
+	db := dbFromContext(ctx, l.db)
+
+	query := byDatesAndGroup(db.Model(&models.Lecture{}), startDate, endDate, groupID)
+
+	if byGroup {
+		query = query.Order(`"group" ASC NULLS LAST`)
+	}
+
+	rows, err := query.Order("date ASC, start ASC, id ASC").Rows()
+	if err != nil {
+		return gormerrors.Map(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var lecture models.Lecture
+		if err := db.ScanRows(rows, &lecture); err != nil {
+			return err
+		}
+
+		if err := fn(&lecture); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// byDatesAndGroup ограничивает выборку лекций периодом по датам и, если задана, группой.
+func byDatesAndGroup(query *gorm.DB, startDate, endDate time.Time, groupID *int) *gorm.DB {
 	query = query.Where(`"date" BETWEEN ? AND ?`,
 		startDate.Format("2006-01-02"),
 		endDate.Format("2006-01-02"),
 	)
 
-	if groupName != nil && *groupName != "" {
-		query = query.Where(`"group" = ?`, *groupName)
+	if groupID != nil {
+		query = query.Where("group_id = ?", *groupID)
 	}
 
-	query = query.Debug() // Для дебага, потом можно убрать
+	return query
+}
 
-	if err := query.Find(&lectures).Error; err != nil {
+// Выражения разрезов статистики. Пустые значения сводятся к NULL,
+// месяц берётся в поясе организации.
+var lectureStatsColumns = map[dto.StatsDimension]string{
+	dto.StatsByLector:   `NULLIF(TRIM(lector), '')`,
+	dto.StatsByGroup:    `NULLIF(TRIM("group"), '')`,
+	dto.StatsByPlatform: `NULLIF(TRIM(platform), '')`,
+	dto.StatsByUnit:     `NULLIF(TRIM(unit), '')`,
+	dto.StatsByMonth:    `to_char("date" AT TIME ZONE ?, 'YYYY-MM')`,
+}
+
+// StatsByDatesAndGroup считает в БД число лекций и их длительность в минутах
+// по сочетаниям разрезов. Лекции без времени начала и конца считаются без минут.
+func (l *lectureRepository) StatsByDatesAndGroup(
+	ctx context.Context,
+	startDate, endDate time.Time,
+	groupID *int,
+	dimensions []dto.StatsDimension,
+) ([]entitys.LectureStat, error) {
+	selects := make([]string, 0, len(dimensions)+2)
+	positions := make([]string, 0, len(dimensions))
+
+	var args []any
+
+	for i, dimension := range dimensions {
+		column, ok := lectureStatsColumns[dimension]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown stats dimension %q", common.ErrInvalidInput, dimension)
+		}
+
+		if dimension == dto.StatsByMonth {
+			args = append(args, timezone.Org().String())
+		}
+
+		selects = append(selects, column)
+		positions = append(positions, fmt.Sprint(i+1))
+	}
+
+	selects = append(selects,
+		"COUNT(*)",
+		`COALESCE(SUM(CASE WHEN "end" > start THEN EXTRACT(EPOCH FROM ("end" - start)) / 60 ELSE 0 END), 0)::bigint`,
+	)
+
+	query := byDatesAndGroup(dbFromContext(ctx, l.db).Model(&models.Lecture{}), startDate, endDate, groupID).
+		Select(strings.Join(selects, ", "), args...)
+
+	if len(positions) > 0 {
+		order := strings.Join(positions, ", ")
+		query = query.Group(order).Order(order)
+	}
+
+	rows, err := query.Rows()
+	if err != nil {
+		return nil, gormerrors.Map(err)
+	}
+	defer rows.Close()
+
+	var stats []entitys.LectureStat
+
+	for rows.Next() {
+		keys := make([]sql.NullString, len(dimensions))
+		dest := make([]any, 0, len(dimensions)+2)
+		for i := range keys {
+			dest = append(dest, &keys[i])
+		}
+
+		var stat entitys.LectureStat
+		dest = append(dest, &stat.Lectures, &stat.Minutes)
+
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+
+		stat.Keys = make([]*string, len(keys))
+		for i, key := range keys {
+			if key.Valid {
+				stat.Keys[i] = &key.String
+			}
+		}
+
+		stats = append(stats, stat)
+	}
+
+	return stats, rows.Err()
+}
+
+func (l *lectureRepository) FindByDatesAndLector(
+	ctx context.Context,
+	startDate, endDate time.Time,
+	lectorID int,
+) ([]*models.Lecture, error) {
+	var lectures []*models.Lecture
+
+	err := dbFromContext(ctx, l.db).
+		Where(`"date" BETWEEN ? AND ?`,
+			startDate.Format("2006-01-02"),
+			endDate.Format("2006-01-02"),
+		).
+		Where("lector_id = ?", lectorID).
+		Order("date ASC, start ASC").
+		Find(&lectures).
+		Error
+
+	if err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return lectures, nil
+}
+
+func (l *lectureRepository) FindByDatesAndLocation(
+	ctx context.Context,
+	startDate, endDate time.Time,
+	locationID int,
+) ([]*models.Lecture, error) {
+	var lectures []*models.Lecture
+
+	err := dbFromContext(ctx, l.db).
+		Where(`"date" BETWEEN ? AND ?`,
+			startDate.Format("2006-01-02"),
+			endDate.Format("2006-01-02"),
+		).
+		Where("location_id = ?", locationID).
+		Order("date ASC, start ASC").
+		Find(&lectures).
+		Error
+
+	if err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return lectures, nil
+}
+
+func (l *lectureRepository) FindBySeries(
+	ctx context.Context,
+	seriesID int,
+	from *time.Time,
+) ([]*models.Lecture, error) {
+	var lectures []*models.Lecture
+
+	query := dbFromContext(ctx, l.db).Where("series_id = ?", seriesID)
+
+	if from != nil {
+		query = query.Where("date >= ?", *from)
+	}
+
+	if err := query.Order("date ASC, start ASC").Find(&lectures).Error; err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return lectures, nil
+}
+
+func (l *lectureRepository) UpdateBySeries(
+	ctx context.Context,
+	seriesID int,
+	from *time.Time,
+	updates map[string]interface{},
+) (int64, error) {
+	query := dbFromContext(ctx, l.db).
+		Model(&models.Lecture{}).
+		Where("series_id = ?", seriesID)
+
+	if from != nil {
+		query = query.Where("date >= ?", *from)
+	}
+
+	result := query.Updates(versioned(updates))
+	if result.Error != nil {
+		return 0, gormerrors.Map(result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (l *lectureRepository) DeleteBySeries(
+	ctx context.Context,
+	seriesID int,
+	from *time.Time,
+) ([]*models.Lecture, error) {
+	lectures, err := l.FindBySeries(ctx, seriesID, from)
+	if err != nil {
 		return nil, err
 	}
 
+	if len(lectures) == 0 {
+		return lectures, nil
+	}
+
+	ids := make([]int, 0, len(lectures))
+	for _, lecture := range lectures {
+		ids = append(ids, lecture.ID)
+	}
+
+	if err := dbFromContext(ctx, l.db).Delete(&models.Lecture{}, ids).Error; err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return lectures, nil
+}
+
+// FindOverlapCandidates возвращает лекции за период, у которых совпадает
+// группа, преподаватель или аудитория (без учёта регистра и пробелов по краям).
+func (l *lectureRepository) FindOverlapCandidates(
+	ctx context.Context,
+	startDate, endDate time.Time,
+	groups, lectors, locations []string,
+) ([]*models.Lecture, error) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+
+	if len(groups) > 0 {
+		conditions = append(conditions, `LOWER(TRIM("group")) IN ?`)
+		args = append(args, groups)
+	}
+	if len(lectors) > 0 {
+		conditions = append(conditions, `LOWER(TRIM(lector)) IN ?`)
+		args = append(args, lectors)
+	}
+	if len(locations) > 0 {
+		conditions = append(conditions, `LOWER(TRIM(location)) IN ?`)
+		args = append(args, locations)
+	}
+
+	if len(conditions) == 0 {
+		return nil, nil
+	}
+
+	var lectures []*models.Lecture
+
+	err := dbFromContext(ctx, l.db).
+		Where("date >= ? AND date <= ?", startDate, endDate).
+		Where("("+strings.Join(conditions, " OR ")+")", args...).
+		Order("date ASC, start ASC").
+		Find(&lectures).
+		Error
+
+	if err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
 	return lectures, nil
 }
+
+func (l *lectureRepository) FindByIDs(ctx context.Context, ids []int) ([]*models.Lecture, error) {
+	var lectures []*models.Lecture
+
+	if len(ids) == 0 {
+		return lectures, nil
+	}
+
+	err := dbFromContext(ctx, l.db).
+		Where("id IN ?", ids).
+		Order("date ASC, start ASC, id ASC").
+		Find(&lectures).
+		Error
+
+	if err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return lectures, nil
+}
+
+func (l *lectureRepository) FindByBulkFilter(ctx context.Context, filter dto.BulkLectureFilter) ([]*models.Lecture, error) {
+	var lectures []*models.Lecture
+
+	query := byBulkFilter(dbFromContext(ctx, l.db), filter)
+
+	if err := query.Order("date ASC, start ASC, id ASC").Find(&lectures).Error; err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return lectures, nil
+}
+
+// FindByGroupAndFilter выбирает лекции группы, подходящие под фильтр. Поле Group фильтра не учитывается.
+func (l *lectureRepository) FindByGroupAndFilter(
+	ctx context.Context,
+	groupID int,
+	filter dto.BulkLectureFilter,
+) ([]*models.Lecture, error) {
+	var lectures []*models.Lecture
+
+	filter.Group = nil
+	query := byBulkFilter(dbFromContext(ctx, l.db).Where("group_id = ?", groupID), filter)
+
+	if err := query.Order("date ASC, start ASC, id ASC").Find(&lectures).Error; err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return lectures, nil
+}
+
+func byBulkFilter(query *gorm.DB, filter dto.BulkLectureFilter) *gorm.DB {
+	if filter.Group != nil {
+		query = query.Where(`LOWER(TRIM("group")) = LOWER(TRIM(?))`, *filter.Group)
+	}
+
+	if filter.Lector != nil {
+		query = query.Where("LOWER(TRIM(lector)) = LOWER(TRIM(?))", *filter.Lector)
+	}
+
+	if filter.Location != nil {
+		query = query.Where("LOWER(TRIM(location)) = LOWER(TRIM(?))", *filter.Location)
+	}
+
+	if filter.Platform != nil {
+		query = query.Where("LOWER(TRIM(platform)) = LOWER(TRIM(?))", *filter.Platform)
+	}
+
+	if filter.DateFrom != nil {
+		query = query.Where("date >= ?", *filter.DateFrom)
+	}
+
+	if filter.DateTo != nil {
+		query = query.Where("date <= ?", *filter.DateTo)
+	}
+
+	return query
+}
+
+func (l *lectureRepository) UpdateByIDs(ctx context.Context, ids []int, updates map[string]interface{}) (int64, error) {
+	if len(ids) == 0 || len(updates) == 0 {
+		return 0, nil
+	}
+
+	result := dbFromContext(ctx, l.db).
+		Model(&models.Lecture{}).
+		Where("id IN ?", ids).
+		Updates(versioned(updates))
+
+	if result.Error != nil {
+		return 0, gormerrors.Map(result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
+
+// ShiftByIDs сдвигает даты лекций на days дней.
+func (l *lectureRepository) ShiftByIDs(ctx context.Context, ids []int, days int) (int64, error) {
+	if len(ids) == 0 || days == 0 {
+		return 0, nil
+	}
+
+	result := dbFromContext(ctx, l.db).
+		Model(&models.Lecture{}).
+		Where("id IN ?", ids).
+		Updates(versioned(map[string]interface{}{
+			"date": gorm.Expr("date + make_interval(days => ?)", days),
+		}))
+
+	if result.Error != nil {
+		return 0, gormerrors.Map(result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (l *lectureRepository) DeleteByIDs(ctx context.Context, ids []int) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	result := dbFromContext(ctx, l.db).Delete(&models.Lecture{}, ids)
+	if result.Error != nil {
+		return 0, gormerrors.Map(result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
+
+// Колонки названия и ссылки лекции для каждого вида справочника
+var directoryColumns = map[string][2]string{
+	models.DirectoryGroup:    {"group", "group_id"},
+	models.DirectoryLector:   {"lector", "lector_id"},
+	models.DirectoryUnit:     {"unit", "unit_id"},
+	models.DirectoryLocation: {"location", "location_id"},
+}
+
+// CountByDirectory считает лекции, включая удалённые, которые ссылаются на запись справочника.
+func (l *lectureRepository) CountByDirectory(ctx context.Context, kind string, id int) (int64, error) {
+	var count int64
+
+	err := dbFromContext(ctx, l.db).
+		Unscoped().
+		Model(&models.Lecture{}).
+		Where(directoryColumns[kind][1]+" = ?", id).
+		Count(&count).
+		Error
+
+	if err != nil {
+		return 0, gormerrors.Map(err)
+	}
+
+	return count, nil
+}
+
+// RelinkDirectory переводит лекции, включая удалённые, со ссылок from на запись to
+// и проставляет её название.
+func (l *lectureRepository) RelinkDirectory(ctx context.Context, kind string, from []int, to int, name string) (int64, error) {
+	columns := directoryColumns[kind]
+
+	result := dbFromContext(ctx, l.db).
+		Unscoped().
+		Model(&models.Lecture{}).
+		Where(columns[1]+" IN ?", from).
+		UpdateColumns(versioned(map[string]interface{}{
+			columns[0]: name,
+			columns[1]: to,
+		}))
+
+	if result.Error != nil {
+		return 0, gormerrors.Map(result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
+
+// Колонки, по которым разрешена сортировка списка лекций
+var lectureSortColumns = map[string]string{
+	"date":      "date",
+	"start":     "start",
+	"end":       `"end"`,
+	"group":     `"group"`,
+	"lector":    "lector",
+	"platform":  "platform",
+	"unit":      "unit",
+	"location":  "location",
+	"admin":     "admin",
+	"createdAt": "created_at",
+	"updatedAt": "updated_at",
+}
+
+func (l *lectureRepository) List(
+	ctx context.Context,
+	page int,
+	limit int,
+	filter dto.GetQueryLectureDto,
+) ([]*models.Lecture, *entitys.Pagination, error) {
+	offset := (page - 1) * limit
+
+	var (
+		lectures   []*models.Lecture
+		totalItems int64
+	)
+
+	query := dbFromContext(ctx, l.db).Model(&models.Lecture{})
+
+	exact := map[string]*string{
+		`"group"`:  filter.Group,
+		"lector":   filter.Lector,
+		"platform": filter.Platform,
+		"unit":     filter.Unit,
+		"location": filter.Location,
+		"admin":    filter.Admin,
+	}
+
+	for column, value := range exact {
+		if value != nil {
+			query = query.Where("LOWER(TRIM("+column+")) = LOWER(TRIM(?))", *value)
+		}
+	}
+
+	if filter.DateFrom != nil {
+		query = query.Where("date >= ?", *filter.DateFrom)
+	}
+
+	if filter.DateTo != nil {
+		query = query.Where("date <= ?", *filter.DateTo)
+	}
+
+	if filter.NoURL {
+		query = query.Where("(url IS NULL OR url = '')")
+	}
+
+	if filter.NoStreamKey {
+		query = query.Where("(stream_key IS NULL OR stream_key = '')")
+	}
+
+	if filter.Search != nil && strings.TrimSpace(*filter.Search) != "" {
+		pattern := "%" + escapeLike(strings.TrimSpace(*filter.Search)) + "%"
+
+		query = query.Where(
+			`("group" ILIKE @p OR lector ILIKE @p OR platform ILIKE @p OR unit ILIKE @p OR
+			location ILIKE @p OR description ILIKE @p OR admin ILIKE @p)`,
+			map[string]interface{}{"p": pattern},
+		)
+	}
+
+	if err := query.Count(&totalItems).Error; err != nil {
+		return nil, nil, gormerrors.Map(err)
+	}
+
+	if column, ok := lectureSortColumns[stringValue(filter.SortBy)]; ok {
+		orderDir := "ASC"
+		if filter.Order != nil && *filter.Order == dto.SortDesc {
+			orderDir = "DESC"
+		}
+
+		query = query.Order(column + " " + orderDir + " NULLS LAST")
+	}
+
+	if err := query.
+		Order("date ASC, start ASC, id ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&lectures).
+		Error; err != nil {
+		return nil, nil, gormerrors.Map(err)
+	}
+
+	pagination := entitys.BuildPagination(page, limit, totalItems)
+	return lectures, &pagination, nil
+}
+
+// escapeLike экранирует спецсимволы шаблона LIKE.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
+
+func (l *lectureRepository) ListDeleted(ctx context.Context, page, limit int) ([]*models.Lecture, *entitys.Pagination, error) {
+	offset := (page - 1) * limit
+
+	var (
+		lectures   []*models.Lecture
+		totalItems int64
+	)
+
+	query := dbFromContext(ctx, l.db).Unscoped().Model(&models.Lecture{}).Where("deleted_at IS NOT NULL")
+
+	if err := query.Count(&totalItems).Error; err != nil {
+		return nil, nil, gormerrors.Map(err)
+	}
+
+	if err := query.Order("deleted_at DESC").Limit(limit).Offset(offset).Find(&lectures).Error; err != nil {
+		return nil, nil, gormerrors.Map(err)
+	}
+
+	pagination := entitys.BuildPagination(page, limit, totalItems)
+	return lectures, &pagination, nil
+}
+
+func (l *lectureRepository) GetDeletedByID(ctx context.Context, id int) (*models.Lecture, error) {
+	var lecture models.Lecture
+
+	err := dbFromContext(ctx, l.db).
+		Unscoped().
+		Where("deleted_at IS NOT NULL").
+		First(&lecture, id).
+		Error
+	if err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return &lecture, nil
+}
+
+// Restore возвращает лекцию из корзины, применяя updates (например, отвязку от удалённой серии).
+func (l *lectureRepository) Restore(ctx context.Context, id int, updates map[string]interface{}) (*models.Lecture, error) {
+	values := versioned(updates)
+	values["deleted_at"] = nil
+
+	result := dbFromContext(ctx, l.db).
+		Unscoped().
+		Model(&models.Lecture{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Updates(values)
+
+	if result.Error != nil {
+		return nil, gormerrors.Map(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, common.ErrNotFound
+	}
+
+	return l.GetByID(ctx, id)
+}
+
+func (l *lectureRepository) Purge(ctx context.Context, id int) (*models.Lecture, error) {
+	lecture, err := l.GetDeletedByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := dbFromContext(ctx, l.db).Unscoped().Delete(lecture).Error; err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return lecture, nil
+}
+
+func (l *lectureRepository) PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := dbFromContext(ctx, l.db).
+		Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Delete(&models.Lecture{})
+
+	if result.Error != nil {
+		return 0, gormerrors.Map(result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
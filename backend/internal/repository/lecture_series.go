@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"table-api/internal/models"
+	"table-api/internal/repository/gormerrors"
+
+	"gorm.io/gorm"
+)
+
+type lectureSeriesRepository struct {
+	db *gorm.DB
+}
+
+func NewLectureSeriesRepository(db *gorm.DB) *lectureSeriesRepository {
+	return &lectureSeriesRepository{db: db}
+}
+
+func (l *lectureSeriesRepository) Create(ctx context.Context, series *models.LectureSeries) (*models.LectureSeries, error) {
+	if err := dbFromContext(ctx, l.db).Create(series).Error; err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return series, nil
+}
+
+func (l *lectureSeriesRepository) GetByID(ctx context.Context, id int) (*models.LectureSeries, error) {
+	var series models.LectureSeries
+
+	if err := dbFromContext(ctx, l.db).First(&series, id).Error; err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return &series, nil
+}
+
+func (l *lectureSeriesRepository) Save(ctx context.Context, series *models.LectureSeries) (*models.LectureSeries, error) {
+	if err := dbFromContext(ctx, l.db).Save(series).Error; err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return series, nil
+}
+
+func (l *lectureSeriesRepository) Delete(ctx context.Context, id int) (*models.LectureSeries, error) {
+	var series models.LectureSeries
+
+	if err := dbFromContext(ctx, l.db).First(&series, id).Error; err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	if err := dbFromContext(ctx, l.db).Delete(&series).Error; err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return &series, nil
+}
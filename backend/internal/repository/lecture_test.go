@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"table-api/pkg/timezone"
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// dryRunDB возвращает подключение без БД и функцию, отдающую
+// параметры последнего построенного запроса.
+func dryRunDB(t *testing.T) (*gorm.DB, func() []any) {
+	t.Helper()
+
+	db, err := gorm.Open(
+		postgres.New(postgres.Config{DSN: "host=localhost"}),
+		&gorm.Config{DryRun: true, DisableAutomaticPing: true},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var vars []any
+	err = db.Callback().Query().After("gorm:query").Register("test:vars", func(tx *gorm.DB) {
+		vars = tx.Statement.Vars
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return db, func() []any { return vars }
+}
+
+func useZone(t *testing.T, name string) {
+	t.Helper()
+
+	if err := timezone.Configure(name); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { timezone.Configure("UTC") })
+}
+
+func boundsOf(t *testing.T, vars []any) (string, string) {
+	t.Helper()
+
+	if len(vars) < 2 {
+		t.Fatalf("query vars = %v, want start and end", vars)
+	}
+
+	start, ok1 := vars[0].(time.Time)
+	end, ok2 := vars[1].(time.Time)
+	if !ok1 || !ok2 {
+		t.Fatalf("query vars = %v, want times", vars)
+	}
+
+	return start.Format(time.RFC3339), end.Format(time.RFC3339)
+}
+
+func TestFindByExactDateBounds(t *testing.T) {
+	tests := []struct {
+		name      string
+		zone      string
+		date      string
+		wantStart string
+		wantEnd   string
+	}{
+		{
+			name:      "moscow",
+			zone:      "Europe/Moscow",
+			date:      "2025-03-30T00:00:00Z",
+			wantStart: "2025-03-30T00:00:00+03:00",
+			wantEnd:   "2025-03-31T00:00:00+03:00",
+		},
+		{
+			name:      "berlin spring forward",
+			zone:      "Europe/Berlin",
+			date:      "2025-03-30T00:00:00Z",
+			wantStart: "2025-03-30T00:00:00+01:00",
+			wantEnd:   "2025-03-31T00:00:00+02:00",
+		},
+		{
+			name:      "berlin fall back",
+			zone:      "Europe/Berlin",
+			date:      "2025-10-26T00:00:00+03:00",
+			wantStart: "2025-10-26T00:00:00+02:00",
+			wantEnd:   "2025-10-27T00:00:00+01:00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useZone(t, tt.zone)
+			db, vars := dryRunDB(t)
+
+			date, err := time.Parse(time.RFC3339, tt.date)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := NewLectureRepository(db).FindByExactDate(context.Background(), date); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			start, end := boundsOf(t, vars())
+			if start != tt.wantStart || end != tt.wantEnd {
+				t.Fatalf("bounds = [%s, %s), want [%s, %s)", start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestFindForScheduleBounds(t *testing.T) {
+	tests := []struct {
+		name      string
+		zone      string
+		year      int
+		month     int
+		wantStart string
+		wantEnd   string
+	}{
+		{
+			name:      "moscow march",
+			zone:      "Europe/Moscow",
+			year:      2025,
+			month:     3,
+			wantStart: "2025-03-01T00:00:00+03:00",
+			wantEnd:   "2025-04-01T00:00:00+03:00",
+		},
+		{
+			name:      "berlin march",
+			zone:      "Europe/Berlin",
+			year:      2025,
+			month:     3,
+			wantStart: "2025-03-01T00:00:00+01:00",
+			wantEnd:   "2025-04-01T00:00:00+02:00",
+		},
+		{
+			name:      "berlin october",
+			zone:      "Europe/Berlin",
+			year:      2025,
+			month:     10,
+			wantStart: "2025-10-01T00:00:00+02:00",
+			wantEnd:   "2025-11-01T00:00:00+01:00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useZone(t, tt.zone)
+			db, vars := dryRunDB(t)
+
+			if _, err := NewLectureRepository(db).FindForSchedule(context.Background(), tt.year, tt.month); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			start, end := boundsOf(t, vars())
+			if start != tt.wantStart || end != tt.wantEnd {
+				t.Fatalf("bounds = [%s, %s), want [%s, %s)", start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
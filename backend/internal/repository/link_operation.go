@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"table-api/internal/models"
+	"table-api/internal/repository/gormerrors"
+	common "table-api/pkg"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type linkOperationRepository struct {
+	db *gorm.DB
+}
+
+func NewLinkOperationRepository(db *gorm.DB) *linkOperationRepository {
+	return &linkOperationRepository{db: db}
+}
+
+func (r *linkOperationRepository) Create(ctx context.Context, operation *models.LinkOperation) (*models.LinkOperation, error) {
+	if err := dbFromContext(ctx, r.db).Create(operation).Error; err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return operation, nil
+}
+
+func (r *linkOperationRepository) GetByID(ctx context.Context, id int) (*models.LinkOperation, error) {
+	var operation models.LinkOperation
+
+	err := dbFromContext(ctx, r.db).
+		First(&operation, id).
+		Error
+
+	if err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return &operation, nil
+}
+
+// MarkUndone отмечает операцию отменённой. Уже отменённая операция даёт ErrNotFound.
+func (r *linkOperationRepository) MarkUndone(ctx context.Context, id int, at time.Time) error {
+	result := dbFromContext(ctx, r.db).
+		Model(&models.LinkOperation{}).
+		Where("id = ? AND undone_at IS NULL", id).
+		Update("undone_at", at)
+
+	if result.Error != nil {
+		return gormerrors.Map(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return common.ErrNotFound
+	}
+
+	return nil
+}
@@ -21,38 +21,63 @@ func NewMeetRepository(db *gorm.DB) *meetRepository {
 }
 
 func (m *meetRepository) Create(ctx context.Context, meet *models.Meet) (*models.Meet, error) {
-	if err := m.db.WithContext(ctx).Create(meet).Error; err != nil {
+	if err := dbFromContext(ctx, m.db).Create(meet).Error; err != nil {
 		return nil, gormerrors.Map(err)
 	}
 
 	return meet, nil
 }
 
-func (m *meetRepository) Update(ctx context.Context, id int, updates map[string]interface{}) (*models.Meet, error) {
+// Update применяет изменения к мероприятию. Если задана version, изменения сохраняются,
+// только пока версия мероприятия не изменилась; иначе возвращается StaleError с текущим мероприятием.
+func (m *meetRepository) Update(
+	ctx context.Context,
+	id int,
+	version *int,
+	updates map[string]interface{},
+) (*models.Meet, error) {
 	if len(updates) == 0 {
-		return m.GetByID(ctx, id)
+		return m.checkVersion(ctx, id, version)
 	}
 
-	result := m.db.
-		WithContext(ctx).
+	query := dbFromContext(ctx, m.db).
 		Model(&models.Meet{}).
-		Where("id = ?", id).
-		Updates(updates)
+		Where("id = ?", id)
+
+	if version != nil {
+		query = query.Where("version = ?", *version)
+	}
+
+	result := query.Updates(versioned(updates))
 
 	if result.Error != nil {
 		return nil, gormerrors.Map(result.Error)
 	}
 	if result.RowsAffected == 0 {
-		return nil, common.ErrNotFound
+		return m.checkVersion(ctx, id, version)
 	}
 
 	return m.GetByID(ctx, id)
 }
 
+// checkVersion возвращает мероприятие, если его версия совпадает с version или version не задана.
+func (m *meetRepository) checkVersion(ctx context.Context, id int, version *int) (*models.Meet, error) {
+	meet, err := m.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if version != nil && meet.Version != *version {
+		return nil, &common.StaleError{Current: meet}
+	}
+
+	return meet, nil
+}
+
 func (m *meetRepository) GetByID(ctx context.Context, id int) (*models.Meet, error) {
 	var meet models.Meet
 
-	if err := m.db.WithContext(ctx).First(&meet, id).Error; err != nil {
+	if err := dbFromContext(ctx, m.db).First(&meet, id).Error; err != nil {
 		return nil, gormerrors.Map(err)
 	}
 
@@ -72,7 +97,7 @@ func (m *meetRepository) List(
 		totalItems int64
 	)
 
-	query := m.db.WithContext(ctx).Model(&models.Meet{})
+	query := dbFromContext(ctx, m.db).Model(&models.Meet{})
 
 	if filter.Status != nil {
 		query = query.Where("status = ?", *filter.Status)
@@ -126,6 +151,23 @@ func (m *meetRepository) List(
 	return meets, &pagination, nil
 }
 
+// FindOverlapping возвращает мероприятия, пересекающиеся с интервалом [start, end).
+func (m *meetRepository) FindOverlapping(ctx context.Context, start, end time.Time) ([]*models.Meet, error) {
+	var meets []*models.Meet
+
+	err := dbFromContext(ctx, m.db).
+		Where(`start < ? AND "end" > ?`, end, start).
+		Where("status <> ?", "canceled").
+		Find(&meets).
+		Error
+
+	if err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return meets, nil
+}
+
 func (m *meetRepository) MarkCompletedIfEnded() error {
 	now := time.Now()
 
@@ -136,6 +178,91 @@ func (m *meetRepository) MarkCompletedIfEnded() error {
 			"approved",
 			now,
 		).
-		Update("status", "completed").
+		Updates(versioned(map[string]interface{}{"status": "completed"})).
 		Error
 }
+
+func (m *meetRepository) Delete(ctx context.Context, id int) (*models.Meet, error) {
+	var meet models.Meet
+
+	if err := dbFromContext(ctx, m.db).First(&meet, id).Error; err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	if err := dbFromContext(ctx, m.db).Delete(&meet).Error; err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return &meet, nil
+}
+
+func (m *meetRepository) ListDeleted(ctx context.Context, page, limit int) ([]*models.Meet, *entitys.Pagination, error) {
+	offset := (page - 1) * limit
+
+	var (
+		meets      []*models.Meet
+		totalItems int64
+	)
+
+	query := dbFromContext(ctx, m.db).Unscoped().Model(&models.Meet{}).Where("deleted_at IS NOT NULL")
+
+	if err := query.Count(&totalItems).Error; err != nil {
+		return nil, nil, gormerrors.Map(err)
+	}
+
+	if err := query.Order("deleted_at DESC").Limit(limit).Offset(offset).Find(&meets).Error; err != nil {
+		return nil, nil, gormerrors.Map(err)
+	}
+
+	pagination := entitys.BuildPagination(page, limit, totalItems)
+	return meets, &pagination, nil
+}
+
+func (m *meetRepository) Restore(ctx context.Context, id int) (*models.Meet, error) {
+	result := dbFromContext(ctx, m.db).
+		Unscoped().
+		Model(&models.Meet{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Updates(versioned(map[string]interface{}{"deleted_at": nil}))
+
+	if result.Error != nil {
+		return nil, gormerrors.Map(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, common.ErrNotFound
+	}
+
+	return m.GetByID(ctx, id)
+}
+
+func (m *meetRepository) Purge(ctx context.Context, id int) (*models.Meet, error) {
+	var meet models.Meet
+
+	err := dbFromContext(ctx, m.db).
+		Unscoped().
+		Where("deleted_at IS NOT NULL").
+		First(&meet, id).
+		Error
+	if err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	if err := dbFromContext(ctx, m.db).Unscoped().Delete(&meet).Error; err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return &meet, nil
+}
+
+func (m *meetRepository) PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := dbFromContext(ctx, m.db).
+		Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Delete(&models.Meet{})
+
+	if result.Error != nil {
+		return 0, gormerrors.Map(result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
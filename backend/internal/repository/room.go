@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"table-api/internal/models"
+	"table-api/internal/repository/gormerrors"
+
+	"gorm.io/gorm"
+)
+
+type roomRepository struct {
+	db *gorm.DB
+}
+
+func NewRoomRepository(db *gorm.DB) *roomRepository {
+	return &roomRepository{db: db}
+}
+
+func (r *roomRepository) Create(ctx context.Context, room *models.Room) (*models.Room, error) {
+	if err := dbFromContext(ctx, r.db).Omit("Location").Create(room).Error; err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return r.GetByID(ctx, room.ID)
+}
+
+func (r *roomRepository) GetByID(ctx context.Context, id int) (*models.Room, error) {
+	var room models.Room
+
+	if err := dbFromContext(ctx, r.db).Preload("Location").First(&room, id).Error; err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return &room, nil
+}
+
+func (r *roomRepository) GetByLocationID(ctx context.Context, locationID int) (*models.Room, error) {
+	var room models.Room
+
+	err := dbFromContext(ctx, r.db).
+		Preload("Location").
+		Where("location_id = ?", locationID).
+		First(&room).
+		Error
+
+	if err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return &room, nil
+}
+
+func (r *roomRepository) List(ctx context.Context) ([]*models.Room, error) {
+	var rooms []*models.Room
+
+	err := dbFromContext(ctx, r.db).
+		Preload("Location").
+		Order("building ASC NULLS LAST, id ASC").
+		Find(&rooms).
+		Error
+
+	if err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return rooms, nil
+}
+
+func (r *roomRepository) Save(ctx context.Context, room *models.Room) (*models.Room, error) {
+	if err := dbFromContext(ctx, r.db).Omit("Location").Save(room).Error; err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return r.GetByID(ctx, room.ID)
+}
+
+func (r *roomRepository) Delete(ctx context.Context, id int) (*models.Room, error) {
+	room, err := r.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := dbFromContext(ctx, r.db).Delete(&models.Room{}, id).Error; err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return room, nil
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+type transactor struct {
+	db *gorm.DB
+}
+
+func NewTransactor(db *gorm.DB) *transactor {
+	return &transactor{db: db}
+}
+
+// WithinTransaction выполняет fn в одной транзакции.
+// Репозитории, получившие ctx из fn, работают через эту транзакцию.
+func (t *transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+
+	return t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// dbFromContext возвращает транзакцию из контекста, если она открыта,
+// иначе обычное подключение.
+func dbFromContext(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx
+	}
+
+	return db.WithContext(ctx)
+}
@@ -6,6 +6,8 @@ import (
 	"table-api/internal/models"
 	"table-api/internal/repository/gormerrors"
 	common "table-api/pkg"
+	"table-api/pkg/utils"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -46,6 +48,35 @@ func (u *userRepository) GetByLogin(ctx context.Context, login string) (*models.
 	return &user, nil
 }
 
+// FindByLoginOrName находит пользователя по логину или имени без учёта регистра и лишних пробелов.
+func (u *userRepository) FindByLoginOrName(ctx context.Context, name string) (*models.User, error) {
+	var user models.User
+
+	key := utils.NameKey(name)
+
+	err := u.db.
+		WithContext(ctx).
+		Where(`LOWER(login) = ? OR LOWER(REGEXP_REPLACE(TRIM(name), '\s+', ' ', 'g')) = ?`, key, key).
+		Order("login ASC").
+		First(&user).
+		Error
+	if err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return &user, nil
+}
+
+func (u *userRepository) FindByRole(ctx context.Context, role string) ([]*models.User, error) {
+	var users []*models.User
+
+	if err := u.db.WithContext(ctx).Where("role = ?", role).Order("login ASC").Find(&users).Error; err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return users, nil
+}
+
 func (u *userRepository) List(
 	ctx context.Context,
 	page int,
@@ -126,3 +157,133 @@ func (u *userRepository) Delete(ctx context.Context, id uuid.UUID) (*models.User
 
 	return &user, nil
 }
+
+func (u *userRepository) ListDeleted(ctx context.Context, page, limit int) ([]*models.User, *entitys.Pagination, error) {
+	offset := (page - 1) * limit
+
+	var (
+		users      []*models.User
+		totalItems int64
+	)
+
+	query := u.db.WithContext(ctx).Unscoped().Model(&models.User{}).Where("deleted_at IS NOT NULL")
+
+	if err := query.Count(&totalItems).Error; err != nil {
+		return nil, nil, gormerrors.Map(err)
+	}
+
+	if err := query.Order("deleted_at DESC").Limit(limit).Offset(offset).Find(&users).Error; err != nil {
+		return nil, nil, gormerrors.Map(err)
+	}
+
+	pagination := entitys.BuildPagination(page, limit, totalItems)
+	return users, &pagination, nil
+}
+
+func (u *userRepository) Restore(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	result := u.db.
+		WithContext(ctx).
+		Unscoped().
+		Model(&models.User{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+
+	if result.Error != nil {
+		return nil, gormerrors.Map(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, common.ErrNotFound
+	}
+
+	return u.GetByID(ctx, id)
+}
+
+// Purge окончательно удаляет пользователя из корзины вместе с его refresh-токенами.
+// Лекции и мероприятия сохраняют имя админа, но теряют ссылку на пользователя.
+func (u *userRepository) Purge(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	var user models.User
+
+	err := u.db.
+		WithContext(ctx).
+		Unscoped().
+		Where("deleted_at IS NOT NULL").
+		First(&user, "id = ?", id).
+		Error
+	if err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	err = u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", id).Delete(&models.RefreshToken{}).Error; err != nil {
+			return err
+		}
+
+		if err := unlinkAdmins(tx, []uuid.UUID{id}); err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Delete(&user).Error
+	})
+	if err != nil {
+		return nil, gormerrors.Map(err)
+	}
+
+	return &user, nil
+}
+
+func (u *userRepository) PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error) {
+	var ids []uuid.UUID
+
+	err := u.db.
+		WithContext(ctx).
+		Unscoped().
+		Model(&models.User{}).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Pluck("id", &ids).
+		Error
+	if err != nil {
+		return 0, gormerrors.Map(err)
+	}
+
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	var purged int64
+
+	err = u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id IN ?", ids).Delete(&models.RefreshToken{}).Error; err != nil {
+			return err
+		}
+
+		if err := unlinkAdmins(tx, ids); err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Where("id IN ?", ids).Delete(&models.User{})
+		purged = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return 0, gormerrors.Map(err)
+	}
+
+	return purged, nil
+}
+
+// unlinkAdmins снимает ссылки на удаляемых пользователей с лекций и мероприятий,
+// включая записи в корзине. Версия растёт, чтобы старые ETag получили 412.
+func unlinkAdmins(tx *gorm.DB, ids []uuid.UUID) error {
+	for _, model := range []interface{}{&models.Lecture{}, &models.Meet{}} {
+		err := tx.Unscoped().
+			Model(model).
+			Where("admin_id IN ?", ids).
+			UpdateColumns(versioned(map[string]interface{}{"admin_id": nil})).
+			Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"maps"
+
+	"gorm.io/gorm"
+)
+
+// versioned добавляет к изменениям увеличение версии записи.
+func versioned(updates map[string]interface{}) map[string]interface{} {
+	values := maps.Clone(updates)
+	if values == nil {
+		values = map[string]interface{}{}
+	}
+
+	values["version"] = gorm.Expr("version + 1")
+
+	return values
+}
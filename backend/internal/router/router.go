@@ -15,6 +15,15 @@ func NewRouter(
 	l *handler.LectureHandlers,
 	m *handler.MeetHandlers,
 	sl *handler.ShortLinkHandlers,
+	c *handler.CalendarHandlers,
+	au *handler.AuditHandlers,
+	d *handler.DirectoryHandlers,
+	rm *handler.RoomHandlers,
+	et *handler.ExportTemplateHandlers,
+	wl *handler.WorkloadHandlers,
+	rd *handler.ReadinessHandlers,
+	dg *handler.DigestHandlers,
+	idempotency middleware.IdempotencyStore,
 	logger *slog.Logger,
 	frontend string,
 ) *httprouter.Router {
@@ -25,6 +34,7 @@ func NewRouter(
 	logs := middleware.LoggingMiddleware
 	roles := middleware.RoleMiddleware
 	cors := middleware.CorsMiddleware(frontend)
+	idempotent := middleware.IdempotencyMiddleware(idempotency)
 
 	// Auth
 	router.POST("/api/auth/login", chain(a.Login, cors, logs(logger)))
@@ -40,14 +50,37 @@ func NewRouter(
 		cors,
 		logs(logger),
 	))
-	router.GET("/api/meets/find", chain(
-		m.FindMany,
+	// GET /api/meets/<сегмент> делит уровень с /api/meets/:id/history
+	meetGets := withSegments(
+		map[string]segment{
+			"find": {handle: chain(
+				m.FindMany,
+				cors,
+				logs(logger),
+				auth(),
+			)},
+		},
+		map[string]httprouter.Handle{
+			"history": chain(
+				au.MeetHistory,
+				cors,
+				logs(logger),
+				auth(),
+				roles([]string{"admin", "moderator"}),
+			),
+		},
+	)
+	router.GET("/api/meets/:id", meetGets)
+	router.GET("/api/meets/:id/:sub", meetGets)
+	router.PATCH("/api/meets/:id", chain(
+		m.Update,
 		cors,
 		logs(logger),
 		auth(),
+		roles([]string{"admin", "moderator"}),
 	))
-	router.PATCH("/api/meets/:id", chain(
-		m.Update,
+	router.DELETE("/api/meets/:id", chain(
+		m.Remove,
 		cors,
 		logs(logger),
 		auth(),
@@ -68,6 +101,14 @@ func NewRouter(
 		logs(logger),
 		auth(),
 		roles([]string{"admin", "moderator"}),
+		idempotent,
+	))
+	router.POST("/api/lectures/import", chain(
+		l.Import,
+		cors,
+		logs(logger),
+		auth(),
+		roles([]string{"admin", "moderator"}),
 	))
 	router.POST("/api/lectures/links", chain(
 		l.CreateManyLinks,
@@ -76,44 +117,353 @@ func NewRouter(
 		auth(),
 		roles([]string{"admin", "moderator"}),
 	))
-	router.GET("/api/lectures/dates", chain(
-		l.GetDates,
+	router.POST("/api/lectures/links/undo", chain(
+		l.UndoLinks,
 		cors,
 		logs(logger),
 		auth(),
+		roles([]string{"admin", "moderator"}),
 	))
-	router.GET("/api/lectures/days", chain(
-		l.GetSchedule,
+	router.POST("/api/lectures/series", chain(
+		l.CreateSeries,
 		cors,
 		logs(logger),
 		auth(),
+		roles([]string{"admin", "moderator"}),
 	))
-	router.GET("/api/lectures/schedule/:date", chain(
-		l.GetByDates,
+	router.POST("/api/lectures/copy", chain(
+		l.CopyBlock,
 		cors,
 		logs(logger),
 		auth(),
+		roles([]string{"admin", "moderator"}),
 	))
-	router.PATCH("/api/lectures/:id", chain(
-		l.Update,
+	router.POST("/api/lectures/shift", chain(
+		l.ShiftBlock,
+		cors,
+		logs(logger),
+		auth(),
+		roles([]string{"admin", "moderator"}),
+	))
+	// GET /api/lectures/<сегмент> делит уровень с /api/lectures/:id/history
+	lectureGets := withSegments(
+		map[string]segment{
+			"series": {param: "id", handle: chain(
+				l.GetSeries,
+				cors,
+				logs(logger),
+				auth(),
+			)},
+			"conflicts": {handle: chain(
+				l.Conflicts,
+				cors,
+				logs(logger),
+				auth(),
+			)},
+			"dates": {handle: chain(
+				l.GetDates,
+				cors,
+				logs(logger),
+				auth(),
+			)},
+			"days": {handle: chain(
+				l.GetSchedule,
+				cors,
+				logs(logger),
+				auth(),
+			)},
+			"schedule": {param: "date", handle: chain(
+				l.GetByDates,
+				cors,
+				logs(logger),
+				auth(),
+			)},
+			"export": {handle: chain(
+				l.ExportExcel,
+				cors,
+				logs(logger),
+				auth(),
+			)},
+		},
+		map[string]httprouter.Handle{
+			"history": chain(
+				au.LectureHistory,
+				cors,
+				logs(logger),
+				auth(),
+				roles([]string{"admin", "moderator"}),
+			),
+		},
+	)
+	router.GET("/api/lectures/:id", lectureGets)
+	router.GET("/api/lectures/:id/:sub", lectureGets)
+	router.PATCH("/api/lectures/:id/series", chain(
+		l.UpdateSeries,
+		cors,
+		logs(logger),
+		auth(),
+		roles([]string{"admin", "moderator"}),
+	))
+	router.DELETE("/api/lectures/:id/series", chain(
+		l.RemoveSeries,
 		cors,
 		logs(logger),
 		auth(),
 		roles([]string{"admin", "moderator"}),
 	))
-	router.GET("/api/lectures/export", chain(
-		l.ExportExcel,
+	router.GET("/api/lectures", chain(
+		l.FindMany,
 		cors,
 		logs(logger),
 		auth(),
 	))
+	router.GET("/api/stats/lectures", chain(
+		l.Stats,
+		cors,
+		logs(logger),
+		auth(),
+	))
+	router.PATCH("/api/lectures/:id", chain(
+		withBulk(l.Update, l.BulkUpdate),
+		cors,
+		logs(logger),
+		auth(),
+		roles([]string{"admin", "moderator"}),
+	))
 	router.DELETE("/api/lectures/:id", chain(
-		l.Remove,
+		withBulk(l.Remove, l.BulkRemove),
+		cors,
+		logs(logger),
+		auth(),
+		roles([]string{"admin", "moderator"}),
+	))
+
+	// Stream keys
+	router.GET("/api/stream-keys/lectures/:id", chain(
+		l.RevealStreamKey,
+		cors,
+		logs(logger),
+		auth(),
+		roles([]string{"admin", "moderator"}),
+	))
+	router.POST("/api/stream-keys/lectures/:id/rotate", chain(
+		l.RotateStreamKey,
+		cors,
+		logs(logger),
+		auth(),
+		roles([]string{"admin", "moderator"}),
+	))
+	router.POST("/api/stream-keys/groups/:name/rotate", chain(
+		l.RotateGroupStreamKeys,
+		cors,
+		logs(logger),
+		auth(),
+		roles([]string{"admin", "moderator"}),
+	))
+
+	// Calendar
+	router.POST("/api/calendar/feeds", chain(
+		c.CreateFeed,
+		cors,
+		logs(logger),
+		auth(),
+		roles([]string{"admin", "moderator"}),
+	))
+	router.GET("/api/calendar/feeds", chain(
+		c.ListFeeds,
+		cors,
+		logs(logger),
+		auth(),
+		roles([]string{"admin", "moderator"}),
+	))
+	router.DELETE("/api/calendar/feeds/:id", chain(
+		c.RemoveFeed,
+		cors,
+		logs(logger),
+		auth(),
+		roles([]string{"admin", "moderator"}),
+	))
+	// Календарные приложения не передают JWT, доступ по токену ленты
+	router.GET("/api/calendar/group/:name", chain(c.GroupFeed, cors, logs(logger)))
+	router.GET("/api/calendar/lector/:name", chain(c.LectorFeed, cors, logs(logger)))
+	router.GET("/api/calendar/location/:name", chain(c.LocationFeed, cors, logs(logger)))
+
+	// Directories
+	router.GET("/api/directories/:kind", chain(
+		d.FindMany,
+		cors,
+		logs(logger),
+		auth(),
+	))
+	router.POST("/api/directories/:kind", chain(
+		d.Create,
+		cors,
+		logs(logger),
+		auth(),
+		roles([]string{"admin", "moderator"}),
+	))
+	router.GET("/api/directories/:kind/:id", chain(
+		d.Get,
+		cors,
+		logs(logger),
+		auth(),
+	))
+	router.PATCH("/api/directories/:kind/:id", chain(
+		d.Update,
+		cors,
+		logs(logger),
+		auth(),
+		roles([]string{"admin", "moderator"}),
+	))
+	router.DELETE("/api/directories/:kind/:id", chain(
+		d.Remove,
+		cors,
+		logs(logger),
+		auth(),
+		roles([]string{"admin"}),
+	))
+	router.POST("/api/directories/:kind/:id/merge", chain(
+		d.Merge,
+		cors,
+		logs(logger),
+		auth(),
+		roles([]string{"admin"}),
+	))
+	router.GET("/api/directory-merges", chain(
+		d.Merges,
+		cors,
+		logs(logger),
+		auth(),
+		roles([]string{"admin", "moderator"}),
+	))
+
+	// Rooms
+	router.GET("/api/rooms", chain(
+		rm.FindMany,
+		cors,
+		logs(logger),
+		auth(),
+	))
+	router.POST("/api/rooms", chain(
+		rm.Create,
+		cors,
+		logs(logger),
+		auth(),
+		roles([]string{"admin", "moderator"}),
+	))
+	router.GET("/api/rooms/:id", chain(
+		rm.Get,
+		cors,
+		logs(logger),
+		auth(),
+	))
+	router.PATCH("/api/rooms/:id", chain(
+		rm.Update,
+		cors,
+		logs(logger),
+		auth(),
+		roles([]string{"admin", "moderator"}),
+	))
+	router.DELETE("/api/rooms/:id", chain(
+		rm.Remove,
+		cors,
+		logs(logger),
+		auth(),
+		roles([]string{"admin"}),
+	))
+
+	// Export templates
+	router.GET("/api/export-templates", chain(
+		et.FindMany,
+		cors,
+		logs(logger),
+		auth(),
+	))
+	router.POST("/api/export-templates", chain(
+		et.Create,
+		cors,
+		logs(logger),
+		auth(),
+		roles([]string{"admin"}),
+	))
+	router.GET("/api/export-templates/:id", chain(
+		et.Get,
+		cors,
+		logs(logger),
+		auth(),
+	))
+	router.PATCH("/api/export-templates/:id", chain(
+		et.Update,
+		cors,
+		logs(logger),
+		auth(),
+		roles([]string{"admin"}),
+	))
+	router.DELETE("/api/export-templates/:id", chain(
+		et.Remove,
+		cors,
+		logs(logger),
+		auth(),
+		roles([]string{"admin"}),
+	))
+
+	// Workload
+	router.GET("/api/workload", chain(
+		wl.Report,
 		cors,
 		logs(logger),
 		auth(),
 		roles([]string{"admin", "moderator"}),
 	))
+	router.POST("/api/workload/assign", chain(
+		wl.AutoAssign,
+		cors,
+		logs(logger),
+		auth(),
+		roles([]string{"admin", "moderator"}),
+	))
+	router.GET("/api/technicians/:id/availability", chain(
+		wl.GetAvailability,
+		cors,
+		logs(logger),
+		auth(),
+	))
+	router.PUT("/api/technicians/:id/availability", chain(
+		wl.SetAvailability,
+		cors,
+		logs(logger),
+		auth(),
+		roles([]string{"admin", "moderator", "technician"}),
+	))
+
+	// Readiness
+	router.GET("/api/readiness", chain(
+		rd.Check,
+		cors,
+		logs(logger),
+		auth(),
+		roles([]string{"admin", "moderator", "technician"}),
+	))
+
+	// Digest
+	router.GET("/api/digests/:id", chain(
+		dg.GetSubscription,
+		cors,
+		logs(logger),
+		auth(),
+	))
+	router.PUT("/api/digests/:id", chain(
+		dg.UpdateSubscription,
+		cors,
+		logs(logger),
+		auth(),
+	))
+	router.GET("/api/digests/:id/preview", chain(
+		dg.Preview,
+		cors,
+		logs(logger),
+		auth(),
+	))
 
 	// Users
 	router.POST("/api/users", chain(
@@ -156,6 +506,80 @@ func NewRouter(
 		roles([]string{"admin"}),
 	))
 
+	// Audit
+	router.GET("/api/audit", chain(
+		au.FindMany,
+		cors,
+		logs(logger),
+		auth(),
+		roles([]string{"admin"}),
+	))
+
+	// Trash
+	router.GET("/api/trash/lectures", chain(
+		l.Trash,
+		cors,
+		logs(logger),
+		auth(),
+		roles([]string{"admin"}),
+	))
+	router.POST("/api/trash/lectures/:id/restore", chain(
+		l.Restore,
+		cors,
+		logs(logger),
+		auth(),
+		roles([]string{"admin"}),
+	))
+	router.DELETE("/api/trash/lectures/:id", chain(
+		l.Purge,
+		cors,
+		logs(logger),
+		auth(),
+		roles([]string{"admin"}),
+	))
+	router.GET("/api/trash/meets", chain(
+		m.Trash,
+		cors,
+		logs(logger),
+		auth(),
+		roles([]string{"admin"}),
+	))
+	router.POST("/api/trash/meets/:id/restore", chain(
+		m.Restore,
+		cors,
+		logs(logger),
+		auth(),
+		roles([]string{"admin"}),
+	))
+	router.DELETE("/api/trash/meets/:id", chain(
+		m.Purge,
+		cors,
+		logs(logger),
+		auth(),
+		roles([]string{"admin"}),
+	))
+	router.GET("/api/trash/users", chain(
+		u.Trash,
+		cors,
+		logs(logger),
+		auth(),
+		roles([]string{"admin"}),
+	))
+	router.POST("/api/trash/users/:id/restore", chain(
+		u.Restore,
+		cors,
+		logs(logger),
+		auth(),
+		roles([]string{"admin"}),
+	))
+	router.DELETE("/api/trash/users/:id", chain(
+		u.Purge,
+		cors,
+		logs(logger),
+		auth(),
+		roles([]string{"admin"}),
+	))
+
 	router.GlobalOPTIONS = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		origin := r.Header.Get("Origin")
 
@@ -172,7 +596,7 @@ func NewRouter(
 		}
 
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, If-Match, Idempotency-Key")
 		w.Header().Set("Access-Control-Allow-Credentials", "true")
 
 		w.WriteHeader(http.StatusNoContent)
@@ -180,3 +604,47 @@ func NewRouter(
 
 	return router
 }
+
+// segment — маршрут со статическим сегментом на месте параметра :id.
+// param — имя параметра, под которым обработчик ждёт следующий сегмент пути.
+type segment struct {
+	param  string
+	handle httprouter.Handle
+}
+
+// withSegments разбирает пути /:id и /:id/:sub: httprouter не допускает статический
+// сегмент рядом с параметром на одном уровне. Статические сегменты уходят обработчикам
+// static, а /:id/<действие> — обработчикам actions.
+func withSegments(static map[string]segment, actions map[string]httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		id, sub := ps.ByName("id"), ps.ByName("sub")
+
+		if s, ok := static[id]; ok && (s.param != "") == (sub != "") {
+			if s.param != "" {
+				ps = httprouter.Params{{Key: s.param, Value: sub}}
+			}
+			s.handle(w, r, ps)
+			return
+		}
+
+		if action, ok := actions[sub]; ok {
+			action(w, r, httprouter.Params{{Key: "id", Value: id}})
+			return
+		}
+
+		http.NotFound(w, r)
+	}
+}
+
+// withBulk отдаёт /bulk отдельному обработчику: httprouter не допускает
+// статический сегмент рядом с параметром :id на одном уровне.
+func withBulk(single, bulk httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		if ps.ByName("id") == "bulk" {
+			bulk(w, r, ps)
+			return
+		}
+
+		single(w, r, ps)
+	}
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"table-api/internal/entitys"
+	"table-api/internal/handler/dto"
+	"table-api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	AuditEntityLecture = "lecture"
+	AuditEntityMeet    = "meet"
+)
+
+const (
+	AuditActionCreate  = "create"
+	AuditActionUpdate  = "update"
+	AuditActionDelete  = "delete"
+	AuditActionRestore = "restore"
+	AuditActionPurge   = "purge"
+	// Просмотр открытого ключа трансляции
+	AuditActionReveal = "reveal"
+)
+
+type AuditLogRepository interface {
+	CreateMany(ctx context.Context, logs []*models.AuditLog) error
+	FindByEntity(ctx context.Context, entityType, entityID string) ([]*models.AuditLog, error)
+	List(ctx context.Context, page, limit int, filter dto.GetQueryAuditDto) ([]*models.AuditLog, *entitys.Pagination, error)
+}
+
+// AuditRecorder пишет изменения сущностей в журнал.
+type AuditRecorder interface {
+	Record(ctx context.Context, entityType, action string, records ...AuditRecord) error
+}
+
+// AuditRecord — состояние одной сущности до и после операции.
+// Before и After — DTO ответа API, чтобы имена полей в журнале совпадали с API.
+type AuditRecord struct {
+	EntityID string
+	Before   any
+	After    any
+	// Отпечатки секретов по имени поля: в Before и After секреты только маской,
+	// и смену ключа с теми же последними символами по ним не заметить
+	BeforeSecrets map[string]string
+	AfterSecrets  map[string]string
+}
+
+// Служебные поля не попадают в diff
+var auditIgnoredFields = map[string]struct{}{
+	"createdAt": {},
+	"updatedAt": {},
+	"deletedAt": {},
+	"warnings":  {},
+	"version":   {},
+	"CreatedAt": {},
+	"UpdatedAt": {},
+}
+
+type auditService struct {
+	auditRepo AuditLogRepository
+}
+
+func NewAuditService(repo AuditLogRepository) *auditService {
+	return &auditService{auditRepo: repo}
+}
+
+// Record пишет в журнал операции над сущностями одного типа от имени пользователя из ctx.
+// Обновления без изменённых полей пропускаются.
+func (a *auditService) Record(ctx context.Context, entityType, action string, records ...AuditRecord) error {
+	var userID *uuid.UUID
+	if id, ok := ctx.Value("userID").(uuid.UUID); ok && id != uuid.Nil {
+		userID = &id
+	}
+
+	logs := make([]*models.AuditLog, 0, len(records))
+
+	for _, record := range records {
+		changes, err := auditDiff(record)
+		if err != nil {
+			return err
+		}
+
+		if action == AuditActionUpdate && len(changes) == 0 {
+			continue
+		}
+
+		logs = append(logs, &models.AuditLog{
+			EntityType: entityType,
+			EntityID:   record.EntityID,
+			Action:     action,
+			UserID:     userID,
+			Changes:    changes,
+		})
+	}
+
+	return a.auditRepo.CreateMany(ctx, logs)
+}
+
+func (a *auditService) History(ctx context.Context, entityType, entityID string) ([]*models.AuditLog, error) {
+	return a.auditRepo.FindByEntity(ctx, entityType, entityID)
+}
+
+func (a *auditService) List(
+	ctx context.Context,
+	page, limit int,
+	filter dto.GetQueryAuditDto,
+) ([]*models.AuditLog, *entitys.Pagination, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	return a.auditRepo.List(ctx, page, limit, filter)
+}
+
+// auditDiff сравнивает JSON-представления двух состояний по полям.
+// Поле с секретом считается изменённым и при одинаковой маске, если разные отпечатки.
+func auditDiff(record AuditRecord) ([]models.AuditChange, error) {
+	beforeFields, err := auditSnapshot(record.Before)
+	if err != nil {
+		return nil, err
+	}
+
+	afterFields, err := auditSnapshot(record.After)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]struct{}, len(beforeFields)+len(afterFields))
+	for k := range beforeFields {
+		keys[k] = struct{}{}
+	}
+	for k := range afterFields {
+		keys[k] = struct{}{}
+	}
+
+	fields := make([]string, 0, len(keys))
+	for k := range keys {
+		if _, ignored := auditIgnoredFields[k]; !ignored {
+			fields = append(fields, k)
+		}
+	}
+	sort.Strings(fields)
+
+	var changes []models.AuditChange
+	for _, field := range fields {
+		b, a := beforeFields[field], afterFields[field]
+		if reflect.DeepEqual(b, a) && record.BeforeSecrets[field] == record.AfterSecrets[field] {
+			continue
+		}
+
+		changes = append(changes, models.AuditChange{Field: field, Before: b, After: a})
+	}
+
+	return changes, nil
+}
+
+func auditSnapshot(v any) (map[string]any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
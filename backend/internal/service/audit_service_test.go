@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"table-api/internal/models"
+	"table-api/pkg/utils"
+	"table-api/pkg/vault"
+	"testing"
+	"time"
+)
+
+// auditLogRepo запоминает записанные журналы.
+type auditLogRepo struct {
+	AuditLogRepository
+	logs []*models.AuditLog
+}
+
+func (a *auditLogRepo) CreateMany(ctx context.Context, logs []*models.AuditLog) error {
+	a.logs = append(a.logs, logs...)
+	return nil
+}
+
+func TestRecordStreamKeyRotation(t *testing.T) {
+	secret := func(value string) *vault.Secret {
+		s := vault.Secret(value)
+		return &s
+	}
+
+	tests := []struct {
+		name    string
+		before  *vault.Secret
+		after   *vault.Secret
+		changed bool
+	}{
+		{name: "same key", before: secret("aaaa-bbbb-cccc-1234"), after: secret("aaaa-bbbb-cccc-1234")},
+		{name: "rotated with same suffix", before: secret("aaaa-bbbb-cccc-1234"), after: secret("dddd-eeee-ffff-1234"), changed: true},
+		{name: "rotated", before: secret("aaaa-bbbb-cccc-1234"), after: secret("dddd-eeee-ffff-5678"), changed: true},
+		{name: "key set", after: secret("dddd-eeee-ffff-5678"), changed: true},
+		{name: "key removed", before: secret("aaaa-bbbb-cccc-1234"), after: secret(""), changed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &auditLogRepo{}
+			service := &lectureService{audit: NewAuditService(repo)}
+
+			date := time.Date(2025, 10, 6, 0, 0, 0, 0, time.UTC)
+			before := &models.Lecture{ID: 1, Date: date, StreamKey: tt.before}
+			after := &models.Lecture{ID: 1, Date: date, StreamKey: tt.after}
+
+			err := service.recordLectures(context.Background(), AuditActionUpdate, []*models.Lecture{before}, []*models.Lecture{after})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if changed := len(repo.logs) == 1; changed != tt.changed {
+				t.Fatalf("logged = %v, want %v", changed, tt.changed)
+			}
+			if !tt.changed {
+				return
+			}
+
+			changes := repo.logs[0].Changes
+			if len(changes) != 1 || changes[0].Field != "streamKey" {
+				t.Fatalf("changes = %+v, want one streamKey change", changes)
+			}
+
+			// В журнал попадает только маска
+			for _, value := range []any{changes[0].Before, changes[0].After} {
+				if s, ok := value.(string); ok && s != "" && !utils.IsMasked(s) {
+					t.Fatalf("plain stream key in audit: %q", s)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"table-api/internal/handler/dto"
+	"table-api/internal/mappers"
+	"table-api/internal/models"
+	"table-api/internal/visibility"
+	common "table-api/pkg"
+	"table-api/pkg/ical"
+	"table-api/pkg/timezone"
+	"time"
+)
+
+// Окно ленты: прошедший месяц и год вперёд
+const (
+	calendarPastDays   = 30
+	calendarFutureDays = 365
+)
+
+type CalendarFeedRepository interface {
+	Create(ctx context.Context, feed *models.CalendarFeed) (*models.CalendarFeed, error)
+	GetByToken(ctx context.Context, token string) (*models.CalendarFeed, error)
+	List(ctx context.Context) ([]*models.CalendarFeed, error)
+	Delete(ctx context.Context, id int) (*models.CalendarFeed, error)
+}
+
+type CalendarLectureRepository interface {
+	FindByDatesAndGroup(ctx context.Context, startDate, endDate time.Time, groupID *int) ([]*models.Lecture, error)
+	FindByDatesAndLector(ctx context.Context, startDate, endDate time.Time, lectorID int) ([]*models.Lecture, error)
+	FindByDatesAndLocation(ctx context.Context, startDate, endDate time.Time, locationID int) ([]*models.Lecture, error)
+}
+
+// CalendarZones знает часовой пояс места проведения.
+type CalendarZones interface {
+	Zone(ctx context.Context, location *string) (*time.Location, error)
+}
+
+type calendarService struct {
+	feedRepo    CalendarFeedRepository
+	lectureRepo CalendarLectureRepository
+	directories DirectoryResolver
+	zones       CalendarZones
+	view        visibility.View
+	domain      string
+}
+
+func NewCalendarService(
+	feedRepo CalendarFeedRepository,
+	lectureRepo CalendarLectureRepository,
+	directories DirectoryResolver,
+	zones CalendarZones,
+	view visibility.View,
+	domain string,
+) *calendarService {
+	return &calendarService{
+		feedRepo:    feedRepo,
+		lectureRepo: lectureRepo,
+		directories: directories,
+		zones:       zones,
+		view:        view,
+		domain:      strings.TrimRight(domain, "/"),
+	}
+}
+
+func (c *calendarService) CreateFeed(ctx context.Context, req dto.CreateCalendarFeedRequest) (*models.CalendarFeed, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+
+	feed := &models.CalendarFeed{
+		Kind:  req.Kind,
+		Name:  strings.TrimSpace(req.Name),
+		Token: base64.RawURLEncoding.EncodeToString(buf),
+	}
+
+	return c.feedRepo.Create(ctx, feed)
+}
+
+func (c *calendarService) ListFeeds(ctx context.Context) ([]*models.CalendarFeed, error) {
+	return c.feedRepo.List(ctx)
+}
+
+func (c *calendarService) RemoveFeed(ctx context.Context, id int) (*models.CalendarFeed, error) {
+	return c.feedRepo.Delete(ctx, id)
+}
+
+// FeedURL возвращает адрес подписки для календарных приложений.
+func (c *calendarService) FeedURL(feed *models.CalendarFeed) string {
+	return fmt.Sprintf(
+		"%s/api/calendar/%s/%s.ics?token=%s",
+		c.domain,
+		feed.Kind,
+		url.PathEscape(feed.Name),
+		feed.Token,
+	)
+}
+
+// Feed собирает календарь по ленте. Токен должен принадлежать ленте того же вида и имени.
+func (c *calendarService) Feed(ctx context.Context, kind, name, token string) (*ical.Calendar, error) {
+	if token == "" {
+		return nil, common.ErrUnauthorized
+	}
+
+	feed, err := c.feedRepo.GetByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return nil, common.ErrForbidden
+		}
+		return nil, err
+	}
+
+	if feed.Kind != kind || !strings.EqualFold(feed.Name, strings.TrimSpace(name)) {
+		return nil, common.ErrForbidden
+	}
+
+	now := timezone.Now()
+	start := now.AddDate(0, 0, -calendarPastDays)
+	end := now.AddDate(0, 0, calendarFutureDays)
+
+	var lectures []*models.Lecture
+
+	// Лента привязана к названию; пока записи справочника нет, она пуста
+	entry, err := c.directories.Lookup(ctx, feed.Kind, feed.Name)
+	if err != nil && !errors.Is(err, common.ErrNotFound) {
+		return nil, err
+	}
+
+	if entry != nil {
+		switch feed.Kind {
+		case dto.CalendarFeedGroup:
+			lectures, err = c.lectureRepo.FindByDatesAndGroup(ctx, start, end, &entry.ID)
+		case dto.CalendarFeedLector:
+			lectures, err = c.lectureRepo.FindByDatesAndLector(ctx, start, end, entry.ID)
+		case dto.CalendarFeedLocation:
+			lectures, err = c.lectureRepo.FindByDatesAndLocation(ctx, start, end, entry.ID)
+		default:
+			return nil, common.ErrNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	calendar := &ical.Calendar{
+		ProdID: "-//tables-conference-managment//lectures//RU",
+		Name:   feed.Name,
+	}
+
+	// Пояс ищется один раз на место, лекций в ленте может быть много
+	zones := map[string]*time.Location{}
+
+	for _, lecture := range lectures {
+		key := ""
+		if lecture.Location != nil {
+			key = *lecture.Location
+		}
+
+		zone, ok := zones[key]
+		if !ok {
+			zone, err = c.zones.Zone(ctx, lecture.Location)
+			if err != nil {
+				return nil, err
+			}
+			zones[key] = zone
+		}
+
+		calendar.Events = append(calendar.Events, c.lectureEvent(lecture, zone))
+	}
+
+	return calendar, nil
+}
+
+// lectureEvent переводит время лекции в момент по поясу места zone.
+func (c *calendarService) lectureEvent(lecture *models.Lecture, zone *time.Location) ical.Event {
+	// Текст события собирается из полей, открытых подписчикам лент
+	visible := mappers.LectureToDto(lecture, c.view)
+
+	event := ical.Event{
+		UID:          fmt.Sprintf("lecture-%d@%s", lecture.ID, c.host()),
+		Summary:      joinNonEmpty(" · ", visible.Group, visible.Lector),
+		Location:     joinNonEmpty(", ", visible.Unit, visible.Location),
+		LastModified: lecture.CreatedAt,
+	}
+
+	if event.Summary == "" {
+		event.Summary = "Лекция"
+	}
+
+	// SEQUENCE растёт с каждым изменением, чтобы клиенты подхватывали правки:
+	// версия новой лекции равна 1, а SEQUENCE начинается с 0
+	event.Sequence = max(lecture.Version-1, 0)
+	if lecture.UpdatedAt != nil {
+		event.LastModified = *lecture.UpdatedAt
+	}
+
+	var description []string
+	if visible.Platform != nil && *visible.Platform != "" {
+		description = append(description, "Платформа: "+*visible.Platform)
+	}
+	if visible.ShortURL != nil && *visible.ShortURL != "" {
+		event.URL = c.domain + "/l/" + *visible.ShortURL
+		description = append(description, "Ссылка: "+event.URL)
+	}
+	if visible.Description != nil && *visible.Description != "" {
+		description = append(description, *visible.Description)
+	}
+	event.Description = strings.Join(description, "\n")
+
+	day := startOfDay(lecture.Date)
+	event.Start = day
+	event.AllDay = true
+
+	if start, end, ok := lectureInterval(lecture); ok {
+		local := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, zone)
+
+		event.AllDay = false
+		event.Start = start.On(local)
+		event.End = end.On(local)
+	}
+
+	return event
+}
+
+func (c *calendarService) host() string {
+	if u, err := url.Parse(c.domain); err == nil && u.Host != "" {
+		return u.Host
+	}
+
+	return c.domain
+}
+
+func joinNonEmpty(sep string, values ...*string) string {
+	var parts []string
+	for _, v := range values {
+		if v != nil && strings.TrimSpace(*v) != "" {
+			parts = append(parts, strings.TrimSpace(*v))
+		}
+	}
+
+	return strings.Join(parts, sep)
+}
@@ -0,0 +1,441 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"log/slog"
+	"slices"
+	"strings"
+	"table-api/internal/entitys"
+	"table-api/internal/handler/dto"
+	"table-api/internal/models"
+	common "table-api/pkg"
+	"table-api/pkg/timezone"
+	"table-api/pkg/utils"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DigestSubscriptionRepository interface {
+	GetByUser(ctx context.Context, userID uuid.UUID) (*models.DigestSubscription, error)
+	ListEnabled(ctx context.Context) ([]*models.DigestSubscription, error)
+	Save(ctx context.Context, subscription *models.DigestSubscription) (*models.DigestSubscription, error)
+}
+
+type DigestLectureRepository interface {
+	FindByDateRange(ctx context.Context, startDate, endDate time.Time) ([]*models.Lecture, error)
+}
+
+type DigestMeetRepository interface {
+	FindOverlapping(ctx context.Context, start, end time.Time) ([]*models.Meet, error)
+}
+
+type DigestUserRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*models.User, error)
+}
+
+// DigestMailer отправляет письмо с текстовой и HTML-версией.
+type DigestMailer interface {
+	SendAlternative(to, subject, text, html string)
+}
+
+type digestService struct {
+	subscriptions DigestSubscriptionRepository
+	lectureRepo   DigestLectureRepository
+	meetRepo      DigestMeetRepository
+	userRepo      DigestUserRepository
+	directories   DirectoryResolver
+	mailer        DigestMailer
+	sendAt        utils.Clock
+	domain        string
+	logger        *slog.Logger
+}
+
+func NewDigestService(
+	subscriptions DigestSubscriptionRepository,
+	lectureRepo DigestLectureRepository,
+	meetRepo DigestMeetRepository,
+	userRepo DigestUserRepository,
+	directories DirectoryResolver,
+	mailer DigestMailer,
+	sendAt utils.Clock,
+	domain string,
+	logger *slog.Logger,
+) *digestService {
+	return &digestService{
+		subscriptions: subscriptions,
+		lectureRepo:   lectureRepo,
+		meetRepo:      meetRepo,
+		userRepo:      userRepo,
+		directories:   directories,
+		mailer:        mailer,
+		sendAt:        sendAt,
+		domain:        domain,
+		logger:        logger,
+	}
+}
+
+// GetSubscription возвращает подписку пользователя. Пока пользователь её не настроил,
+// возвращается выключенная подписка с охватом по его роли.
+func (d *digestService) GetSubscription(ctx context.Context, userID uuid.UUID) (*models.DigestSubscription, error) {
+	user, err := d.user(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.subscription(ctx, user)
+}
+
+// UpdateSubscription включает или выключает сводку и меняет её охват.
+func (d *digestService) UpdateSubscription(
+	ctx context.Context,
+	userID uuid.UUID,
+	req dto.UpdateDigestSubscriptionRequest,
+) (*models.DigestSubscription, error) {
+	user, err := d.user(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription, err := d.subscription(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Enabled != nil {
+		subscription.Enabled = *req.Enabled
+	}
+
+	if req.Scope != nil {
+		subscription.Scope = *req.Scope
+	}
+
+	if subscription.Scope == models.DigestScopeAll && user.Role != "admin" && user.Role != "moderator" {
+		return nil, fmt.Errorf("%w: scope %q is available to admins and moderators", common.ErrInvalidInput, models.DigestScopeAll)
+	}
+
+	if req.Lector != nil {
+		entry, err := d.directories.Lookup(ctx, models.DirectoryLector, *req.Lector)
+		if errors.Is(err, common.ErrNotFound) {
+			return nil, fmt.Errorf("%w: unknown lector %q", common.ErrInvalidInput, *req.Lector)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		subscription.LectorID = &entry.ID
+		subscription.Lector = &entry.Name
+	}
+
+	if subscription.Scope == models.DigestScopeLector && subscription.LectorID == nil {
+		return nil, fmt.Errorf("%w: scope %q requires a lector", common.ErrInvalidInput, models.DigestScopeLector)
+	}
+
+	if subscription.Enabled && (user.Email == nil || *user.Email == "") {
+		return nil, fmt.Errorf("%w: user %s has no email", common.ErrInvalidInput, user.Login)
+	}
+
+	return d.subscriptions.Save(ctx, subscription)
+}
+
+// Preview собирает сводку пользователя на дату так, как она уйдёт письмом.
+func (d *digestService) Preview(ctx context.Context, userID uuid.UUID, date time.Time) (*entitys.DigestMail, error) {
+	user, err := d.user(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription, err := d.subscription(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := d.schedule(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+
+	return renderDigest(schedule.For(user, subscription))
+}
+
+// AutoSend каждый день в заданное время рассылает подписчикам сводку на завтра.
+func (d *digestService) AutoSend() {
+	for {
+		time.Sleep(time.Until(nextDaily(time.Now(), d.sendAt)))
+		d.send(context.Background(), startOfDay(timezone.Now()).AddDate(0, 0, 1))
+	}
+}
+
+func (d *digestService) send(ctx context.Context, date time.Time) {
+	subscriptions, err := d.subscriptions.ListEnabled(ctx)
+	if err != nil {
+		d.logger.Error(fmt.Sprintf("digest subscriptions: %v", err))
+		return
+	}
+
+	if len(subscriptions) == 0 {
+		return
+	}
+
+	schedule, err := d.schedule(ctx, date)
+	if err != nil {
+		d.logger.Error(fmt.Sprintf("digest schedule: %v", err))
+		return
+	}
+
+	sent := 0
+
+	for _, subscription := range subscriptions {
+		user, err := d.userRepo.GetByID(ctx, subscription.UserID)
+		if err != nil {
+			d.logger.Warn(fmt.Sprintf("digest recipient %s: %v", subscription.UserID, err))
+			continue
+		}
+
+		if user.Email == nil || *user.Email == "" {
+			d.logger.Warn(fmt.Sprintf("digest recipient %s has no email", user.Login))
+			continue
+		}
+
+		digest := schedule.For(user, subscription)
+
+		// Пустые сводки не отправляются
+		if len(digest.Entries) == 0 {
+			continue
+		}
+
+		mail, err := renderDigest(digest)
+		if err != nil {
+			d.logger.Error(fmt.Sprintf("digest for %s: %v", user.Login, err))
+			continue
+		}
+
+		go d.mailer.SendAlternative(*user.Email, mail.Subject, mail.Text, mail.HTML)
+		sent++
+	}
+
+	d.logger.Info(fmt.Sprintf("digest for %s: sent %d emails", date.Format("2006-01-02"), sent))
+}
+
+// user возвращает получателя сводки. Чужие подписки доступны только админам.
+func (d *digestService) user(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	if role, _ := ctx.Value("role").(string); role != "admin" {
+		if actor, _ := ctx.Value("userID").(uuid.UUID); actor != userID {
+			return nil, fmt.Errorf("%w: only admins can manage digests of other users", common.ErrForbidden)
+		}
+	}
+
+	return d.userRepo.GetByID(ctx, userID)
+}
+
+func (d *digestService) subscription(ctx context.Context, user *models.User) (*models.DigestSubscription, error) {
+	subscription, err := d.subscriptions.GetByUser(ctx, user.ID)
+	if err == nil {
+		return subscription, nil
+	}
+	if !errors.Is(err, common.ErrNotFound) {
+		return nil, err
+	}
+
+	scope := models.DigestScopeAdmin
+	if user.Role == "admin" || user.Role == "moderator" {
+		scope = models.DigestScopeAll
+	}
+
+	return &models.DigestSubscription{UserID: user.ID, Scope: scope}, nil
+}
+
+// digestSchedule — лекции и мероприятия дня, общие для всех получателей.
+type digestSchedule struct {
+	date     time.Time
+	lectures []*models.Lecture
+	meets    []*models.Meet
+	domain   string
+}
+
+func (d *digestService) schedule(ctx context.Context, date time.Time) (*digestSchedule, error) {
+	day := startOfDay(date)
+
+	lectures, err := d.lectureRepo.FindByDateRange(ctx, day, endOfDay(day))
+	if err != nil {
+		return nil, err
+	}
+
+	meets, err := d.meetRepo.FindOverlapping(ctx, day, day.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	return &digestSchedule{date: day, lectures: lectures, meets: meets, domain: d.domain}, nil
+}
+
+// For отбирает записи по охвату подписки.
+func (s *digestSchedule) For(user *models.User, subscription *models.DigestSubscription) *entitys.Digest {
+	digest := &entitys.Digest{
+		Date:      s.date,
+		Recipient: adminName(user),
+		Scope:     subscription.Scope,
+		Entries:   []entitys.DigestEntry{},
+	}
+
+	for _, lecture := range s.lectures {
+		switch subscription.Scope {
+		case models.DigestScopeAdmin:
+			if lecture.AdminID == nil || *lecture.AdminID != user.ID {
+				continue
+			}
+		case models.DigestScopeLector:
+			if lecture.LectorID == nil || subscription.LectorID == nil || *lecture.LectorID != *subscription.LectorID {
+				continue
+			}
+		}
+
+		entry := entitys.DigestEntry{
+			Kind:     entitys.AssignmentLecture,
+			ID:       lecture.ID,
+			Title:    joinNonEmpty(" · ", lecture.Group, lecture.Lector),
+			Location: joinNonEmpty(", ", lecture.Unit, lecture.Location),
+			Platform: derefString(lecture.Platform),
+			Link:     s.link(lecture.ShortURL),
+			Admin:    derefString(lecture.Admin),
+		}
+
+		if lecture.Start != nil && lecture.End != nil {
+			entry.Time = lecture.Start.String() + "–" + lecture.End.String()
+		}
+
+		digest.Entries = append(digest.Entries, entry)
+	}
+
+	for _, meet := range s.meets {
+		switch subscription.Scope {
+		case models.DigestScopeAdmin:
+			if meet.AdminID == nil || *meet.AdminID != user.ID {
+				continue
+			}
+		case models.DigestScopeLector:
+			continue
+		}
+
+		entry := entitys.DigestEntry{
+			Kind:     entitys.AssignmentMeet,
+			ID:       meet.ID,
+			Title:    derefString(meet.EventName),
+			Location: derefString(meet.Location),
+			Platform: derefString(meet.Platform),
+			Link:     s.link(meet.ShortURL),
+			Admin:    derefString(meet.Admin),
+		}
+
+		if meet.Start != nil && meet.End != nil {
+			entry.Time = meet.Start.In(timezone.Org()).Format("15:04") + "–" + meet.End.In(timezone.Org()).Format("15:04")
+		}
+
+		digest.Entries = append(digest.Entries, entry)
+	}
+
+	// Записи без времени идут в конце дня
+	slices.SortStableFunc(digest.Entries, func(a, b entitys.DigestEntry) int {
+		if (a.Time == "") != (b.Time == "") {
+			if a.Time == "" {
+				return 1
+			}
+			return -1
+		}
+		return strings.Compare(a.Time, b.Time)
+	})
+
+	return digest
+}
+
+func (s *digestSchedule) link(code *string) string {
+	if code == nil || *code == "" {
+		return ""
+	}
+
+	return s.domain + "/l/" + *code
+}
+
+// Названия месяцев в родительном падеже для дат в письме
+var digestMonths = [...]string{
+	"января", "февраля", "марта", "апреля", "мая", "июня",
+	"июля", "августа", "сентября", "октября", "ноября", "декабря",
+}
+
+func digestDate(t time.Time) string {
+	return fmt.Sprintf("%d %s %d", t.Day(), digestMonths[t.Month()-1], t.Year())
+}
+
+var digestFuncs = map[string]any{
+	"date": digestDate,
+	"kind": func(kind string) string {
+		if kind == entitys.AssignmentMeet {
+			return "Мероприятие"
+		}
+		return "Лекция"
+	},
+}
+
+var digestText = texttemplate.Must(texttemplate.New("digest").Funcs(digestFuncs).Parse(
+	`Здравствуйте, {{.Recipient}}!
+
+Расписание на {{date .Date}}:
+{{range .Entries}}
+{{if .Time}}{{.Time}}{{else}}Время не указано{{end}} — {{kind .Kind}}: {{or .Title "без названия"}}
+{{- if .Location}}
+  Место: {{.Location}}{{end}}
+{{- if .Platform}}
+  Платформа: {{.Platform}}{{end}}
+{{- if .Link}}
+  Ссылка: {{.Link}}{{end}}
+{{- if .Admin}}
+  Админ: {{.Admin}}{{end}}
+{{end}}`))
+
+var digestHTML = htmltemplate.Must(htmltemplate.New("digest").Funcs(digestFuncs).Parse(
+	`<!DOCTYPE html>
+<html>
+<body style="font-family: Arial, sans-serif; font-size: 14px;">
+<p>Здравствуйте, {{.Recipient}}!</p>
+<p>Расписание на <b>{{date .Date}}</b>:</p>
+<table cellpadding="6" cellspacing="0" border="1" style="border-collapse: collapse;">
+<tr style="background: #4CAF50; color: #FFFFFF;">
+<th>Время</th><th>Что</th><th>Место</th><th>Платформа</th><th>Ссылка</th><th>Админ</th>
+</tr>
+{{range .Entries}}<tr>
+<td>{{or .Time "—"}}</td>
+<td>{{kind .Kind}}: {{or .Title "без названия"}}</td>
+<td>{{.Location}}</td>
+<td>{{.Platform}}</td>
+<td>{{if .Link}}<a href="{{.Link}}">{{.Link}}</a>{{end}}</td>
+<td>{{.Admin}}</td>
+</tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+// renderDigest собирает тему, текстовую и HTML-версии письма.
+func renderDigest(digest *entitys.Digest) (*entitys.DigestMail, error) {
+	var text, html bytes.Buffer
+
+	if err := digestText.Execute(&text, digest); err != nil {
+		return nil, err
+	}
+
+	if err := digestHTML.Execute(&html, digest); err != nil {
+		return nil, err
+	}
+
+	return &entitys.DigestMail{
+		Digest:  digest,
+		Subject: fmt.Sprintf("Расписание на %s: записей %d", digestDate(digest.Date), len(digest.Entries)),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
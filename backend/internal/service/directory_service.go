@@ -0,0 +1,388 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"table-api/internal/entitys"
+	"table-api/internal/handler/dto"
+	"table-api/internal/models"
+	common "table-api/pkg"
+	"table-api/pkg/utils"
+)
+
+type DirectoryRepository interface {
+	Create(ctx context.Context, entry *models.DirectoryEntry) (*models.DirectoryEntry, error)
+	GetByID(ctx context.Context, kind string, id int) (*models.DirectoryEntry, error)
+	FindByIDs(ctx context.Context, kind string, ids []int) ([]*models.DirectoryEntry, error)
+	FindByKey(ctx context.Context, kind, key string) (*models.DirectoryEntry, error)
+	List(ctx context.Context, kind string, page, limit int, search *string) ([]*models.DirectoryEntry, *entitys.Pagination, error)
+	Save(ctx context.Context, entry *models.DirectoryEntry) (*models.DirectoryEntry, error)
+	Delete(ctx context.Context, ids ...int) error
+	CreateMerges(ctx context.Context, merges []*models.DirectoryMerge) error
+	ListMerges(ctx context.Context, kind *string, page, limit int) ([]*models.DirectoryMerge, *entitys.Pagination, error)
+}
+
+type DirectoryLectureRepository interface {
+	CountByDirectory(ctx context.Context, kind string, id int) (int64, error)
+	RelinkDirectory(ctx context.Context, kind string, from []int, to int, name string) (int64, error)
+}
+
+// DirectoryResolver сопоставляет названия из лекций с записями справочников.
+type DirectoryResolver interface {
+	// Lookup ищет запись по названию или псевдониму
+	Lookup(ctx context.Context, kind, name string) (*models.DirectoryEntry, error)
+	// Resolve ищет запись и создаёт её, если такой ещё нет
+	Resolve(ctx context.Context, kind, name string) (*models.DirectoryEntry, error)
+}
+
+type directoryService struct {
+	directoryRepo DirectoryRepository
+	lectureRepo   DirectoryLectureRepository
+	tx            Transactor
+}
+
+func NewDirectoryService(
+	repo DirectoryRepository,
+	lectureRepo DirectoryLectureRepository,
+	tx Transactor,
+) *directoryService {
+	return &directoryService{
+		directoryRepo: repo,
+		lectureRepo:   lectureRepo,
+		tx:            tx,
+	}
+}
+
+func (d *directoryService) Lookup(ctx context.Context, kind, name string) (*models.DirectoryEntry, error) {
+	key := utils.NameKey(name)
+	if key == "" {
+		return nil, common.ErrNotFound
+	}
+
+	return d.directoryRepo.FindByKey(ctx, kind, key)
+}
+
+func (d *directoryService) Resolve(ctx context.Context, kind, name string) (*models.DirectoryEntry, error) {
+	entry, err := d.Lookup(ctx, kind, name)
+	if !errors.Is(err, common.ErrNotFound) {
+		return entry, err
+	}
+
+	if utils.NameKey(name) == "" {
+		return nil, fmt.Errorf("%w: %s name must not be empty", common.ErrInvalidInput, kind)
+	}
+
+	entry, err = d.directoryRepo.Create(ctx, &models.DirectoryEntry{
+		Kind: kind,
+		Name: utils.CleanName(name),
+		Key:  utils.NameKey(name),
+	})
+
+	// Запись могли создать параллельно
+	if errors.Is(err, common.ErrAlreadyExists) {
+		return d.Lookup(ctx, kind, name)
+	}
+
+	return entry, err
+}
+
+func (d *directoryService) Create(
+	ctx context.Context,
+	kind string,
+	req dto.CreateDirectoryEntryRequest,
+) (*models.DirectoryEntry, error) {
+	entry := &models.DirectoryEntry{
+		Kind: kind,
+		Name: utils.CleanName(req.Name),
+		Key:  utils.NameKey(req.Name),
+	}
+
+	if entry.Key == "" {
+		return nil, fmt.Errorf("%w: name must not be empty", common.ErrInvalidInput)
+	}
+
+	setDirectoryEmail(entry, req.Email)
+	setDirectoryAliases(entry, req.Aliases)
+
+	if err := d.ensureUniqueKeys(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return d.directoryRepo.Create(ctx, entry)
+}
+
+func (d *directoryService) Get(ctx context.Context, kind string, id int) (*models.DirectoryEntry, error) {
+	return d.directoryRepo.GetByID(ctx, kind, id)
+}
+
+func (d *directoryService) List(
+	ctx context.Context,
+	kind string,
+	page, limit int,
+	search *string,
+) ([]*models.DirectoryEntry, *entitys.Pagination, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	return d.directoryRepo.List(ctx, kind, page, limit, search)
+}
+
+// Update меняет запись справочника. При переименовании название
+// обновляется во всех лекциях, которые на неё ссылаются.
+func (d *directoryService) Update(
+	ctx context.Context,
+	kind string,
+	id int,
+	req dto.UpdateDirectoryEntryRequest,
+) (*models.DirectoryEntry, error) {
+	var updated *models.DirectoryEntry
+
+	err := d.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		entry, err := d.directoryRepo.GetByID(ctx, kind, id)
+		if err != nil {
+			return err
+		}
+
+		renamed := false
+		if req.Name != nil && utils.CleanName(*req.Name) != entry.Name {
+			if utils.NameKey(*req.Name) == "" {
+				return fmt.Errorf("%w: name must not be empty", common.ErrInvalidInput)
+			}
+
+			entry.Name = utils.CleanName(*req.Name)
+			entry.Key = utils.NameKey(*req.Name)
+			renamed = true
+		}
+
+		if req.Email != nil {
+			setDirectoryEmail(entry, req.Email)
+		}
+
+		if req.Aliases != nil {
+			setDirectoryAliases(entry, *req.Aliases)
+		} else {
+			setDirectoryAliases(entry, entry.Aliases)
+		}
+
+		if err := d.ensureUniqueKeys(ctx, entry); err != nil {
+			return err
+		}
+
+		updated, err = d.directoryRepo.Save(ctx, entry)
+		if err != nil {
+			return err
+		}
+
+		if renamed {
+			_, err = d.lectureRepo.RelinkDirectory(ctx, kind, []int{id}, id, entry.Name)
+		}
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// Remove удаляет запись, на которую не ссылается ни одна лекция, в том числе из корзины.
+func (d *directoryService) Remove(ctx context.Context, kind string, id int) (*models.DirectoryEntry, error) {
+	var removed *models.DirectoryEntry
+
+	err := d.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		entry, err := d.directoryRepo.GetByID(ctx, kind, id)
+		if err != nil {
+			return err
+		}
+
+		count, err := d.lectureRepo.CountByDirectory(ctx, kind, id)
+		if err != nil {
+			return err
+		}
+
+		if count > 0 {
+			return fmt.Errorf("%w: %s is used by %d lectures, merge it instead", common.ErrConflict, kind, count)
+		}
+
+		removed = entry
+		return d.directoryRepo.Delete(ctx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return removed, nil
+}
+
+// Merge сводит записи ids в запись id: лекции переходят на неё,
+// названия и псевдонимы исходных записей становятся её псевдонимами.
+func (d *directoryService) Merge(
+	ctx context.Context,
+	kind string,
+	id int,
+	ids []int,
+) (*models.DirectoryEntry, []*models.DirectoryMerge, error) {
+	for _, sourceID := range ids {
+		if sourceID == id {
+			return nil, nil, fmt.Errorf("%w: cannot merge %s into itself", common.ErrInvalidInput, kind)
+		}
+	}
+
+	var (
+		target *models.DirectoryEntry
+		merges []*models.DirectoryMerge
+	)
+
+	err := d.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		target, err = d.directoryRepo.GetByID(ctx, kind, id)
+		if err != nil {
+			return err
+		}
+
+		sources, err := d.directoryRepo.FindByIDs(ctx, kind, ids)
+		if err != nil {
+			return err
+		}
+
+		if len(sources) != len(uniqueInts(ids)) {
+			return fmt.Errorf("%w: some %s entries do not exist", common.ErrNotFound, kind)
+		}
+
+		aliases := append([]string{}, target.Aliases...)
+		sourceIDs := make([]int, 0, len(sources))
+
+		for _, source := range sources {
+			count, err := d.lectureRepo.CountByDirectory(ctx, kind, source.ID)
+			if err != nil {
+				return err
+			}
+
+			aliases = append(aliases, source.Name)
+			aliases = append(aliases, source.Aliases...)
+			sourceIDs = append(sourceIDs, source.ID)
+
+			merges = append(merges, &models.DirectoryMerge{
+				Kind:      kind,
+				EntryID:   target.ID,
+				Variant:   source.Name,
+				Canonical: target.Name,
+				Lectures:  count,
+				Source:    models.DirectoryMergeManual,
+			})
+		}
+
+		if _, err := d.lectureRepo.RelinkDirectory(ctx, kind, sourceIDs, target.ID, target.Name); err != nil {
+			return err
+		}
+
+		// Исходные записи удаляются до сохранения псевдонимов, иначе ключи совпадут
+		if err := d.directoryRepo.Delete(ctx, sourceIDs...); err != nil {
+			return err
+		}
+
+		setDirectoryAliases(target, aliases)
+
+		if target, err = d.directoryRepo.Save(ctx, target); err != nil {
+			return err
+		}
+
+		return d.directoryRepo.CreateMerges(ctx, merges)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return target, merges, nil
+}
+
+func (d *directoryService) ListMerges(
+	ctx context.Context,
+	kind *string,
+	page, limit int,
+) ([]*models.DirectoryMerge, *entitys.Pagination, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	return d.directoryRepo.ListMerges(ctx, kind, page, limit)
+}
+
+// ensureUniqueKeys проверяет, что название и псевдонимы не заняты другими записями того же вида.
+func (d *directoryService) ensureUniqueKeys(ctx context.Context, entry *models.DirectoryEntry) error {
+	for _, key := range append([]string{entry.Key}, entry.AliasKeys...) {
+		existing, err := d.directoryRepo.FindByKey(ctx, entry.Kind, key)
+		if errors.Is(err, common.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if existing.ID != entry.ID {
+			return fmt.Errorf("%w: %q is already used by %s %q", common.ErrAlreadyExists, key, entry.Kind, existing.Name)
+		}
+	}
+
+	return nil
+}
+
+func setDirectoryEmail(entry *models.DirectoryEntry, email *string) {
+	if email == nil || strings.TrimSpace(*email) == "" {
+		entry.Email = nil
+		return
+	}
+
+	trimmed := strings.TrimSpace(*email)
+	entry.Email = &trimmed
+}
+
+// setDirectoryAliases сохраняет псевдонимы без повторов и без совпадений с названием.
+func setDirectoryAliases(entry *models.DirectoryEntry, aliases []string) {
+	seen := map[string]struct{}{entry.Key: {}}
+
+	entry.Aliases = []string{}
+	entry.AliasKeys = []string{}
+
+	for _, alias := range aliases {
+		key := utils.NameKey(alias)
+		if _, ok := seen[key]; ok || key == "" {
+			continue
+		}
+
+		seen[key] = struct{}{}
+		entry.Aliases = append(entry.Aliases, utils.CleanName(alias))
+		entry.AliasKeys = append(entry.AliasKeys, key)
+	}
+}
+
+func uniqueInts(values []int) []int {
+	seen := make(map[int]struct{}, len(values))
+	result := make([]int, 0, len(values))
+
+	for _, v := range values {
+		if _, ok := seen[v]; !ok {
+			seen[v] = struct{}{}
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
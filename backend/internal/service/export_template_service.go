@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"table-api/internal/handler/dto"
+	"table-api/internal/models"
+	common "table-api/pkg"
+)
+
+// Имя встроенного шаблона: раскладка, которую понимает импорт
+const defaultExportTemplateName = "default"
+
+type ExportTemplateRepository interface {
+	Create(ctx context.Context, template *models.ExportTemplate) (*models.ExportTemplate, error)
+	GetByID(ctx context.Context, id int) (*models.ExportTemplate, error)
+	GetByName(ctx context.Context, name string) (*models.ExportTemplate, error)
+	List(ctx context.Context) ([]*models.ExportTemplate, error)
+	Save(ctx context.Context, template *models.ExportTemplate) (*models.ExportTemplate, error)
+	Delete(ctx context.Context, id int) (*models.ExportTemplate, error)
+}
+
+// ExportTemplateResolver находит шаблон выгрузки по имени.
+type ExportTemplateResolver interface {
+	Template(ctx context.Context, name string) (*models.ExportTemplate, error)
+}
+
+type exportTemplateService struct {
+	repo ExportTemplateRepository
+}
+
+func NewExportTemplateService(repo ExportTemplateRepository) *exportTemplateService {
+	return &exportTemplateService{repo: repo}
+}
+
+func (e *exportTemplateService) Create(ctx context.Context, req dto.CreateExportTemplateRequest) (*models.ExportTemplate, error) {
+	name, err := exportTemplateName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	columns, err := templateColumns(req.Columns)
+	if err != nil {
+		return nil, err
+	}
+
+	defaults := defaultExportTemplate()
+
+	template := &models.ExportTemplate{
+		Name:       name,
+		Columns:    columns,
+		DateFormat: defaults.DateFormat,
+		TimeFormat: defaults.TimeFormat,
+	}
+
+	if req.DateFormat != nil {
+		template.DateFormat = *req.DateFormat
+	}
+
+	if req.TimeFormat != nil {
+		template.TimeFormat = *req.TimeFormat
+	}
+
+	return e.repo.Create(ctx, template)
+}
+
+func (e *exportTemplateService) Get(ctx context.Context, id int) (*models.ExportTemplate, error) {
+	return e.repo.GetByID(ctx, id)
+}
+
+// List возвращает встроенный шаблон и шаблоны из БД.
+func (e *exportTemplateService) List(ctx context.Context) ([]*models.ExportTemplate, error) {
+	templates, err := e.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]*models.ExportTemplate{defaultExportTemplate()}, templates...), nil
+}
+
+func (e *exportTemplateService) Update(ctx context.Context, id int, req dto.UpdateExportTemplateRequest) (*models.ExportTemplate, error) {
+	template, err := e.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		if template.Name, err = exportTemplateName(*req.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Columns != nil {
+		if template.Columns, err = templateColumns(*req.Columns); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.DateFormat != nil {
+		template.DateFormat = *req.DateFormat
+	}
+
+	if req.TimeFormat != nil {
+		template.TimeFormat = *req.TimeFormat
+	}
+
+	return e.repo.Save(ctx, template)
+}
+
+func (e *exportTemplateService) Remove(ctx context.Context, id int) (*models.ExportTemplate, error) {
+	return e.repo.Delete(ctx, id)
+}
+
+// Template возвращает шаблон по имени; пустое имя — встроенный шаблон.
+func (e *exportTemplateService) Template(ctx context.Context, name string) (*models.ExportTemplate, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || name == defaultExportTemplateName {
+		return defaultExportTemplate(), nil
+	}
+
+	template, err := e.repo.GetByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: export template %q", err, name)
+	}
+
+	return template, nil
+}
+
+func defaultExportTemplate() *models.ExportTemplate {
+	columns := make([]models.ExportColumn, 0, len(defaultExportFields))
+	for _, field := range defaultExportFields {
+		columns = append(columns, models.ExportColumn{Field: field, Header: exportFields[field].Header})
+	}
+
+	return &models.ExportTemplate{
+		Name:       defaultExportTemplateName,
+		Columns:    columns,
+		DateFormat: "YYYY-MM-DD",
+		TimeFormat: "HH:mm",
+	}
+}
+
+func exportTemplateName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+
+	if name == "" {
+		return "", fmt.Errorf("%w: template name is required", common.ErrInvalidInput)
+	}
+
+	if name == defaultExportTemplateName {
+		return "", fmt.Errorf("%w: template name %q is reserved", common.ErrInvalidInput, name)
+	}
+
+	return name, nil
+}
+
+// templateColumns проверяет поля колонок и подставляет заголовки по умолчанию.
+func templateColumns(columns []dto.ExportColumnDto) ([]models.ExportColumn, error) {
+	result := make([]models.ExportColumn, 0, len(columns))
+	seen := make(map[string]struct{}, len(columns))
+
+	for _, column := range columns {
+		field, ok := exportFields[column.Field]
+		if !ok {
+			return nil, fmt.Errorf(
+				"%w: unknown export field %q, expected one of %s",
+				common.ErrInvalidInput, column.Field, strings.Join(exportFieldNames(), ", "),
+			)
+		}
+
+		if _, ok := seen[column.Field]; ok {
+			return nil, fmt.Errorf("%w: duplicate export field %q", common.ErrInvalidInput, column.Field)
+		}
+		seen[column.Field] = struct{}{}
+
+		header := strings.TrimSpace(column.Header)
+		if header == "" {
+			header = field.Header
+		}
+
+		result = append(result, models.ExportColumn{Field: column.Field, Header: header})
+	}
+
+	return result, nil
+}
+
+func exportFieldNames() []string {
+	names := make([]string, 0, len(exportFields))
+	for name := range exportFields {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"table-api/internal/entitys"
+	"table-api/internal/models"
+	"table-api/internal/visibility"
+	common "table-api/pkg"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Наибольшая длина Idempotency-Key
+const maxIdempotencyKeyLength = 255
+
+// Сколько ждать исходный запрос, прежде чем считать его оборванным и выполнить повтор
+const idempotencyPendingTimeout = 5 * time.Minute
+
+type IdempotencyKeyRepository interface {
+	Reserve(ctx context.Context, record *models.IdempotencyKey) (bool, error)
+	Get(ctx context.Context, userID uuid.UUID, key string) (*models.IdempotencyKey, error)
+	Complete(ctx context.Context, id int, status int, body []byte) error
+	Delete(ctx context.Context, id int) error
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type idempotencyService struct {
+	repo      IdempotencyKeyRepository
+	retention time.Duration
+	logger    *slog.Logger
+}
+
+func NewIdempotencyService(repo IdempotencyKeyRepository, retention time.Duration, logger *slog.Logger) *idempotencyService {
+	return &idempotencyService{repo: repo, retention: retention, logger: logger}
+}
+
+// Begin занимает ключ пользователя из ctx под запрос с отпечатком fingerprint.
+// Если запрос с этим ключом уже выполнен, возвращает сохранённый ответ.
+func (s *idempotencyService) Begin(ctx context.Context, key, fingerprint string) (*entitys.StoredResponse, error) {
+	if len(key) > maxIdempotencyKeyLength {
+		return nil, fmt.Errorf("%w: Idempotency-Key must be at most %d characters", common.ErrInvalidInput, maxIdempotencyKeyLength)
+	}
+
+	userID, ok := ctx.Value("userID").(uuid.UUID)
+	if !ok || userID == uuid.Nil {
+		return nil, common.ErrUnauthorized
+	}
+
+	// Вторая попытка нужна, если устаревший ключ удалили между Reserve и Get
+	for range 2 {
+		reserved, err := s.repo.Reserve(ctx, &models.IdempotencyKey{
+			UserID:      userID,
+			Key:         key,
+			Fingerprint: fingerprint,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if reserved {
+			return nil, nil
+		}
+
+		record, err := s.repo.Get(ctx, userID, key)
+		if errors.Is(err, common.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		expired := record.CreatedAt.Before(time.Now().Add(-s.retention))
+		abandoned := record.Status == 0 && record.CreatedAt.Before(time.Now().Add(-idempotencyPendingTimeout))
+
+		if expired || abandoned {
+			if err := s.repo.Delete(ctx, record.ID); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if record.Fingerprint != fingerprint {
+			return nil, fmt.Errorf("%w: Idempotency-Key is already used for another request", common.ErrInvalidInput)
+		}
+
+		if record.Status == 0 {
+			return nil, fmt.Errorf("%w: request with this Idempotency-Key is still in progress", common.ErrConflict)
+		}
+
+		return &entitys.StoredResponse{Status: record.Status, Body: record.Body}, nil
+	}
+
+	return nil, fmt.Errorf("%w: Idempotency-Key is busy, retry the request", common.ErrConflict)
+}
+
+// Complete сохраняет ответ на запрос, занявший ключ. Ключи трансляций
+// хранятся только маской, как в журнале аудита: повтор отдаёт их замаскированными.
+func (s *idempotencyService) Complete(ctx context.Context, key string, response entitys.StoredResponse) {
+	record, err := s.record(ctx, key)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("idempotency key %q: %v", key, err))
+		return
+	}
+
+	body, err := visibility.ApplyJSON(response.Body, visibility.Snapshot.Lecture)
+	if err != nil {
+		// Ответ, который не удалось очистить, не сохраняется: ключ освобождается
+		s.logger.Error(fmt.Sprintf("idempotency key %q: %v", key, err))
+		if err := s.repo.Delete(ctx, record.ID); err != nil {
+			s.logger.Error(fmt.Sprintf("idempotency key %q: %v", key, err))
+		}
+		return
+	}
+
+	if err := s.repo.Complete(ctx, record.ID, response.Status, body); err != nil {
+		s.logger.Error(fmt.Sprintf("idempotency key %q: %v", key, err))
+	}
+}
+
+// Release освобождает ключ, чтобы запрос можно было повторить.
+func (s *idempotencyService) Release(ctx context.Context, key string) {
+	record, err := s.record(ctx, key)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("idempotency key %q: %v", key, err))
+		return
+	}
+
+	if err := s.repo.Delete(ctx, record.ID); err != nil {
+		s.logger.Error(fmt.Sprintf("idempotency key %q: %v", key, err))
+	}
+}
+
+func (s *idempotencyService) record(ctx context.Context, key string) (*models.IdempotencyKey, error) {
+	userID, _ := ctx.Value("userID").(uuid.UUID)
+
+	return s.repo.Get(ctx, userID, key)
+}
+
+// AutoPurge периодически удаляет ключи старше срока хранения.
+func (s *idempotencyService) AutoPurge(timeout time.Duration) {
+	for {
+		purged, err := s.repo.DeleteBefore(context.Background(), time.Now().Add(-s.retention))
+		if err != nil {
+			s.logger.Error(fmt.Sprintf("idempotency keys purge failed: %v", err))
+		} else if purged > 0 {
+			s.logger.Info(fmt.Sprintf("idempotency keys purge: removed %d keys", purged))
+		}
+
+		time.Sleep(timeout)
+	}
+}
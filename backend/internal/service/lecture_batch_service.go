@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"table-api/internal/entitys"
+	"table-api/internal/handler/dto"
+	"table-api/internal/mappers"
+	"table-api/internal/models"
+	common "table-api/pkg"
+)
+
+// CreateManyPartial создаёт лекции пакета, которые прошли проверки, а по остальным
+// возвращает причины отказа. Строки с ошибками и пересечениями не мешают сохранить прочие.
+func (l *lectureService) CreateManyPartial(
+	ctx context.Context,
+	reqs []dto.CreateLectureRequest,
+	force bool,
+) ([]entitys.LectureBatchRow, error) {
+	rows := make([]entitys.LectureBatchRow, len(reqs))
+
+	var (
+		candidates []*models.Lecture
+		// Позиции кандидатов в rows
+		indexes []int
+	)
+
+	for i, req := range reqs {
+		rows[i] = entitys.LectureBatchRow{Index: i, Status: entitys.BatchRowFailed}
+
+		lecture, err := l.prepareBatchRow(ctx, req)
+		if isBatchRowError(err) {
+			rows[i].Errors = []string{err.Error()}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		candidates = append(candidates, lecture)
+		indexes = append(indexes, i)
+	}
+
+	err := l.ensureNoConflicts(ctx, candidates, force)
+
+	var conflictErr *LectureConflictError
+	switch {
+	case errors.As(err, &conflictErr):
+		conflicting := make(map[int]struct{}, len(conflictErr.Conflicts))
+
+		for _, conflict := range conflictErr.Conflicts {
+			// Индексы кандидатов переводятся в позиции запроса
+			index := indexes[*conflict.Index]
+			conflict.Index = &index
+
+			positions := make([]int, 0, len(conflict.ConflictingIndexes))
+			for _, i := range conflict.ConflictingIndexes {
+				positions = append(positions, indexes[i])
+			}
+			conflict.ConflictingIndexes = positions
+
+			rows[index].Conflicts = append(rows[index].Conflicts, conflict)
+			conflicting[index] = struct{}{}
+		}
+
+		var rest []*models.Lecture
+		var restIndexes []int
+		for i, lecture := range candidates {
+			if _, ok := conflicting[indexes[i]]; !ok {
+				rest = append(rest, lecture)
+				restIndexes = append(restIndexes, indexes[i])
+			}
+		}
+		candidates, indexes = rest, restIndexes
+	case err != nil:
+		return nil, err
+	}
+
+	if len(candidates) == 0 {
+		return rows, nil
+	}
+
+	warnings := make([][]string, len(candidates))
+	for i, lecture := range candidates {
+		warnings[i], err = l.rooms.Warnings(ctx, lecture.Location, lecture.Platform, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var created []*models.Lecture
+
+	err = l.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := l.resolveDirectories(ctx, true, candidates...); err != nil {
+			return err
+		}
+
+		created, err = l.lectureRepo.CreateMany(ctx, candidates)
+		if err != nil {
+			return err
+		}
+
+		return l.recordLectures(ctx, AuditActionCreate, nil, created)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, lecture := range created {
+		lecture.Warnings = warnings[i]
+		rows[indexes[i]].Status = entitys.BatchRowCreated
+		rows[indexes[i]].Lecture = lecture
+	}
+
+	return rows, nil
+}
+
+// prepareBatchRow проверяет одну лекцию пакета и собирает из неё кандидата на запись.
+func (l *lectureService) prepareBatchRow(ctx context.Context, req dto.CreateLectureRequest) (*models.Lecture, error) {
+	if message, err := dto.Validate(req); err != nil {
+		return nil, fmt.Errorf("%w: %s", common.ErrInvalidInput, message)
+	}
+
+	lecture, err := mappers.DtoToLecture(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateLectureTimes(lecture); err != nil {
+		return nil, err
+	}
+
+	if err := l.resolveDirectories(ctx, false, lecture); err != nil {
+		return nil, err
+	}
+
+	return lecture, nil
+}
+
+// isBatchRowError отличает ошибки данных строки от сбоев, из-за которых прерывается весь пакет.
+func isBatchRowError(err error) bool {
+	return errors.Is(err, common.ErrInvalidInput) || errors.Is(err, common.ErrNotFound)
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"table-api/internal/entitys"
+	"table-api/internal/handler/dto"
+	"table-api/internal/models"
+	common "table-api/pkg"
+	"time"
+)
+
+// CopyBlock копирует лекции за период источника в целевой период в одной транзакции.
+// Сдвиг округляется вверх до целого числа недель, чтобы лекции остались в свои дни недели.
+// Пересечения с лекциями целевого периода возвращаются в результате; без force они
+// запрещают запись. В режиме dryRun ничего не сохраняется.
+func (l *lectureService) CopyBlock(
+	ctx context.Context,
+	req dto.CopyLecturesRequest,
+	dryRun bool,
+	force bool,
+) (*entitys.LectureBlock, error) {
+	if req.SourceTo.Before(req.SourceFrom) {
+		return nil, fmt.Errorf("%w: sourceTo must not be before sourceFrom", common.ErrInvalidInput)
+	}
+
+	if req.TargetTo != nil && req.TargetTo.Before(req.TargetFrom) {
+		return nil, fmt.Errorf("%w: targetTo must not be before targetFrom", common.ErrInvalidInput)
+	}
+
+	offset := daysBetween(req.SourceFrom, req.TargetFrom)
+	if rem := ((offset % 7) + 7) % 7; rem != 0 {
+		offset += 7 - rem
+	}
+
+	if offset == 0 {
+		return nil, fmt.Errorf("%w: target range must differ from source range", common.ErrInvalidInput)
+	}
+
+	source, err := l.selectBlock(ctx, req.SourceFrom, req.SourceTo, req.Group)
+	if err != nil {
+		return nil, err
+	}
+
+	copies := make([]*models.Lecture, 0, len(source))
+	for _, lecture := range source {
+		c := copyLecture(lecture, offset, req)
+		if req.TargetTo != nil && c.Date.After(endOfDay(*req.TargetTo)) {
+			continue
+		}
+
+		copies = append(copies, c)
+	}
+
+	block, err := l.blockWithConflicts(ctx, offset, copies, dryRun, force)
+	if err != nil || dryRun {
+		return block, err
+	}
+
+	err = l.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		block.Lectures, err = l.lectureRepo.CreateMany(ctx, copies)
+		if err != nil {
+			return err
+		}
+
+		return l.recordLectures(ctx, AuditActionCreate, nil, block.Lectures)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
+
+// ShiftBlock переносит лекции за период на days дней в одной транзакции.
+func (l *lectureService) ShiftBlock(
+	ctx context.Context,
+	req dto.ShiftLecturesRequest,
+	dryRun bool,
+	force bool,
+) (*entitys.LectureBlock, error) {
+	if req.To.Before(req.From) {
+		return nil, fmt.Errorf("%w: to must not be before from", common.ErrInvalidInput)
+	}
+
+	selected, err := l.selectBlock(ctx, req.From, req.To, req.Group)
+	if err != nil {
+		return nil, err
+	}
+
+	shifted := make([]*models.Lecture, 0, len(selected))
+	for _, lecture := range selected {
+		s := *lecture
+		s.Date = lecture.Date.AddDate(0, 0, req.Days)
+		shifted = append(shifted, &s)
+	}
+
+	block, err := l.blockWithConflicts(ctx, req.Days, shifted, dryRun, force)
+	if err != nil || dryRun {
+		return block, err
+	}
+
+	ids := lectureIDs(selected)
+
+	err = l.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := l.lectureRepo.ShiftByIDs(ctx, ids, req.Days); err != nil {
+			return err
+		}
+
+		block.Lectures, err = l.lectureRepo.FindByIDs(ctx, ids)
+		if err != nil {
+			return err
+		}
+
+		return l.recordLectures(ctx, AuditActionUpdate, selected, block.Lectures)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
+
+// selectBlock выбирает лекции за период, при необходимости только одной группы.
+func (l *lectureService) selectBlock(ctx context.Context, from, to time.Time, group *string) ([]*models.Lecture, error) {
+	lectures, err := l.lectureRepo.FindByDateRange(ctx, startOfDay(from), endOfDay(to))
+	if err != nil {
+		return nil, err
+	}
+
+	if group != nil {
+		filtered := lectures[:0]
+		for _, lecture := range lectures {
+			if conflictKey(lecture.Group) == conflictKey(group) {
+				filtered = append(filtered, lecture)
+			}
+		}
+		lectures = filtered
+	}
+
+	if len(lectures) > maxBulkLectures {
+		return nil, fmt.Errorf(
+			"%w: range contains %d lectures, at most %d allowed",
+			common.ErrInvalidInput, len(lectures), maxBulkLectures,
+		)
+	}
+
+	return lectures, nil
+}
+
+// blockWithConflicts собирает предварительный результат и проверяет пересечения.
+func (l *lectureService) blockWithConflicts(
+	ctx context.Context,
+	offset int,
+	candidates []*models.Lecture,
+	dryRun bool,
+	force bool,
+) (*entitys.LectureBlock, error) {
+	conflicts, err := l.candidateConflicts(ctx, candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(conflicts) > 0 && !force && !dryRun {
+		return nil, &LectureConflictError{Conflicts: conflicts}
+	}
+
+	return &entitys.LectureBlock{
+		OffsetDays: offset,
+		Lectures:   candidates,
+		Conflicts:  conflicts,
+	}, nil
+}
+
+// copyLecture возвращает новую лекцию без привязки к серии, сдвинутую на offset дней.
+func copyLecture(lecture *models.Lecture, offset int, req dto.CopyLecturesRequest) *models.Lecture {
+	c := &models.Lecture{
+		Group:        lecture.Group,
+		Lector:       lecture.Lector,
+		Platform:     lecture.Platform,
+		Unit:         lecture.Unit,
+		Location:     lecture.Location,
+		GroupID:      lecture.GroupID,
+		LectorID:     lecture.LectorID,
+		UnitID:       lecture.UnitID,
+		LocationID:   lecture.LocationID,
+		Description:  lecture.Description,
+		Date:         lecture.Date.AddDate(0, 0, offset),
+		Start:        lecture.Start,
+		End:          lecture.End,
+		AbnormalTime: lecture.AbnormalTime,
+	}
+
+	if req.KeepURLs {
+		c.URL = lecture.URL
+		c.ShortURL = lecture.ShortURL
+	}
+
+	if req.KeepStreamKeys {
+		c.StreamKey = lecture.StreamKey
+	}
+
+	if req.KeepAdmins {
+		c.Admin = lecture.Admin
+		c.AdminID = lecture.AdminID
+	}
+
+	return c
+}
+
+// daysBetween возвращает число календарных дней от a до b.
+func daysBetween(a, b time.Time) int {
+	from := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	to := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+
+	return int(to.Sub(from).Hours() / 24)
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"table-api/internal/handler/dto"
+	"table-api/internal/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// blockLectureRepo отдаёт лекции источника и не находит пересечений.
+type blockLectureRepo struct {
+	LectureRepository
+	lectures []*models.Lecture
+}
+
+func (b *blockLectureRepo) FindByDateRange(ctx context.Context, start, end time.Time) ([]*models.Lecture, error) {
+	return b.lectures, nil
+}
+
+func (b *blockLectureRepo) FindOverlapCandidates(
+	ctx context.Context,
+	startDate, endDate time.Time,
+	groups, lectors, locations []string,
+) ([]*models.Lecture, error) {
+	return nil, nil
+}
+
+func TestCopyBlockAdmins(t *testing.T) {
+	admin := "Иванов"
+	adminID := uuid.New()
+	group := "ИВТ-21"
+	date := time.Date(2025, 10, 6, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		keepAdmins  bool
+		wantAdmin   *string
+		wantAdminID *uuid.UUID
+	}{
+		{name: "admins kept", keepAdmins: true, wantAdmin: &admin, wantAdminID: &adminID},
+		{name: "admins dropped", keepAdmins: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &lectureService{lectureRepo: &blockLectureRepo{lectures: []*models.Lecture{
+				{ID: 1, Group: &group, Date: date, Admin: &admin, AdminID: &adminID},
+			}}}
+
+			block, err := service.CopyBlock(context.Background(), dto.CopyLecturesRequest{
+				SourceFrom: date,
+				SourceTo:   date,
+				TargetFrom: date.AddDate(0, 0, 7),
+				KeepAdmins: tt.keepAdmins,
+			}, true, false)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(block.Lectures) != 1 {
+				t.Fatalf("copied %d lectures, want 1", len(block.Lectures))
+			}
+
+			c := block.Lectures[0]
+			if !equalPtr(c.Admin, tt.wantAdmin) {
+				t.Fatalf("Admin = %v, want %v", c.Admin, tt.wantAdmin)
+			}
+			if !equalPtr(c.AdminID, tt.wantAdminID) {
+				t.Fatalf("AdminID = %v, want %v", c.AdminID, tt.wantAdminID)
+			}
+		})
+	}
+}
+
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
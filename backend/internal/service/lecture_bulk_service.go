@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+	"table-api/internal/handler/dto"
+	"table-api/internal/mappers"
+	"table-api/internal/models"
+	common "table-api/pkg"
+)
+
+// Верхняя граница числа лекций в одной массовой операции
+const maxBulkLectures = 1000
+
+// BulkUpdate применяет частичное обновление ко всем выбранным лекциям в одной транзакции.
+// В режиме dryRun ничего не сохраняется, возвращается предпросмотр результата.
+func (l *lectureService) BulkUpdate(
+	ctx context.Context,
+	req dto.BulkUpdateLecturesRequest,
+	dryRun bool,
+	force bool,
+) ([]*models.Lecture, error) {
+	selected, err := l.selectBulk(ctx, req.IDs, req.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]*models.Lecture, 0, len(selected))
+	for _, lecture := range selected {
+		candidates = append(candidates, mappers.ApplyUpdateToLecture(lecture, req.Update))
+	}
+
+	if err := validateLectureTimes(candidates...); err != nil {
+		return nil, err
+	}
+
+	if err := l.resolveDirectories(ctx, false, candidates...); err != nil {
+		return nil, err
+	}
+
+	if !force && conflictFieldsChanged(req.Update) {
+		if err := l.ensureNoConflicts(ctx, candidates, false); err != nil {
+			return nil, err
+		}
+	}
+
+	if dryRun {
+		return candidates, nil
+	}
+
+	if err := l.shortenUpdateURL(ctx, &req.Update); err != nil {
+		return nil, err
+	}
+
+	ids := lectureIDs(selected)
+	var updated []*models.Lecture
+
+	err = l.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		directoryIDs, err := l.resolveUpdate(ctx, &req.Update)
+		if err != nil {
+			return err
+		}
+
+		updates := lectureUpdates(req.Update)
+		maps.Copy(updates, directoryIDs)
+
+		if _, err := l.lectureRepo.UpdateByIDs(ctx, ids, updates); err != nil {
+			return err
+		}
+
+		updated, err = l.lectureRepo.FindByIDs(ctx, ids)
+		if err != nil {
+			return err
+		}
+
+		return l.recordLectures(ctx, AuditActionUpdate, selected, updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// AssignAdmin назначает выбранным лекциям админа в одной транзакции.
+func (l *lectureService) AssignAdmin(ctx context.Context, ids []int, admin *models.User) ([]*models.Lecture, error) {
+	var updated []*models.Lecture
+
+	err := l.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		before, err := l.lectureRepo.FindByIDs(ctx, ids)
+		if err != nil {
+			return err
+		}
+
+		_, err = l.lectureRepo.UpdateByIDs(ctx, ids, map[string]interface{}{
+			"admin":    adminName(admin),
+			"admin_id": admin.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		updated, err = l.lectureRepo.FindByIDs(ctx, ids)
+		if err != nil {
+			return err
+		}
+
+		return l.recordLectures(ctx, AuditActionUpdate, before, updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// BulkRemove переносит выбранные лекции в корзину в одной транзакции.
+// В режиме dryRun возвращает лекции, которые были бы удалены.
+func (l *lectureService) BulkRemove(
+	ctx context.Context,
+	req dto.BulkDeleteLecturesRequest,
+	dryRun bool,
+) ([]*models.Lecture, error) {
+	selected, err := l.selectBulk(ctx, req.IDs, req.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if dryRun {
+		return selected, nil
+	}
+
+	err = l.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := l.lectureRepo.DeleteByIDs(ctx, lectureIDs(selected)); err != nil {
+			return err
+		}
+
+		return l.recordLectures(ctx, AuditActionDelete, selected, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return selected, nil
+}
+
+// selectBulk выбирает лекции либо по списку ID, либо по фильтру.
+func (l *lectureService) selectBulk(
+	ctx context.Context,
+	ids []int,
+	filter *dto.BulkLectureFilter,
+) ([]*models.Lecture, error) {
+	switch {
+	case len(ids) > 0 && filter != nil:
+		return nil, fmt.Errorf("%w: either ids or filter must be given, not both", common.ErrInvalidInput)
+	case len(ids) > 0:
+		return l.selectBulkByIDs(ctx, ids)
+	case filter != nil:
+		return l.selectBulkByFilter(ctx, *filter)
+	default:
+		return nil, fmt.Errorf("%w: ids or filter is required", common.ErrInvalidInput)
+	}
+}
+
+func (l *lectureService) selectBulkByIDs(ctx context.Context, ids []int) ([]*models.Lecture, error) {
+	lectures, err := l.lectureRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[int]struct{}, len(lectures))
+	for _, lecture := range lectures {
+		found[lecture.ID] = struct{}{}
+	}
+
+	var missing []string
+	for _, id := range ids {
+		if _, ok := found[id]; !ok {
+			missing = append(missing, strconv.Itoa(id))
+		}
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: lectures %s", common.ErrNotFound, strings.Join(missing, ", "))
+	}
+
+	return lectures, nil
+}
+
+func (l *lectureService) selectBulkByFilter(ctx context.Context, filter dto.BulkLectureFilter) ([]*models.Lecture, error) {
+	// Пустой фильтр выбрал бы всё расписание
+	if filter.Group == nil && filter.Lector == nil && filter.Location == nil && filter.Platform == nil &&
+		filter.DateFrom == nil && filter.DateTo == nil {
+		return nil, fmt.Errorf("%w: filter must not be empty", common.ErrInvalidInput)
+	}
+
+	if filter.DateFrom != nil && filter.DateTo != nil && filter.DateTo.Before(*filter.DateFrom) {
+		return nil, fmt.Errorf("%w: dateTo must not be before dateFrom", common.ErrInvalidInput)
+	}
+
+	if filter.DateFrom != nil {
+		from := startOfDay(*filter.DateFrom)
+		filter.DateFrom = &from
+	}
+
+	if filter.DateTo != nil {
+		to := endOfDay(*filter.DateTo)
+		filter.DateTo = &to
+	}
+
+	lectures, err := l.lectureRepo.FindByBulkFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lectures) > maxBulkLectures {
+		return nil, fmt.Errorf(
+			"%w: filter matches %d lectures, at most %d allowed",
+			common.ErrInvalidInput, len(lectures), maxBulkLectures,
+		)
+	}
+
+	return lectures, nil
+}
+
+func lectureIDs(lectures []*models.Lecture) []int {
+	ids := make([]int, 0, len(lectures))
+	for _, lecture := range lectures {
+		ids = append(ids, lecture.ID)
+	}
+
+	return ids
+}
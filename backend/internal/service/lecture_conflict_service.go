@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"table-api/internal/entitys"
+	"table-api/internal/handler/dto"
+	"table-api/internal/models"
+	common "table-api/pkg"
+	"table-api/pkg/timezone"
+	"table-api/pkg/utils"
+	"time"
+)
+
+const (
+	ConflictByLector   = "lector"
+	ConflictByGroup    = "group"
+	ConflictByLocation = "location"
+)
+
+var conflictDimensions = []string{ConflictByLector, ConflictByGroup, ConflictByLocation}
+
+type LectureConflictError struct {
+	Conflicts []entitys.LectureConflict
+}
+
+func (e *LectureConflictError) Error() string {
+	return "lecture schedule conflict"
+}
+
+func (e *LectureConflictError) Unwrap() error {
+	return common.ErrConflict
+}
+
+func (e *LectureConflictError) Details() any {
+	return e.Conflicts
+}
+
+// FindConflicts возвращает пересечения среди уже сохранённых лекций за период.
+func (l *lectureService) FindConflicts(ctx context.Context, start, end time.Time) ([]entitys.LectureConflict, error) {
+	lectures, err := l.lectureRepo.FindByDateRange(ctx, startOfDay(start), endOfDay(end))
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts := findLectureConflicts(lectures, nil)
+	for i := range conflicts {
+		conflicts[i].Index = nil
+	}
+
+	return conflicts, nil
+}
+
+// ensureNoConflicts проверяет кандидатов на запись против сохранённых лекций
+// и друг против друга. Лекции с ненулевым ID считаются обновляемыми.
+func (l *lectureService) ensureNoConflicts(ctx context.Context, candidates []*models.Lecture, force bool) error {
+	if force {
+		return nil
+	}
+
+	conflicts, err := l.candidateConflicts(ctx, candidates)
+	if err != nil {
+		return err
+	}
+
+	if len(conflicts) > 0 {
+		return &LectureConflictError{Conflicts: conflicts}
+	}
+
+	return nil
+}
+
+// candidateConflicts возвращает пересечения кандидатов с сохранёнными лекциями и друг с другом.
+func (l *lectureService) candidateConflicts(ctx context.Context, candidates []*models.Lecture) ([]entitys.LectureConflict, error) {
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	var (
+		groups, lectors, locations = map[string]struct{}{}, map[string]struct{}{}, map[string]struct{}{}
+		minDate, maxDate           time.Time
+	)
+
+	for i, c := range candidates {
+		if i == 0 || c.Date.Before(minDate) {
+			minDate = c.Date
+		}
+		if i == 0 || c.Date.After(maxDate) {
+			maxDate = c.Date
+		}
+
+		if v := conflictKey(c.Group); v != "" {
+			groups[v] = struct{}{}
+		}
+		if v := conflictKey(c.Lector); v != "" {
+			lectors[v] = struct{}{}
+		}
+		if v := conflictKey(c.Location); v != "" {
+			locations[v] = struct{}{}
+		}
+	}
+
+	existing, err := l.lectureRepo.FindOverlapCandidates(
+		ctx,
+		startOfDay(minDate),
+		endOfDay(maxDate),
+		setToSlice(groups),
+		setToSlice(lectors),
+		setToSlice(locations),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return findLectureConflicts(candidates, existing), nil
+}
+
+func findLectureConflicts(candidates, existing []*models.Lecture) []entitys.LectureConflict {
+	candidateIDs := make(map[int]struct{}, len(candidates))
+	for _, c := range candidates {
+		if c.ID != 0 {
+			candidateIDs[c.ID] = struct{}{}
+		}
+	}
+
+	var conflicts []entitys.LectureConflict
+
+	for i, c := range candidates {
+		for _, dimension := range conflictDimensions {
+			value := conflictKey(lectureDimension(c, dimension))
+			if value == "" {
+				continue
+			}
+
+			conflict := entitys.LectureConflict{
+				Date:      c.Date.Format("2006-01-02"),
+				Dimension: dimension,
+				Value:     strings.TrimSpace(*lectureDimension(c, dimension)),
+			}
+
+			for _, e := range existing {
+				if _, updated := candidateIDs[e.ID]; updated {
+					continue
+				}
+
+				if conflictKey(lectureDimension(e, dimension)) == value && lecturesOverlap(c, e) {
+					conflict.ConflictingIDs = append(conflict.ConflictingIDs, e.ID)
+				}
+			}
+
+			for j, o := range candidates {
+				if i == j || conflictKey(lectureDimension(o, dimension)) != value || !lecturesOverlap(c, o) {
+					continue
+				}
+
+				if o.ID != 0 {
+					conflict.ConflictingIDs = append(conflict.ConflictingIDs, o.ID)
+				} else {
+					conflict.ConflictingIndexes = append(conflict.ConflictingIndexes, j)
+				}
+			}
+
+			if len(conflict.ConflictingIDs) == 0 && len(conflict.ConflictingIndexes) == 0 {
+				continue
+			}
+
+			index := i
+			conflict.Index = &index
+			if c.ID != 0 {
+				id := c.ID
+				conflict.LectureID = &id
+			}
+
+			conflicts = append(conflicts, conflict)
+		}
+	}
+
+	return conflicts
+}
+
+// conflictFieldsChanged сообщает, затрагивает ли обновление поля, влияющие на пересечения.
+func conflictFieldsChanged(dto dto.UpdateLectureRequest) bool {
+	return dto.Date != nil ||
+		dto.Start != nil ||
+		dto.End != nil ||
+		dto.Group != nil ||
+		dto.Lector != nil ||
+		dto.Location != nil
+}
+
+func lecturesOverlap(a, b *models.Lecture) bool {
+	if a.Date.Format("2006-01-02") != b.Date.Format("2006-01-02") {
+		return false
+	}
+
+	aStart, aEnd, ok := lectureInterval(a)
+	if !ok {
+		return false
+	}
+
+	bStart, bEnd, ok := lectureInterval(b)
+	if !ok {
+		return false
+	}
+
+	return aStart < bEnd && bStart < aEnd
+}
+
+func lectureInterval(lecture *models.Lecture) (utils.Clock, utils.Clock, bool) {
+	if lecture.Start == nil || lecture.End == nil || *lecture.End <= *lecture.Start {
+		return 0, 0, false
+	}
+
+	return *lecture.Start, *lecture.End, true
+}
+
+func lectureDimension(lecture *models.Lecture, dimension string) *string {
+	switch dimension {
+	case ConflictByLector:
+		return lecture.Lector
+	case ConflictByGroup:
+		return lecture.Group
+	case ConflictByLocation:
+		return lecture.Location
+	}
+
+	return nil
+}
+
+func conflictKey(value *string) string {
+	if value == nil {
+		return ""
+	}
+
+	return strings.ToLower(strings.TrimSpace(*value))
+}
+
+func setToSlice(set map[string]struct{}) []string {
+	result := make([]string, 0, len(set))
+	for v := range set {
+		result = append(result, v)
+	}
+
+	return result
+}
+
+// startOfDay возвращает полночь календарного дня t в поясе организации.
+func startOfDay(t time.Time) time.Time {
+	return timezone.Day(t)
+}
+
+func endOfDay(t time.Time) time.Time {
+	return startOfDay(t).AddDate(0, 0, 1).Add(-time.Second)
+}
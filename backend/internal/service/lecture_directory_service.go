@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"table-api/internal/handler/dto"
+	"table-api/internal/models"
+	common "table-api/pkg"
+	"table-api/pkg/utils"
+)
+
+// Поля лекции, которые ссылаются на справочники
+type lectureDirectoryField struct {
+	kind     string
+	idColumn string
+	name     func(*models.Lecture) **string
+	id       func(*models.Lecture) **int
+	update   func(*dto.UpdateLectureRequest) **string
+}
+
+var lectureDirectoryFields = []lectureDirectoryField{
+	{
+		kind:     models.DirectoryGroup,
+		idColumn: "group_id",
+		name:     func(l *models.Lecture) **string { return &l.Group },
+		id:       func(l *models.Lecture) **int { return &l.GroupID },
+		update:   func(r *dto.UpdateLectureRequest) **string { return &r.Group },
+	},
+	{
+		kind:     models.DirectoryLector,
+		idColumn: "lector_id",
+		name:     func(l *models.Lecture) **string { return &l.Lector },
+		id:       func(l *models.Lecture) **int { return &l.LectorID },
+		update:   func(r *dto.UpdateLectureRequest) **string { return &r.Lector },
+	},
+	{
+		kind:     models.DirectoryUnit,
+		idColumn: "unit_id",
+		name:     func(l *models.Lecture) **string { return &l.Unit },
+		id:       func(l *models.Lecture) **int { return &l.UnitID },
+		update:   func(r *dto.UpdateLectureRequest) **string { return &r.Unit },
+	},
+	{
+		kind:     models.DirectoryLocation,
+		idColumn: "location_id",
+		name:     func(l *models.Lecture) **string { return &l.Location },
+		id:       func(l *models.Lecture) **int { return &l.LocationID },
+		update:   func(r *dto.UpdateLectureRequest) **string { return &r.Location },
+	},
+}
+
+// resolveDirectories связывает лекции с записями справочников и приводит названия к каноническим.
+// Без create неизвестные названия остаются как есть: так проверяются кандидаты до записи.
+func (l *lectureService) resolveDirectories(ctx context.Context, create bool, lectures ...*models.Lecture) error {
+	cache := map[string]*models.DirectoryEntry{}
+	admins := map[string]*models.User{}
+
+	for _, lecture := range lectures {
+		for _, f := range lectureDirectoryFields {
+			name, id := f.name(lecture), f.id(lecture)
+
+			if *name == nil || utils.NameKey(**name) == "" {
+				*id = nil
+				continue
+			}
+
+			cacheKey := f.kind + "\x00" + utils.NameKey(**name)
+
+			entry, ok := cache[cacheKey]
+			if !ok {
+				var err error
+
+				if create {
+					entry, err = l.directories.Resolve(ctx, f.kind, **name)
+				} else {
+					entry, err = l.directories.Lookup(ctx, f.kind, **name)
+					if errors.Is(err, common.ErrNotFound) {
+						entry, err = nil, nil
+					}
+				}
+				if err != nil {
+					return err
+				}
+
+				cache[cacheKey] = entry
+			}
+
+			if entry == nil {
+				*id = nil
+				continue
+			}
+
+			canonical, entryID := entry.Name, entry.ID
+			*name, *id = &canonical, &entryID
+		}
+
+		if err := l.resolveAdmin(ctx, create, lecture, admins); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolveAdmin связывает лекцию с пользователем-админом и записывает его имя.
+// Без create неизвестное имя остаётся как есть, с create — это ошибка ввода.
+func (l *lectureService) resolveAdmin(
+	ctx context.Context,
+	create bool,
+	lecture *models.Lecture,
+	cache map[string]*models.User,
+) error {
+	if lecture.Admin == nil || utils.NameKey(*lecture.Admin) == "" {
+		lecture.Admin, lecture.AdminID = nil, nil
+		return nil
+	}
+
+	key := utils.NameKey(*lecture.Admin)
+
+	user, ok := cache[key]
+	if !ok {
+		var err error
+
+		if create {
+			user, err = findAdmin(ctx, l.admins, *lecture.Admin)
+		} else {
+			user, err = l.admins.Admin(ctx, *lecture.Admin)
+			if errors.Is(err, common.ErrNotFound) {
+				user, err = nil, nil
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		cache[key] = user
+	}
+
+	if user == nil {
+		lecture.AdminID = nil
+		return nil
+	}
+
+	name := adminName(user)
+	lecture.Admin, lecture.AdminID = &name, &user.ID
+
+	return nil
+}
+
+// resolveUpdate приводит названия в обновлении к каноническим, создавая недостающие записи,
+// и возвращает значения колонок ссылок на справочники.
+func (l *lectureService) resolveUpdate(ctx context.Context, req *dto.UpdateLectureRequest) (map[string]interface{}, error) {
+	ids := map[string]interface{}{}
+
+	for _, f := range lectureDirectoryFields {
+		name := f.update(req)
+		if *name == nil {
+			continue
+		}
+
+		if utils.NameKey(**name) == "" {
+			ids[f.idColumn] = nil
+			continue
+		}
+
+		entry, err := l.directories.Resolve(ctx, f.kind, **name)
+		if err != nil {
+			return nil, err
+		}
+
+		canonical := entry.Name
+		*name = &canonical
+		ids[f.idColumn] = entry.ID
+	}
+
+	if req.Admin != nil {
+		if utils.NameKey(*req.Admin) == "" {
+			ids["admin_id"] = nil
+			return ids, nil
+		}
+
+		user, err := findAdmin(ctx, l.admins, *req.Admin)
+		if err != nil {
+			return nil, err
+		}
+
+		name := adminName(user)
+		req.Admin = &name
+		ids["admin_id"] = user.ID
+	}
+
+	return ids, nil
+}
+
+// lookupDirectory возвращает ID записи справочника по названию или nil, если такой нет.
+func (l *lectureService) lookupDirectory(ctx context.Context, kind, name string) (*int, error) {
+	entry, err := l.directories.Lookup(ctx, kind, name)
+	if errors.Is(err, common.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &entry.ID, nil
+}
@@ -0,0 +1,566 @@
+package service
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"table-api/internal/handler/dto"
+	"table-api/internal/models"
+	"table-api/pkg/utils"
+	"time"
+	"unicode/utf8"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// exportField — поле лекции, которое шаблон может вывести колонкой.
+// Width — ширина колонки в символах: потоковая запись задаёт её до первой строки.
+type exportField struct {
+	Header string
+	Width  int
+	Value  func(row *dto.LectureResponse, layouts exportLayouts) any
+}
+
+// exportLayouts — раскладки time.Format для даты и времени суток.
+type exportLayouts struct {
+	Date string
+	Time string
+}
+
+// exportColumn — колонка выгрузки с подписью и значением для строки.
+type exportColumn struct {
+	Header string
+	Width  int
+	Value  func(row *dto.LectureResponse) any
+}
+
+// Форматы даты и времени, доступные шаблонам
+var (
+	exportDateLayouts = map[string]string{
+		"YYYY-MM-DD": "2006-01-02",
+		"DD.MM.YYYY": "02.01.2006",
+		"DD/MM/YYYY": "02/01/2006",
+		"MM/DD/YYYY": "01/02/2006",
+	}
+	exportTimeLayouts = map[string]string{
+		"HH:mm":    "15:04",
+		"HH:mm:ss": "15:04:05",
+		"HH.mm":    "15.04",
+	}
+)
+
+// Поля шаблона по умолчанию; импорт ожидает ту же раскладку и заголовки
+var defaultExportFields = []string{
+	"id", "date", "start", "end", "group", "lector",
+	"platform", "unit", "location", "shortUrl", "streamKey",
+	"description", "admin",
+}
+
+// Поля выгрузки называются так же, как в JSON-ответе лекции
+var exportFields = map[string]exportField{
+	"id": {Header: "ID", Width: 8, Value: func(row *dto.LectureResponse, _ exportLayouts) any {
+		return row.ID
+	}},
+	"date": {Header: "Дата", Width: 12, Value: func(row *dto.LectureResponse, layouts exportLayouts) any {
+		return row.Date.Format(layouts.Date)
+	}},
+	"start":        clockField("Начало", 8, func(row *dto.LectureResponse) *utils.Clock { return row.Start }),
+	"end":          clockField("Конец", 8, func(row *dto.LectureResponse) *utils.Clock { return row.End }),
+	"group":        textField("Группа", 20, func(row *dto.LectureResponse) *string { return row.Group }),
+	"lector":       textField("Лектор", 30, func(row *dto.LectureResponse) *string { return row.Lector }),
+	"platform":     textField("Платформа", 15, func(row *dto.LectureResponse) *string { return row.Platform }),
+	"unit":         textField("Корпус", 15, func(row *dto.LectureResponse) *string { return row.Unit }),
+	"location":     textField("Место", 25, func(row *dto.LectureResponse) *string { return row.Location }),
+	"url":          textField("Исходная ссылка", 40, func(row *dto.LectureResponse) *string { return row.URL }),
+	"shortUrl":     textField("Ссылка", 12, func(row *dto.LectureResponse) *string { return row.ShortURL }),
+	"streamKey":    textField("Ключ потока", 22, func(row *dto.LectureResponse) *string { return row.StreamKey }),
+	"description":  textField("Описание", 40, func(row *dto.LectureResponse) *string { return row.Description }),
+	"admin":        textField("Админ", 20, func(row *dto.LectureResponse) *string { return row.Admin }),
+	"abnormalTime": textField("Особое время", 20, func(row *dto.LectureResponse) *string { return row.AbnormalTime }),
+}
+
+func textField(header string, width int, value func(row *dto.LectureResponse) *string) exportField {
+	return exportField{Header: header, Width: width, Value: func(row *dto.LectureResponse, _ exportLayouts) any {
+		if v := value(row); v != nil {
+			return *v
+		}
+		return nil
+	}}
+}
+
+func clockField(header string, width int, value func(row *dto.LectureResponse) *utils.Clock) exportField {
+	return exportField{Header: header, Width: width, Value: func(row *dto.LectureResponse, layouts exportLayouts) any {
+		if v := value(row); v != nil {
+			return v.On(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)).Format(layouts.Time)
+		}
+		return nil
+	}}
+}
+
+// exportColumns собирает колонки выгрузки по шаблону.
+// Пустая подпись колонки заменяется заголовком поля.
+func exportColumns(template *models.ExportTemplate) []exportColumn {
+	layouts := exportLayouts{
+		Date: exportDateLayouts[template.DateFormat],
+		Time: exportTimeLayouts[template.TimeFormat],
+	}
+
+	columns := make([]exportColumn, 0, len(template.Columns))
+
+	for _, column := range template.Columns {
+		field, ok := exportFields[column.Field]
+		if !ok {
+			continue
+		}
+
+		header := column.Header
+		if header == "" {
+			header = field.Header
+		}
+
+		columns = append(columns, exportColumn{
+			Header: header,
+			Width:  max(field.Width, utf8.RuneCountInString(header)) + 2,
+			Value: func(row *dto.LectureResponse) any {
+				return field.Value(row, layouts)
+			},
+		})
+	}
+
+	return columns
+}
+
+// lectureCSVWriter пишет выгрузку в CSV построчно.
+type lectureCSVWriter struct {
+	w       *csv.Writer
+	columns []exportColumn
+	record  []string
+}
+
+// newLectureCSVWriter пишет заголовок. BOM в начале нужен Excel,
+// чтобы кириллица открывалась в UTF-8. BOM и заголовок остаются в буфере,
+// поэтому ошибка первого чтения строк ещё успевает уйти JSON-ответом.
+func newLectureCSVWriter(writer io.Writer, columns []exportColumn) (*lectureCSVWriter, error) {
+	buffered := bufio.NewWriter(writer)
+	if _, err := buffered.WriteString("\uFEFF"); err != nil {
+		return nil, err
+	}
+
+	c := &lectureCSVWriter{
+		// csv.Writer пишет в тот же буфер и сбрасывает его в Flush
+		w:       csv.NewWriter(buffered),
+		columns: columns,
+		record:  make([]string, len(columns)),
+	}
+
+	for i, column := range columns {
+		c.record[i] = column.Header
+	}
+
+	if err := c.w.Write(c.record); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *lectureCSVWriter) Write(row *dto.LectureResponse) error {
+	for i, column := range c.columns {
+		c.record[i] = ""
+		if value := column.Value(row); value != nil {
+			c.record[i] = fmt.Sprint(value)
+		}
+	}
+
+	return c.w.Write(c.record)
+}
+
+func (c *lectureCSVWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// lectureSheetWriter пишет лист лекций через StreamWriter: строки сразу уходят
+// во временный буфер excelize и не держатся в памяти.
+type lectureSheetWriter struct {
+	stream    *excelize.StreamWriter
+	columns   []exportColumn
+	dataStyle int
+	row       int
+}
+
+// newLectureSheetWriter создаёт лист с шириной колонок и строкой заголовков.
+func newLectureSheetWriter(f *excelize.File, sheet string, columns []exportColumn) (*lectureSheetWriter, error) {
+	if _, err := f.NewSheet(sheet); err != nil {
+		return nil, err
+	}
+
+	stream, err := f.NewStreamWriter(sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, column := range columns {
+		if err := stream.SetColWidth(i+1, i+1, float64(column.Width)); err != nil {
+			return nil, err
+		}
+	}
+
+	headerStyle := exportHeaderStyle(f)
+
+	header := make([]any, len(columns))
+	for i, column := range columns {
+		header[i] = excelize.Cell{StyleID: headerStyle, Value: column.Header}
+	}
+
+	if err := stream.SetRow("A1", header); err != nil {
+		return nil, err
+	}
+
+	return &lectureSheetWriter{
+		stream:    stream,
+		columns:   columns,
+		dataStyle: exportDataStyle(f),
+		row:       1,
+	}, nil
+}
+
+func (s *lectureSheetWriter) Write(row *dto.LectureResponse) error {
+	s.row++
+
+	cells := make([]any, len(s.columns))
+	for i, column := range s.columns {
+		cells[i] = excelize.Cell{StyleID: s.dataStyle, Value: column.Value(row)}
+	}
+
+	cell, _ := excelize.CoordinatesToCellName(1, s.row)
+
+	return s.stream.SetRow(cell, cells)
+}
+
+func (s *lectureSheetWriter) Flush() error {
+	return s.stream.Flush()
+}
+
+// Лист со сводкой аналитической выгрузки
+const lectureSummarySheet = "Сводка"
+
+// Подпись для лекций без группы, лектора или платформы
+const lectureNotSpecified = "Не указано"
+
+// Высота блока сводки в строках: под таблицей помещается диаграмма
+const summaryBlockRows = 18
+
+// Excel ограничивает имя листа 31 символом и запрещает часть знаков
+var sheetNameReplacer = strings.NewReplacer(
+	"[", "_", "]", "_", ":", "_", "*", "_", "?", "_", "/", "_", "\\", "_",
+)
+
+// lectureTotal — число лекций и минут по одному значению измерения.
+type lectureTotal struct {
+	Name    string
+	Count   int
+	Minutes int
+}
+
+// summaryDimension — измерение сводки и способ достать его из строки выгрузки.
+type summaryDimension struct {
+	Title string
+	Key   func(row *dto.LectureResponse) *string
+}
+
+var lectureSummaryDimensions = []summaryDimension{
+	{Title: "Лектор", Key: func(row *dto.LectureResponse) *string { return row.Lector }},
+	{Title: "Группа", Key: func(row *dto.LectureResponse) *string { return row.Group }},
+	{Title: "Платформа", Key: func(row *dto.LectureResponse) *string { return row.Platform }},
+}
+
+// lectureWorkbook собирает книгу выгрузки по мере чтения лекций.
+// Плоская раскладка — один лист Lectures. Аналитическая — лист сводки с диаграммами
+// и по листу на день или группу; лекции приходят упорядоченными по ключу листа,
+// поэтому в каждый момент открыт только один StreamWriter.
+// excelize держит в памяти до 16 МБ данных каждого листа и только сверх этого
+// сбрасывает их во временный файл, так что аналитическая книга с сотнями мелких
+// листов занимает больше памяти, чем плоская.
+type lectureWorkbook struct {
+	f       *excelize.File
+	columns []exportColumn
+	profile dto.ExportProfile
+	summary *lectureSummary
+
+	sheet *lectureSheetWriter
+	part  string
+	used  map[string]bool
+}
+
+func newLectureWorkbook(f *excelize.File, columns []exportColumn, profile dto.ExportProfile) (*lectureWorkbook, error) {
+	b := &lectureWorkbook{
+		f:       f,
+		columns: columns,
+		profile: profile,
+	}
+
+	if profile == dto.ExportProfileFlat {
+		sheet, err := newLectureSheetWriter(f, lectureExportSheet, columns)
+		if err != nil {
+			return nil, err
+		}
+
+		index, _ := f.GetSheetIndex(lectureExportSheet)
+		f.SetActiveSheet(index)
+
+		b.sheet = sheet
+		return b, nil
+	}
+
+	// Первый лист новой книги становится сводкой, чтобы не оставлять пустой Sheet1
+	if err := f.SetSheetName(f.GetSheetName(0), lectureSummarySheet); err != nil {
+		return nil, err
+	}
+
+	b.summary = newLectureSummary()
+	b.used = map[string]bool{strings.ToLower(lectureSummarySheet): true}
+
+	return b, nil
+}
+
+func (b *lectureWorkbook) Write(row *dto.LectureResponse) error {
+	if b.summary == nil {
+		return b.sheet.Write(row)
+	}
+
+	b.summary.Add(row)
+
+	part := row.Date.Format("2006-01-02")
+	if b.profile == dto.ExportProfileGroups {
+		part = valueOrNotSpecified(row.Group)
+	}
+
+	if b.sheet == nil || part != b.part {
+		if b.sheet != nil {
+			if err := b.sheet.Flush(); err != nil {
+				return err
+			}
+		}
+
+		sheet, err := newLectureSheetWriter(b.f, uniqueSheetName(part, b.used), b.columns)
+		if err != nil {
+			return err
+		}
+
+		b.sheet, b.part = sheet, part
+	}
+
+	return b.sheet.Write(row)
+}
+
+// Close дописывает открытый лист и, для аналитической раскладки, сводку.
+func (b *lectureWorkbook) Close() error {
+	if b.sheet != nil {
+		if err := b.sheet.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if b.summary == nil {
+		return nil
+	}
+
+	b.f.SetActiveSheet(0)
+
+	return writeLectureSummary(b.f, b.summary)
+}
+
+// lectureSummary копит итоги аналитической выгрузки. Память растёт
+// с числом разных лекторов, групп и платформ, а не с числом лекций.
+type lectureSummary struct {
+	Count      int
+	Minutes    int
+	Dimensions []*summaryTotals
+}
+
+// summaryTotals — итоги по одному измерению в порядке первого появления.
+type summaryTotals struct {
+	index  map[string]int
+	totals []lectureTotal
+}
+
+func newLectureSummary() *lectureSummary {
+	summary := &lectureSummary{}
+	for range lectureSummaryDimensions {
+		summary.Dimensions = append(summary.Dimensions, &summaryTotals{index: map[string]int{}})
+	}
+
+	return summary
+}
+
+func (s *lectureSummary) Add(row *dto.LectureResponse) {
+	minutes := lectureMinutes(row)
+
+	s.Count++
+	s.Minutes += minutes
+
+	for i, dimension := range lectureSummaryDimensions {
+		s.Dimensions[i].add(valueOrNotSpecified(dimension.Key(row)), minutes)
+	}
+}
+
+func (t *summaryTotals) add(name string, minutes int) {
+	j, ok := t.index[name]
+	if !ok {
+		j = len(t.totals)
+		t.index[name] = j
+		t.totals = append(t.totals, lectureTotal{Name: name})
+	}
+
+	t.totals[j].Count++
+	t.totals[j].Minutes += minutes
+}
+
+// sorted возвращает итоги: сначала больше часов, затем по имени.
+func (t *summaryTotals) sorted() []lectureTotal {
+	sort.Slice(t.totals, func(i, j int) bool {
+		if t.totals[i].Minutes != t.totals[j].Minutes {
+			return t.totals[i].Minutes > t.totals[j].Minutes
+		}
+		return t.totals[i].Name < t.totals[j].Name
+	})
+
+	return t.totals
+}
+
+// writeLectureSummary пишет общие итоги и по таблице с диаграммой
+// на каждое измерение: лектор, группа, платформа.
+func writeLectureSummary(f *excelize.File, summary *lectureSummary) error {
+	sheet := lectureSummarySheet
+	headerStyle := exportHeaderStyle(f)
+	dataStyle := exportDataStyle(f)
+
+	// writeRow пишет значения строки начиная с колонки A и задаёт им стиль
+	writeRow := func(row int, style int, values ...any) error {
+		first := "A" + strconv.Itoa(row)
+		last, err := excelize.CoordinatesToCellName(len(values), row)
+		if err != nil {
+			return err
+		}
+
+		if err := f.SetSheetRow(sheet, first, &values); err != nil {
+			return err
+		}
+
+		return f.SetCellStyle(sheet, first, last, style)
+	}
+
+	if err := writeRow(1, dataStyle, "Всего лекций", summary.Count); err != nil {
+		return err
+	}
+	if err := writeRow(2, dataStyle, "Всего часов", minutesToHours(summary.Minutes)); err != nil {
+		return err
+	}
+
+	start := 4
+
+	for i, dimension := range lectureSummaryDimensions {
+		totals := summary.Dimensions[i].sorted()
+
+		if err := writeRow(start, headerStyle, dimension.Title, "Лекций", "Часов"); err != nil {
+			return err
+		}
+
+		for i, total := range totals {
+			err := writeRow(start+i+1, dataStyle, total.Name, total.Count, minutesToHours(total.Minutes))
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(totals) > 0 {
+			if err := addSummaryChart(f, dimension.Title, start, start+len(totals)); err != nil {
+				return err
+			}
+		}
+
+		start += max(len(totals)+2, summaryBlockRows)
+	}
+
+	if err := f.SetColWidth(sheet, "A", "A", 30); err != nil {
+		return err
+	}
+
+	return f.SetColWidth(sheet, "B", "C", 12)
+}
+
+// addSummaryChart ставит справа от таблицы сводки столбчатую диаграмму
+// с числом лекций и часами. header — строка заголовка, last — последняя строка данных.
+func addSummaryChart(f *excelize.File, title string, header, last int) error {
+	ref := func(col string, from, to int) string {
+		return fmt.Sprintf("'%s'!$%s$%d:$%s$%d", lectureSummarySheet, col, from, col, to)
+	}
+
+	categories := ref("A", header+1, last)
+
+	return f.AddChart(lectureSummarySheet, "E"+strconv.Itoa(header), &excelize.Chart{
+		Type: excelize.Col,
+		Series: []excelize.ChartSeries{
+			{Name: ref("B", header, header), Categories: categories, Values: ref("B", header+1, last)},
+			{Name: ref("C", header, header), Categories: categories, Values: ref("C", header+1, last)},
+		},
+		Title:     []excelize.RichTextRun{{Text: title}},
+		Legend:    excelize.ChartLegend{Position: "bottom"},
+		Dimension: excelize.ChartDimension{Width: 640, Height: 320},
+	})
+}
+
+// lectureMinutes — длительность лекции; без начала или конца она не учитывается.
+func lectureMinutes(row *dto.LectureResponse) int {
+	if row.Start == nil || row.End == nil || *row.End <= *row.Start {
+		return 0
+	}
+
+	return int(*row.End - *row.Start)
+}
+
+func minutesToHours(minutes int) float64 {
+	return math.Round(float64(minutes)/60*100) / 100
+}
+
+func valueOrNotSpecified(value *string) string {
+	if value == nil || strings.TrimSpace(*value) == "" {
+		return lectureNotSpecified
+	}
+
+	return *value
+}
+
+// uniqueSheetName приводит имя к допустимому в Excel и добавляет номер,
+// если такое имя уже занято. Excel сравнивает имена листов без учёта регистра.
+func uniqueSheetName(name string, used map[string]bool) string {
+	base := strings.Trim(sheetNameReplacer.Replace(name), "'")
+	if base == "" {
+		base = lectureNotSpecified
+	}
+
+	candidate := truncateRunes(base, 31)
+
+	for n := 2; used[strings.ToLower(candidate)]; n++ {
+		suffix := fmt.Sprintf(" (%d)", n)
+		candidate = truncateRunes(base, 31-utf8.RuneCountInString(suffix)) + suffix
+	}
+
+	used[strings.ToLower(candidate)] = true
+
+	return candidate
+}
+
+func truncateRunes(s string, limit int) string {
+	if utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+
+	return string([]rune(s)[:limit])
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"runtime"
+	"table-api/internal/handler/dto"
+	"table-api/internal/models"
+	"table-api/internal/visibility"
+	"table-api/pkg/timezone"
+	"table-api/pkg/utils"
+	"testing"
+	"time"
+)
+
+// Размеры выгрузки бенчмарка: пик кучи должен оставаться одним и тем же
+var benchExportRows = []int{10_000, 100_000, 300_000}
+
+// streamLectureRepo отдаёт курсором заданное число лекций, как EachByDatesAndGroup
+// над БД, и замеряет пик занятой кучи во время прохода.
+type streamLectureRepo struct {
+	LectureRepository
+	rows     int
+	baseline uint64
+	peak     uint64
+}
+
+func (s *streamLectureRepo) EachByDatesAndGroup(
+	ctx context.Context,
+	startDate, endDate time.Time,
+	groupID *int,
+	byGroup bool,
+	fn func(lecture *models.Lecture) error,
+) error {
+	start := utils.NewClock(10, 0)
+	end := utils.NewClock(11, 30)
+
+	for i := range s.rows {
+		group := fmt.Sprintf("Группа %d", i%50)
+		lector := fmt.Sprintf("Лектор %d", i%200)
+		url := fmt.Sprintf("https://example.com/lecture/%d", i)
+
+		lecture := &models.Lecture{
+			ID:      i + 1,
+			Group:   &group,
+			Lector:  &lector,
+			URL:     &url,
+			Date:    startDate.AddDate(0, 0, i%30),
+			Start:   &start,
+			End:     &end,
+			Version: 1,
+		}
+
+		if err := fn(lecture); err != nil {
+			return err
+		}
+
+		if i%10_000 == 0 {
+			s.measure()
+		}
+	}
+
+	s.measure()
+
+	return nil
+}
+
+func (s *streamLectureRepo) measure() {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+
+	if stats.HeapInuse > s.baseline && stats.HeapInuse-s.baseline > s.peak {
+		s.peak = stats.HeapInuse - s.baseline
+	}
+}
+
+func BenchmarkLectureExport(b *testing.B) {
+	start, end := timezone.Month(2025, 10)
+
+	for _, format := range []dto.ExportFormat{dto.ExportFormatCSV, dto.ExportFormatXLSX} {
+		for _, rows := range benchExportRows {
+			b.Run(fmt.Sprintf("%s/rows=%d", format, rows), func(b *testing.B) {
+				repo := &streamLectureRepo{rows: rows}
+				service := &lectureService{
+					lectureRepo: repo,
+					templates:   NewExportTemplateService(nil),
+				}
+
+				filter := dto.ExportLecturesExcelRequest{
+					StartDate: start,
+					EndDate:   end,
+					Profile:   dto.ExportProfileFlat,
+					Format:    format,
+				}
+				view := visibility.DefaultPolicy().Default
+
+				b.ReportAllocs()
+
+				for b.Loop() {
+					runtime.GC()
+
+					var stats runtime.MemStats
+					runtime.ReadMemStats(&stats)
+					repo.baseline = stats.HeapInuse
+
+					if err := service.Export(context.Background(), filter, view, io.Discard); err != nil {
+						b.Fatal(err)
+					}
+				}
+
+				// Пик кучи не должен расти вместе с числом строк
+				b.ReportMetric(float64(repo.peak), "peak-heap-B")
+			})
+		}
+	}
+}
@@ -0,0 +1,479 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"table-api/internal/handler/dto"
+	"table-api/internal/mappers"
+	"table-api/internal/models"
+	common "table-api/pkg"
+	"table-api/pkg/utils"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	ImportRowValid    = "valid"
+	ImportRowInvalid  = "invalid"
+	ImportRowConflict = "conflict"
+	ImportRowCreated  = "created"
+	ImportRowUpdated  = "updated"
+)
+
+type LectureImportError struct {
+	Report *dto.ImportLecturesResponse
+}
+
+func (e *LectureImportError) Error() string {
+	return "lecture import contains invalid rows"
+}
+
+func (e *LectureImportError) Unwrap() error {
+	return common.ErrInvalidInput
+}
+
+func (e *LectureImportError) Details() any {
+	return e.Report
+}
+
+// importRow — разобранная строка книги. Строка с заполненной колонкой ID
+// обновляет существующую лекцию, без ID — создаёт новую.
+type importRow struct {
+	id      int
+	lecture dto.CreateLectureRequest
+	// код короткой ссылки из колонки "Ссылка"
+	code *string
+}
+
+// importCandidate — корректная строка, готовая к записи.
+type importCandidate struct {
+	entry  int
+	id     int
+	create dto.CreateLectureRequest
+	update dto.UpdateLectureRequest
+	// Колонки времени, которые строка очищает
+	clear []string
+}
+
+// Import разбирает книгу в формате выгрузки lectures.xlsx.
+// В режиме dryRun лекции не сохраняются, возвращается только отчёт по строкам.
+func (l *lectureService) Import(
+	ctx context.Context,
+	reader io.Reader,
+	dryRun bool,
+	force bool,
+) (*dto.ImportLecturesResponse, error) {
+	f, err := excelize.OpenReader(reader)
+	if err != nil {
+		return nil, fmt.Errorf("%w: file is not a valid xlsx workbook", common.ErrInvalidInput)
+	}
+	defer f.Close()
+
+	sheet := lectureExportSheet
+	if index, _ := f.GetSheetIndex(sheet); index == -1 {
+		sheet = f.GetSheetName(f.GetActiveSheetIndex())
+	}
+
+	rows, err := f.GetRows(sheet, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", common.ErrInvalidInput, err.Error())
+	}
+
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: workbook is empty", common.ErrInvalidInput)
+	}
+
+	columns := make(map[string]int, len(rows[0]))
+	for i, header := range rows[0] {
+		columns[normalizeHeader(header)] = i
+	}
+
+	if _, ok := columns[normalizeHeader("Дата")]; !ok {
+		return nil, fmt.Errorf("%w: missing column Дата", common.ErrInvalidInput)
+	}
+
+	report := &dto.ImportLecturesResponse{DryRun: dryRun}
+
+	var (
+		valid      []importCandidate
+		candidates []*models.Lecture
+		seen       = map[int]int{}
+	)
+
+	for i, row := range rows[1:] {
+		if isEmptyRow(row) {
+			continue
+		}
+
+		parsed, errs := l.parseImportRow(ctx, row, columns)
+		if len(errs) == 0 {
+			if message, err := dto.Validate(parsed.lecture); err != nil {
+				errs = append(errs, message)
+			}
+		}
+
+		entry := dto.ImportLectureRow{Row: i + 2, ID: parsed.id, Lecture: &parsed.lecture}
+		candidate := importCandidate{entry: len(report.Rows), id: parsed.id, create: parsed.lecture}
+
+		var lecture *models.Lecture
+
+		switch {
+		case len(errs) > 0:
+		case parsed.id != 0:
+			if row, ok := seen[parsed.id]; ok {
+				errs = append(errs, fmt.Sprintf("ID: lecture %d is already updated by row %d", parsed.id, row))
+				break
+			}
+			seen[parsed.id] = entry.Row
+
+			existing, err := l.lectureRepo.GetByID(ctx, parsed.id)
+			if errors.Is(err, common.ErrNotFound) {
+				errs = append(errs, fmt.Sprintf("ID: lecture %d not found", parsed.id))
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			candidate.update, candidate.clear = importUpdate(parsed, columns)
+			lecture = clearLectureTimes(mappers.ApplyUpdateToLecture(existing, candidate.update), candidate.clear)
+		default:
+			if lecture, err = mappers.DtoToLecture(parsed.lecture); err != nil {
+				return nil, err
+			}
+		}
+
+		if lecture != nil {
+			if err := validateLectureTimes(lecture); err != nil {
+				errs = append(errs, err.Error())
+			}
+		}
+
+		if len(errs) > 0 {
+			entry.Status = ImportRowInvalid
+			entry.Errors = errs
+			report.Invalid++
+		} else {
+			entry.Status = ImportRowValid
+			report.Valid++
+			valid = append(valid, candidate)
+			candidates = append(candidates, lecture)
+		}
+
+		report.Rows = append(report.Rows, entry)
+	}
+
+	report.Total = len(report.Rows)
+
+	if !force && len(candidates) > 0 {
+		if err := l.resolveDirectories(ctx, false, candidates...); err != nil {
+			return nil, err
+		}
+
+		err = l.ensureNoConflicts(ctx, candidates, false)
+
+		var conflictErr *LectureConflictError
+		switch {
+		case errors.As(err, &conflictErr):
+			markImportConflicts(report, valid, conflictErr)
+		case err != nil:
+			return nil, err
+		}
+	}
+
+	if dryRun {
+		return report, nil
+	}
+
+	if report.Invalid > 0 {
+		return nil, &LectureImportError{Report: report}
+	}
+
+	if len(valid) == 0 {
+		return nil, fmt.Errorf("%w: no lectures to import", common.ErrInvalidInput)
+	}
+
+	var creates []dto.CreateLectureRequest
+	for _, c := range valid {
+		if c.id == 0 {
+			creates = append(creates, c.create)
+		}
+	}
+
+	// Пересечения уже проверены для всей книги разом: построчная проверка
+	// отклонила бы, например, обмен временем между двумя лекциями
+	err = l.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, c := range valid {
+			if c.id == 0 {
+				continue
+			}
+
+			if _, err := l.update(ctx, c.id, nil, c.update, c.clear, true); err != nil {
+				return fmt.Errorf("row %d: %w", report.Rows[c.entry].Row, err)
+			}
+		}
+
+		if len(creates) == 0 {
+			return nil
+		}
+
+		_, err := l.CreateMany(ctx, creates, true)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range valid {
+		if c.id == 0 {
+			report.Rows[c.entry].Status = ImportRowCreated
+			report.Created++
+		} else {
+			report.Rows[c.entry].Status = ImportRowUpdated
+			report.Updated++
+		}
+	}
+
+	return report, nil
+}
+
+func (l *lectureService) parseImportRow(
+	ctx context.Context,
+	row []string,
+	columns map[string]int,
+) (importRow, []string) {
+	var (
+		parsed importRow
+		errs   []string
+	)
+
+	req := &parsed.lecture
+
+	cell := func(header string) string {
+		i, ok := columns[normalizeHeader(header)]
+		if !ok || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	optional := func(header string) *string {
+		if v := cell(header); v != "" {
+			return &v
+		}
+		return nil
+	}
+
+	if value := cell("ID"); value != "" {
+		id, err := strconv.Atoi(value)
+		if err != nil || id < 1 {
+			errs = append(errs, fmt.Sprintf("ID: invalid lecture id %q", value))
+		}
+		parsed.id = id
+	}
+
+	date, err := parseImportDate(cell("Дата"))
+	if err != nil {
+		errs = append(errs, "Дата: "+err.Error())
+	}
+	req.Date = date
+
+	if req.Start, err = parseImportClock(cell("Начало")); err != nil {
+		errs = append(errs, "Начало: "+err.Error())
+	}
+	if req.End, err = parseImportClock(cell("Конец")); err != nil {
+		errs = append(errs, "Конец: "+err.Error())
+	}
+	if req.Start != nil && req.End != nil && *req.End <= *req.Start {
+		errs = append(errs, "Конец: end must be after start")
+	}
+	req.Group = optional("Группа")
+	req.Lector = optional("Лектор")
+	req.Platform = optional("Платформа")
+	req.Unit = optional("Корпус")
+	req.Location = optional("Место")
+	req.StreamKey = optional("Ключ потока")
+	req.Description = optional("Описание")
+	req.Admin = optional("Админ")
+
+	if req.Admin != nil {
+		_, err := l.admins.Admin(ctx, *req.Admin)
+		if errors.Is(err, common.ErrNotFound) {
+			errs = append(errs, "Админ: unknown user "+*req.Admin)
+		} else if err != nil {
+			errs = append(errs, "Админ: "+err.Error())
+		}
+	}
+
+	// В выгрузке колонка "Ссылка" содержит код короткой ссылки
+	if link := cell("Ссылка"); link != "" {
+		if strings.HasPrefix(link, "http://") || strings.HasPrefix(link, "https://") {
+			req.URL = &link
+		} else if url, err := l.shortLinkService.Resolve(ctx, link); err == nil {
+			req.URL = url
+			parsed.code = &link
+		} else {
+			errs = append(errs, "Ссылка: unknown short link "+link)
+		}
+	}
+
+	return parsed, errs
+}
+
+// importUpdate переводит строку с ID в правку лекции. Пустая ячейка колонки,
+// которая есть в книге, очищает поле; колонки, которых нет, не меняются.
+// Очищаемые колонки времени возвращаются отдельно: в правке их не передать.
+// Пустой или замаскированный ключ потока оставляет сохранённый ключ.
+func importUpdate(row importRow, columns map[string]int) (dto.UpdateLectureRequest, []string) {
+	req := row.lecture
+
+	var clear []string
+	for _, c := range []struct {
+		header string
+		column string
+		value  *utils.Clock
+	}{
+		{"Начало", "start", req.Start},
+		{"Конец", "end", req.End},
+	} {
+		if _, ok := columns[normalizeHeader(c.header)]; ok && c.value == nil {
+			clear = append(clear, c.column)
+		}
+	}
+
+	text := func(header string, value *string) *string {
+		if _, ok := columns[normalizeHeader(header)]; !ok {
+			return nil
+		}
+		if value == nil {
+			empty := ""
+			return &empty
+		}
+		return value
+	}
+
+	update := dto.UpdateLectureRequest{
+		Date:        &req.Date,
+		Start:       req.Start,
+		End:         req.End,
+		Group:       text("Группа", req.Group),
+		Lector:      text("Лектор", req.Lector),
+		Platform:    text("Платформа", req.Platform),
+		Unit:        text("Корпус", req.Unit),
+		Location:    text("Место", req.Location),
+		StreamKey:   req.StreamKey,
+		Description: text("Описание", req.Description),
+		Admin:       text("Админ", req.Admin),
+	}
+
+	if _, ok := columns[normalizeHeader("Ссылка")]; ok {
+		// Код из выгрузки сохраняется как есть, новая короткая ссылка не создаётся
+		update.URL = text("Ссылка", req.URL)
+		if row.code != nil {
+			update.ShortURL = row.code
+		} else if req.URL == nil {
+			update.ShortURL = update.URL
+		}
+	}
+
+	return update, clear
+}
+
+// clearLectureTimes очищает у лекции колонки времени из clear.
+func clearLectureTimes(lecture *models.Lecture, clear []string) *models.Lecture {
+	for _, column := range clear {
+		switch column {
+		case "start":
+			lecture.Start = nil
+		case "end":
+			lecture.End = nil
+		}
+	}
+
+	return lecture
+}
+
+func markImportConflicts(report *dto.ImportLecturesResponse, valid []importCandidate, conflictErr *LectureConflictError) {
+	for _, conflict := range conflictErr.Conflicts {
+		entry := &report.Rows[valid[*conflict.Index].entry]
+
+		// Индексы кандидатов переводятся в номера строк книги
+		row := entry.Row
+		conflict.Index = &row
+
+		rows := make([]int, 0, len(conflict.ConflictingIndexes))
+		for _, i := range conflict.ConflictingIndexes {
+			rows = append(rows, report.Rows[valid[i].entry].Row)
+		}
+		conflict.ConflictingIndexes = rows
+
+		if entry.Status == ImportRowValid {
+			entry.Status = ImportRowConflict
+			report.Valid--
+			report.Invalid++
+		}
+
+		entry.Conflicts = append(entry.Conflicts, conflict)
+	}
+}
+
+func parseImportDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, errors.New("date is required")
+	}
+
+	for _, layout := range []string{"2006-01-02", "02.01.2006", time.RFC3339} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return startOfDay(t), nil
+		}
+	}
+
+	// Ячейка с форматом даты приходит как порядковый номер дня Excel
+	if serial, err := strconv.ParseFloat(value, 64); err == nil {
+		if t, err := excelize.ExcelDateToTime(serial, false); err == nil {
+			return startOfDay(t), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unrecognized date %q", value)
+}
+
+func parseImportClock(value string) (*utils.Clock, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	// Ячейка с форматом времени приходит как доля суток
+	if fraction, err := strconv.ParseFloat(value, 64); err == nil && fraction >= 0 && fraction < 1 {
+		clock := utils.Clock(int(math.Round(fraction*24*60)) % (24 * 60))
+		return &clock, nil
+	}
+
+	minutes, err := utils.ParseClock(value)
+	if err != nil {
+		return nil, fmt.Errorf("unrecognized time %q", value)
+	}
+
+	clock := utils.Clock(minutes)
+	return &clock, nil
+}
+
+func normalizeHeader(header string) string {
+	return strings.ToLower(strings.TrimSpace(header))
+}
+
+func isEmptyRow(row []string) bool {
+	for _, v := range row {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"slices"
+	"table-api/internal/handler/dto"
+	"table-api/pkg/utils"
+	"testing"
+	"time"
+)
+
+func TestImportUpdateClearsEmptyCells(t *testing.T) {
+	start := utils.NewClock(10, 0)
+
+	tests := []struct {
+		name      string
+		headers   []string
+		lecture   dto.CreateLectureRequest
+		wantClear []string
+		wantStart *utils.Clock
+		wantGroup *string
+	}{
+		{
+			name:      "empty times cleared like text",
+			headers:   []string{"Дата", "Начало", "Конец", "Группа"},
+			wantClear: []string{"start", "end"},
+			wantGroup: new(string),
+		},
+		{
+			name:      "only empty end cleared",
+			headers:   []string{"Дата", "Начало", "Конец"},
+			lecture:   dto.CreateLectureRequest{Start: &start},
+			wantClear: []string{"end"},
+			wantStart: &start,
+		},
+		{
+			name:    "missing columns kept",
+			headers: []string{"Дата"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns := map[string]int{}
+			for i, header := range tt.headers {
+				columns[normalizeHeader(header)] = i
+			}
+
+			tt.lecture.Date = time.Date(2025, 10, 6, 0, 0, 0, 0, time.UTC)
+
+			update, clear := importUpdate(importRow{id: 1, lecture: tt.lecture}, columns)
+
+			if !slices.Equal(clear, tt.wantClear) {
+				t.Fatalf("clear = %v, want %v", clear, tt.wantClear)
+			}
+			if !equalPtr(update.Start, tt.wantStart) {
+				t.Fatalf("Start = %v, want %v", update.Start, tt.wantStart)
+			}
+			if !equalPtr(update.Group, tt.wantGroup) {
+				t.Fatalf("Group = %v, want %v", update.Group, tt.wantGroup)
+			}
+		})
+	}
+}
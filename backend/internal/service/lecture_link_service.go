@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"table-api/internal/entitys"
+	"table-api/internal/handler/dto"
+	"table-api/internal/models"
+	common "table-api/pkg"
+	"table-api/pkg/timezone"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type LinkOperationRepository interface {
+	Create(ctx context.Context, operation *models.LinkOperation) (*models.LinkOperation, error)
+	GetByID(ctx context.Context, id int) (*models.LinkOperation, error)
+	MarkUndone(ctx context.Context, id int, at time.Time) error
+}
+
+// CreateManyLinks проставляет ссылку и новую короткую ссылку лекциям группы,
+// подходящим под фильтр запроса. Операция запоминается, чтобы её можно было отменить.
+// В режиме dryRun ничего не сохраняется, короткая ссылка не создаётся.
+func (l *lectureService) CreateManyLinks(
+	ctx context.Context,
+	req dto.UpdateManyLinksRequest,
+	dryRun bool,
+) (*entitys.LinkDiff, error) {
+	group, err := l.directories.Lookup(ctx, models.DirectoryGroup, req.GroupName)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := dto.BulkLectureFilter{Lector: req.Lector, Platform: req.Platform}
+
+	// Прошедшие лекции меняются, только если период задан явно
+	from := startOfDay(timezone.Now())
+	if req.DateFrom != nil {
+		from = startOfDay(*req.DateFrom)
+	}
+	filter.DateFrom = &from
+
+	if req.DateTo != nil {
+		to := endOfDay(*req.DateTo)
+		if to.Before(from) {
+			return nil, fmt.Errorf("%w: dateTo must not be before dateFrom", common.ErrInvalidInput)
+		}
+		filter.DateTo = &to
+	}
+
+	selected, err := l.lectureRepo.FindByGroupAndFilter(ctx, group.ID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(selected) > maxBulkLectures {
+		return nil, fmt.Errorf(
+			"%w: filter matches %d lectures, at most %d allowed",
+			common.ErrInvalidInput, len(selected), maxBulkLectures,
+		)
+	}
+
+	var changed []*models.Lecture
+	for _, lecture := range selected {
+		current := strings.TrimSpace(derefString(lecture.URL))
+
+		if current == req.Url || (req.SkipLinked && current != "") {
+			continue
+		}
+
+		changed = append(changed, lecture)
+	}
+
+	diff := &entitys.LinkDiff{Skipped: len(selected) - len(changed), Changes: []entitys.LinkDiffRow{}}
+
+	if dryRun || len(changed) == 0 {
+		for _, lecture := range changed {
+			diff.Changes = append(diff.Changes, linkDiffRow(lecture, entitys.LinkValues{URL: &req.Url}))
+		}
+
+		return diff, nil
+	}
+
+	shortURL, err := l.shortLinkService.ShortUrl(ctx, req.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := lectureIDs(changed)
+
+	err = l.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		_, err := l.lectureRepo.UpdateByIDs(ctx, ids, map[string]interface{}{
+			"url":       req.Url,
+			"short_url": *shortURL,
+		})
+		if err != nil {
+			return err
+		}
+
+		updated, err := l.lectureRepo.FindByIDs(ctx, ids)
+		if err != nil {
+			return err
+		}
+
+		operation := &models.LinkOperation{
+			GroupID:  group.ID,
+			Group:    group.Name,
+			URL:      req.Url,
+			ShortURL: *shortURL,
+			Changes:  make([]models.LinkChange, 0, len(changed)),
+		}
+
+		if userID, ok := ctx.Value("userID").(uuid.UUID); ok && userID != uuid.Nil {
+			operation.UserID = &userID
+		}
+
+		for _, lecture := range changed {
+			operation.Changes = append(operation.Changes, models.LinkChange{
+				LectureID: lecture.ID,
+				URL:       lecture.URL,
+				ShortURL:  lecture.ShortURL,
+			})
+		}
+
+		if _, err := l.linkRepo.Create(ctx, operation); err != nil {
+			return err
+		}
+
+		diff.OperationID = operation.ID
+
+		for _, lecture := range changed {
+			diff.Changes = append(diff.Changes, linkDiffRow(lecture, entitys.LinkValues{URL: &req.Url, ShortURL: shortURL}))
+		}
+
+		return l.recordLectures(ctx, AuditActionUpdate, changed, updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return diff, nil
+}
+
+// UndoLinks отменяет массовую простановку ссылки operationID — ту, ответ на которую
+// видел вызывающий, а не последнюю по времени: иначе отмена одного модератора
+// могла бы откатить чужую операцию. Лекции, ссылки которых с тех пор поменяли
+// или которые удалены, не трогаются.
+func (l *lectureService) UndoLinks(ctx context.Context, operationID int) (*entitys.LinkDiff, error) {
+	var diff *entitys.LinkDiff
+
+	err := l.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		operation, err := l.linkRepo.GetByID(ctx, operationID)
+		if errors.Is(err, common.ErrNotFound) {
+			return fmt.Errorf("%w: link operation %d not found", common.ErrNotFound, operationID)
+		}
+		if err != nil {
+			return err
+		}
+
+		if operation.UndoneAt != nil {
+			return fmt.Errorf("%w: link operation %d is already undone", common.ErrConflict, operationID)
+		}
+
+		ids := make([]int, 0, len(operation.Changes))
+		for _, change := range operation.Changes {
+			ids = append(ids, change.LectureID)
+		}
+
+		lectures, err := l.lectureRepo.FindByIDs(ctx, ids)
+		if err != nil {
+			return err
+		}
+
+		current := make(map[int]*models.Lecture, len(lectures))
+		for _, lecture := range lectures {
+			current[lecture.ID] = lecture
+		}
+
+		diff = &entitys.LinkDiff{OperationID: operation.ID, Changes: []entitys.LinkDiffRow{}}
+
+		var before []*models.Lecture
+		for _, change := range operation.Changes {
+			lecture, ok := current[change.LectureID]
+			if !ok || derefString(lecture.URL) != operation.URL || derefString(lecture.ShortURL) != operation.ShortURL {
+				diff.Skipped++
+				continue
+			}
+
+			_, err := l.lectureRepo.UpdateByIDs(ctx, []int{lecture.ID}, map[string]interface{}{
+				"url":       change.URL,
+				"short_url": change.ShortURL,
+			})
+			if err != nil {
+				return err
+			}
+
+			before = append(before, lecture)
+			diff.Changes = append(diff.Changes, linkDiffRow(lecture, entitys.LinkValues{URL: change.URL, ShortURL: change.ShortURL}))
+		}
+
+		if err := l.linkRepo.MarkUndone(ctx, operation.ID, time.Now()); err != nil {
+			return err
+		}
+
+		if len(before) == 0 {
+			return nil
+		}
+
+		restored, err := l.lectureRepo.FindByIDs(ctx, lectureIDs(before))
+		if err != nil {
+			return err
+		}
+
+		return l.recordLectures(ctx, AuditActionUpdate, before, restored)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return diff, nil
+}
+
+func linkDiffRow(lecture *models.Lecture, after entitys.LinkValues) entitys.LinkDiffRow {
+	return entitys.LinkDiffRow{
+		LectureID: lecture.ID,
+		Date:      lecture.Date,
+		Group:     lecture.Group,
+		Lector:    lecture.Lector,
+		Platform:  lecture.Platform,
+		Before:    entitys.LinkValues{URL: lecture.URL, ShortURL: lecture.ShortURL},
+		After:     after,
+	}
+}
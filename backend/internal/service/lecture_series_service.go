@@ -0,0 +1,420 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"table-api/internal/handler/dto"
+	"table-api/internal/mappers"
+	"table-api/internal/models"
+	common "table-api/pkg"
+	"table-api/pkg/utils"
+	"time"
+)
+
+// Верхняя граница числа лекций в одной серии
+const maxSeriesOccurrences = 500
+
+type LectureSeriesRepository interface {
+	Create(ctx context.Context, series *models.LectureSeries) (*models.LectureSeries, error)
+	GetByID(ctx context.Context, id int) (*models.LectureSeries, error)
+	Save(ctx context.Context, series *models.LectureSeries) (*models.LectureSeries, error)
+	Delete(ctx context.Context, id int) (*models.LectureSeries, error)
+}
+
+func (l *lectureService) CreateSeries(
+	ctx context.Context,
+	req dto.CreateLectureSeriesRequest,
+	force bool,
+) (*models.LectureSeries, []*models.Lecture, error) {
+	rule, err := utils.ParseRRule(req.RRule)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", common.ErrInvalidInput, err.Error())
+	}
+
+	// Даты серии считаются в поясе организации, поэтому правило разворачивается от шаблона
+	series := mappers.DtoToLectureSeries(req)
+	series.RRule = rule.String()
+
+	dates, err := rule.Expand(series.StartDate, series.ExDates, maxSeriesOccurrences)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", common.ErrInvalidInput, err.Error())
+	}
+
+	if len(dates) == 0 {
+		return nil, nil, fmt.Errorf("%w: recurrence rule produces no lectures", common.ErrInvalidInput)
+	}
+
+	candidates := make([]*models.Lecture, 0, len(dates))
+	for _, date := range dates {
+		candidates = append(candidates, mappers.SeriesToLecture(series, date))
+	}
+
+	if err := validateLectureTimes(candidates[0]); err != nil {
+		return nil, nil, err
+	}
+
+	if err := l.resolveDirectories(ctx, false, candidates...); err != nil {
+		return nil, nil, err
+	}
+
+	if err := l.ensureNoConflicts(ctx, candidates, force); err != nil {
+		return nil, nil, err
+	}
+
+	if series.URL != nil {
+		shortUrl, err := l.shortLinkService.ShortUrl(ctx, *series.URL)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		series.ShortURL = shortUrl
+		for _, c := range candidates {
+			c.ShortURL = shortUrl
+		}
+	}
+
+	var lectures []*models.Lecture
+
+	err = l.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := l.resolveDirectories(ctx, true, candidates...); err != nil {
+			return err
+		}
+
+		// Шаблон серии хранит те же канонические названия, что и её лекции
+		series.Group, series.Lector = candidates[0].Group, candidates[0].Lector
+		series.Unit, series.Location = candidates[0].Unit, candidates[0].Location
+		series.Admin = candidates[0].Admin
+
+		if _, err := l.seriesRepo.Create(ctx, series); err != nil {
+			return err
+		}
+
+		for _, c := range candidates {
+			c.SeriesID = &series.ID
+		}
+
+		lectures, err = l.lectureRepo.CreateMany(ctx, candidates)
+		if err != nil {
+			return err
+		}
+
+		return l.recordLectures(ctx, AuditActionCreate, nil, lectures)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return series, lectures, nil
+}
+
+func (l *lectureService) GetSeries(ctx context.Context, id int) (*models.LectureSeries, []*models.Lecture, error) {
+	series, err := l.seriesRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lectures, err := l.lectureRepo.FindBySeries(ctx, id, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return series, lectures, nil
+}
+
+func (l *lectureService) UpdateSeries(
+	ctx context.Context,
+	id int,
+	scope dto.SeriesScope,
+	req dto.UpdateLectureRequest,
+	force bool,
+) ([]*models.Lecture, error) {
+	lecture, err := l.lectureRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if lecture.SeriesID == nil {
+		return nil, fmt.Errorf("%w: lecture is not part of a series", common.ErrInvalidInput)
+	}
+
+	if scope == dto.SeriesScopeThis {
+		updated, err := l.Update(ctx, id, nil, req, force)
+		if err != nil {
+			return nil, err
+		}
+
+		return []*models.Lecture{updated}, nil
+	}
+
+	if req.Date != nil {
+		return nil, fmt.Errorf("%w: date can only be changed for a single occurrence", common.ErrInvalidInput)
+	}
+
+	seriesID := *lecture.SeriesID
+
+	checkConflicts := !force && conflictFieldsChanged(req)
+
+	if checkConflicts || req.Start != nil || req.End != nil {
+		affected, err := l.lectureRepo.FindBySeries(ctx, seriesID, &lecture.Date)
+		if scope == dto.SeriesScopeAll {
+			affected, err = l.lectureRepo.FindBySeries(ctx, seriesID, nil)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		candidates := make([]*models.Lecture, 0, len(affected))
+		for _, a := range affected {
+			candidates = append(candidates, mappers.ApplyUpdateToLecture(a, req))
+		}
+
+		if err := validateLectureTimes(candidates...); err != nil {
+			return nil, err
+		}
+
+		if err := l.resolveDirectories(ctx, false, candidates...); err != nil {
+			return nil, err
+		}
+
+		if checkConflicts {
+			if err := l.ensureNoConflicts(ctx, candidates, false); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := l.shortenUpdateURL(ctx, &req); err != nil {
+		return nil, err
+	}
+
+	var updated []*models.Lecture
+
+	err = l.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		ids, err := l.resolveUpdate(ctx, &req)
+		if err != nil {
+			return err
+		}
+
+		updates := lectureUpdates(req)
+		maps.Copy(updates, ids)
+
+		series, err := l.seriesRepo.GetByID(ctx, seriesID)
+		if err != nil {
+			return err
+		}
+
+		if scope == dto.SeriesScopeAll || !lecture.Date.After(series.StartDate) {
+			before, err := l.lectureRepo.FindBySeries(ctx, seriesID, nil)
+			if err != nil {
+				return err
+			}
+
+			mappers.ApplyUpdateToSeries(series, req)
+			if _, err := l.seriesRepo.Save(ctx, series); err != nil {
+				return err
+			}
+
+			if _, err := l.lectureRepo.UpdateBySeries(ctx, seriesID, nil, updates); err != nil {
+				return err
+			}
+
+			updated, err = l.lectureRepo.FindBySeries(ctx, seriesID, nil)
+			if err != nil {
+				return err
+			}
+
+			return l.recordLectures(ctx, AuditActionUpdate, before, updated)
+		}
+
+		before, err := l.lectureRepo.FindBySeries(ctx, seriesID, &lecture.Date)
+		if err != nil {
+			return err
+		}
+
+		// "Эта и следующие": серия делится на две, начиная с даты лекции
+		tail, err := l.splitSeries(ctx, series, lecture.Date)
+		if err != nil {
+			return err
+		}
+
+		mappers.ApplyUpdateToSeries(tail, req)
+		if _, err := l.seriesRepo.Save(ctx, tail); err != nil {
+			return err
+		}
+
+		updates["series_id"] = tail.ID
+		if _, err := l.lectureRepo.UpdateBySeries(ctx, seriesID, &lecture.Date, updates); err != nil {
+			return err
+		}
+
+		updated, err = l.lectureRepo.FindBySeries(ctx, tail.ID, nil)
+		if err != nil {
+			return err
+		}
+
+		return l.recordLectures(ctx, AuditActionUpdate, before, updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (l *lectureService) RemoveSeries(
+	ctx context.Context,
+	id int,
+	scope dto.SeriesScope,
+) ([]*models.Lecture, error) {
+	lecture, err := l.lectureRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if lecture.SeriesID == nil {
+		return nil, fmt.Errorf("%w: lecture is not part of a series", common.ErrInvalidInput)
+	}
+
+	seriesID := *lecture.SeriesID
+	var removed []*models.Lecture
+
+	err = l.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		series, err := l.seriesRepo.GetByID(ctx, seriesID)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case scope == dto.SeriesScopeThis:
+			deleted, err := l.lectureRepo.Delete(ctx, id)
+			if err != nil {
+				return err
+			}
+			removed = []*models.Lecture{deleted}
+
+			series.ExDates = append(series.ExDates, lecture.Date)
+			if _, err := l.seriesRepo.Save(ctx, series); err != nil {
+				return err
+			}
+
+		case scope == dto.SeriesScopeAll || !lecture.Date.After(series.StartDate):
+			removed, err = l.lectureRepo.DeleteBySeries(ctx, seriesID, nil)
+			if err != nil {
+				return err
+			}
+
+			if _, err := l.seriesRepo.Delete(ctx, seriesID); err != nil {
+				return err
+			}
+
+		default:
+			removed, err = l.lectureRepo.DeleteBySeries(ctx, seriesID, &lecture.Date)
+			if err != nil {
+				return err
+			}
+
+			rule, err := utils.ParseRRule(series.RRule)
+			if err != nil {
+				return err
+			}
+
+			until := lecture.Date.AddDate(0, 0, -1)
+			rule.Count = 0
+			rule.Until = &until
+
+			series.RRule = rule.String()
+			series.ExDates = exDatesBefore(series.ExDates, lecture.Date)
+			if _, err := l.seriesRepo.Save(ctx, series); err != nil {
+				return err
+			}
+		}
+
+		return l.recordLectures(ctx, AuditActionDelete, removed, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return removed, nil
+}
+
+// splitSeries обрезает серию перед from и создаёт новую серию с тем же шаблоном,
+// которая продолжает правило начиная с from. Лекцию в from могли перенести
+// отдельно, поэтому новая серия начинается с ближайшего повторения правила:
+// от даты начала зависят день недели и шаг повторений.
+func (l *lectureService) splitSeries(
+	ctx context.Context,
+	series *models.LectureSeries,
+	from time.Time,
+) (*models.LectureSeries, error) {
+	rule, err := utils.ParseRRule(series.RRule)
+	if err != nil {
+		return nil, err
+	}
+
+	tailRule := *rule
+	if rule.Count > 0 {
+		before, err := rule.CountBefore(series.StartDate, from, maxSeriesOccurrences)
+		if err != nil {
+			return nil, err
+		}
+		tailRule.Count = rule.Count - before
+	}
+
+	start := from
+	first, ok, err := rule.FirstFrom(series.StartDate, from, maxSeriesOccurrences)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		start = first
+	}
+
+	tail := *series
+	tail.ID = 0
+	tail.RRule = tailRule.String()
+	tail.StartDate = start
+	tail.ExDates = exDatesFrom(series.ExDates, from)
+	tail.CreatedAt = time.Time{}
+	tail.UpdatedAt = nil
+
+	if _, err := l.seriesRepo.Create(ctx, &tail); err != nil {
+		return nil, err
+	}
+
+	until := from.AddDate(0, 0, -1)
+	rule.Count = 0
+	rule.Until = &until
+
+	series.RRule = rule.String()
+	series.ExDates = exDatesBefore(series.ExDates, from)
+
+	if _, err := l.seriesRepo.Save(ctx, series); err != nil {
+		return nil, err
+	}
+
+	return &tail, nil
+}
+
+func exDatesBefore(dates []time.Time, date time.Time) []time.Time {
+	var result []time.Time
+	for _, d := range dates {
+		if d.Before(date) {
+			result = append(result, d)
+		}
+	}
+
+	return result
+}
+
+func exDatesFrom(dates []time.Time, date time.Time) []time.Time {
+	var result []time.Time
+	for _, d := range dates {
+		if !d.Before(date) {
+			result = append(result, d)
+		}
+	}
+
+	return result
+}
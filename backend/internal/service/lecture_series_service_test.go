@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"table-api/internal/models"
+	"table-api/pkg/utils"
+	"testing"
+	"time"
+)
+
+// seriesRepo запоминает созданные и сохранённые серии.
+type seriesRepo struct {
+	LectureSeriesRepository
+	created []*models.LectureSeries
+}
+
+func (s *seriesRepo) Create(ctx context.Context, series *models.LectureSeries) (*models.LectureSeries, error) {
+	series.ID = 100 + len(s.created)
+	s.created = append(s.created, series)
+	return series, nil
+}
+
+func (s *seriesRepo) Save(ctx context.Context, series *models.LectureSeries) (*models.LectureSeries, error) {
+	return series, nil
+}
+
+func TestSplitSeries(t *testing.T) {
+	day := func(value string) time.Time {
+		d, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+
+	tests := []struct {
+		name      string
+		rrule     string
+		from      string
+		wantStart string
+		wantRule  string
+	}{
+		{
+			name:      "lecture in place",
+			rrule:     "FREQ=WEEKLY;INTERVAL=2;COUNT=6",
+			from:      "2025-10-20",
+			wantStart: "2025-10-20",
+			wantRule:  "FREQ=WEEKLY;INTERVAL=2;COUNT=5",
+		},
+		{
+			name:      "lecture moved later in the week",
+			rrule:     "FREQ=WEEKLY;INTERVAL=2;COUNT=6",
+			from:      "2025-10-22",
+			wantStart: "2025-11-03",
+			wantRule:  "FREQ=WEEKLY;INTERVAL=2;COUNT=4",
+		},
+		{
+			name:      "lecture moved earlier",
+			rrule:     "FREQ=WEEKLY;INTERVAL=2;UNTIL=20251215",
+			from:      "2025-10-19",
+			wantStart: "2025-10-20",
+			wantRule:  "FREQ=WEEKLY;INTERVAL=2;UNTIL=20251215",
+		},
+		{
+			name:      "daily series moved off its step",
+			rrule:     "FREQ=DAILY;INTERVAL=3;COUNT=10",
+			from:      "2025-10-08",
+			wantStart: "2025-10-09",
+			wantRule:  "FREQ=DAILY;INTERVAL=3;COUNT=9",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &seriesRepo{}
+			service := &lectureService{seriesRepo: repo}
+
+			series := &models.LectureSeries{ID: 1, RRule: tt.rrule, StartDate: day("2025-10-06")}
+			rule, err := utils.ParseRRule(tt.rrule)
+			if err != nil {
+				t.Fatal(err)
+			}
+			original, err := rule.Expand(series.StartDate, nil, maxSeriesOccurrences)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tail, err := service.splitSeries(context.Background(), series, day(tt.from))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := tail.StartDate.Format(time.DateOnly); got != tt.wantStart {
+				t.Fatalf("tail StartDate = %s, want %s", got, tt.wantStart)
+			}
+			if tail.RRule != tt.wantRule {
+				t.Fatalf("tail RRule = %s, want %s", tail.RRule, tt.wantRule)
+			}
+
+			// Обе части вместе дают те же повторения, что и исходная серия
+			var got []time.Time
+			for _, part := range []*models.LectureSeries{series, tail} {
+				partRule, err := utils.ParseRRule(part.RRule)
+				if err != nil {
+					t.Fatal(err)
+				}
+				dates, err := partRule.Expand(part.StartDate, nil, maxSeriesOccurrences)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, dates...)
+			}
+
+			if len(got) != len(original) {
+				t.Fatalf("occurrences = %v, want %v", got, original)
+			}
+			for i := range original {
+				if !got[i].Equal(original[i]) {
+					t.Fatalf("occurrences = %v, want %v", got, original)
+				}
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"maps"
 	"reflect"
 	"strconv"
 	"strings"
@@ -11,6 +12,11 @@ import (
 	"table-api/internal/handler/dto"
 	"table-api/internal/mappers"
 	"table-api/internal/models"
+	"table-api/internal/visibility"
+	common "table-api/pkg"
+	"table-api/pkg/timezone"
+	"table-api/pkg/utils"
+	"table-api/pkg/vault"
 	"time"
 
 	"github.com/xuri/excelize/v2"
@@ -19,42 +25,102 @@ import (
 type LectureRepository interface {
 	Create(ctx context.Context, lecture *models.Lecture) (*models.Lecture, error)
 	CreateMany(ctx context.Context, lectures []*models.Lecture) ([]*models.Lecture, error)
-	Update(ctx context.Context, id int, updates map[string]interface{}) (*models.Lecture, error)
+	GetByID(ctx context.Context, id int) (*models.Lecture, error)
+	Update(ctx context.Context, id int, version *int, updates map[string]interface{}) (*models.Lecture, error)
 	Delete(ctx context.Context, id int) (*models.Lecture, error)
-	UpdateURLsByGroup(ctx context.Context, groupName string, url string, shortURL string) ([]*models.Lecture, error)
 	FindByDateRange(ctx context.Context, start, end time.Time) ([]*models.Lecture, error)
 	FindByExactDate(ctx context.Context, date time.Time) ([]*models.Lecture, error)
 	FindForSchedule(ctx context.Context, year, month int) ([]*models.Lecture, error)
 	FindWithUniqueDates(ctx context.Context) ([]*models.Lecture, error)
-	FindByDatesAndGroup(ctx context.Context, startDate, endDate time.Time, groupName *string) ([]*models.Lecture, error)
+	FindByGroup(ctx context.Context, groupID int) ([]*models.Lecture, error)
+	EachByDatesAndGroup(ctx context.Context, startDate, endDate time.Time, groupID *int, byGroup bool, fn func(lecture *models.Lecture) error) error
+	StatsByDatesAndGroup(ctx context.Context, startDate, endDate time.Time, groupID *int, dimensions []dto.StatsDimension) ([]entitys.LectureStat, error)
+	FindBySeries(ctx context.Context, seriesID int, from *time.Time) ([]*models.Lecture, error)
+	UpdateBySeries(ctx context.Context, seriesID int, from *time.Time, updates map[string]interface{}) (int64, error)
+	DeleteBySeries(ctx context.Context, seriesID int, from *time.Time) ([]*models.Lecture, error)
+	FindOverlapCandidates(ctx context.Context, startDate, endDate time.Time, groups, lectors, locations []string) ([]*models.Lecture, error)
+	List(ctx context.Context, page, limit int, filter dto.GetQueryLectureDto) ([]*models.Lecture, *entitys.Pagination, error)
+	FindByIDs(ctx context.Context, ids []int) ([]*models.Lecture, error)
+	FindByBulkFilter(ctx context.Context, filter dto.BulkLectureFilter) ([]*models.Lecture, error)
+	FindByGroupAndFilter(ctx context.Context, groupID int, filter dto.BulkLectureFilter) ([]*models.Lecture, error)
+	UpdateByIDs(ctx context.Context, ids []int, updates map[string]interface{}) (int64, error)
+	DeleteByIDs(ctx context.Context, ids []int) (int64, error)
+	ShiftByIDs(ctx context.Context, ids []int, days int) (int64, error)
+	ListDeleted(ctx context.Context, page, limit int) ([]*models.Lecture, *entitys.Pagination, error)
+	GetDeletedByID(ctx context.Context, id int) (*models.Lecture, error)
+	Restore(ctx context.Context, id int, updates map[string]interface{}) (*models.Lecture, error)
+	Purge(ctx context.Context, id int) (*models.Lecture, error)
+	PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error)
 }
 
 type ShortLinkService interface {
 	GetUrl(ctx context.Context, code string) (*string, error)
+	Resolve(ctx context.Context, code string) (*string, error)
 	ShortUrl(ctx context.Context, url string) (*string, error)
 }
 
 type lectureService struct {
 	lectureRepo      LectureRepository
+	seriesRepo       LectureSeriesRepository
+	tx               Transactor
 	shortLinkService ShortLinkService
+	audit            AuditRecorder
+	directories      DirectoryResolver
+	rooms            RoomChecker
+	templates        ExportTemplateResolver
+	admins           AdminResolver
+	linkRepo         LinkOperationRepository
 }
 
 func NewLectureService(
 	repo LectureRepository,
+	seriesRepo LectureSeriesRepository,
+	tx Transactor,
 	s ShortLinkService,
+	audit AuditRecorder,
+	directories DirectoryResolver,
+	rooms RoomChecker,
+	templates ExportTemplateResolver,
+	admins AdminResolver,
+	linkRepo LinkOperationRepository,
 ) *lectureService {
 	return &lectureService{
 		lectureRepo:      repo,
+		seriesRepo:       seriesRepo,
+		tx:               tx,
 		shortLinkService: s,
+		audit:            audit,
+		directories:      directories,
+		rooms:            rooms,
+		templates:        templates,
+		admins:           admins,
+		linkRepo:         linkRepo,
 	}
 }
 
-func (l *lectureService) Create(ctx context.Context, dto dto.CreateLectureRequest) (*models.Lecture, error) {
+func (l *lectureService) Create(ctx context.Context, dto dto.CreateLectureRequest, force bool) (*models.Lecture, error) {
 	newLecture, err := mappers.DtoToLecture(dto)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := validateLectureTimes(newLecture); err != nil {
+		return nil, err
+	}
+
+	if err := l.resolveDirectories(ctx, false, newLecture); err != nil {
+		return nil, err
+	}
+
+	if err := l.ensureNoConflicts(ctx, []*models.Lecture{newLecture}, force); err != nil {
+		return nil, err
+	}
+
+	warnings, err := l.rooms.Warnings(ctx, newLecture.Location, newLecture.Platform, nil)
+	if err != nil {
+		return nil, err
+	}
+
 	if newLecture.URL != nil {
 		shortUrl, err := l.shortLinkService.ShortUrl(ctx, *newLecture.URL)
 		if err != nil {
@@ -64,25 +130,78 @@ func (l *lectureService) Create(ctx context.Context, dto dto.CreateLectureReques
 		newLecture.ShortURL = shortUrl
 	}
 
-	return l.lectureRepo.Create(ctx, newLecture)
+	var created *models.Lecture
+
+	err = l.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := l.resolveDirectories(ctx, true, newLecture); err != nil {
+			return err
+		}
+
+		created, err = l.lectureRepo.Create(ctx, newLecture)
+		if err != nil {
+			return err
+		}
+
+		return l.recordLectures(ctx, AuditActionCreate, nil, []*models.Lecture{created})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	created.Warnings = warnings
+
+	return created, nil
 }
 
-func (l *lectureService) CreateMany(ctx context.Context, dto []dto.CreateLectureRequest) ([]*models.Lecture, error) {
+func (l *lectureService) CreateMany(ctx context.Context, dto []dto.CreateLectureRequest, force bool) ([]*models.Lecture, error) {
 	newLectures, err := mappers.DtoToManyLecture(dto)
 	if err != nil {
 		return nil, err
 	}
 
-	return l.lectureRepo.CreateMany(ctx, newLectures)
-}
+	if err := validateLectureTimes(newLectures...); err != nil {
+		return nil, err
+	}
 
-func (l *lectureService) CreateManyLinks(ctx context.Context, dto dto.UpdateManyLinksRequest) ([]*models.Lecture, error) {
-	shortUrl, err := l.shortLinkService.ShortUrl(ctx, dto.Url)
+	if err := l.resolveDirectories(ctx, false, newLectures...); err != nil {
+		return nil, err
+	}
+
+	if err := l.ensureNoConflicts(ctx, newLectures, force); err != nil {
+		return nil, err
+	}
+
+	warnings := make([][]string, len(newLectures))
+	for i, lecture := range newLectures {
+		warnings[i], err = l.rooms.Warnings(ctx, lecture.Location, lecture.Platform, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var created []*models.Lecture
+
+	err = l.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := l.resolveDirectories(ctx, true, newLectures...); err != nil {
+			return err
+		}
+
+		created, err = l.lectureRepo.CreateMany(ctx, newLectures)
+		if err != nil {
+			return err
+		}
+
+		return l.recordLectures(ctx, AuditActionCreate, nil, created)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return l.lectureRepo.UpdateURLsByGroup(ctx, dto.GroupName, dto.Url, *shortUrl)
+	for i := range created {
+		created[i].Warnings = warnings[i]
+	}
+
+	return created, nil
 }
 
 func (l *lectureService) GetDates(ctx context.Context) (*entitys.LectureDates, error) {
@@ -173,26 +292,135 @@ func (l *lectureService) GetSchedule(
 	return schedules, nil
 }
 
+func (l *lectureService) List(
+	ctx context.Context,
+	page, limit int,
+	filter dto.GetQueryLectureDto,
+) ([]*models.Lecture, *entitys.Pagination, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	if filter.DateFrom != nil && filter.DateTo != nil && filter.DateTo.Before(*filter.DateFrom) {
+		return nil, nil, fmt.Errorf("%w: dateTo must not be before dateFrom", common.ErrInvalidInput)
+	}
+
+	if filter.DateFrom != nil {
+		from := startOfDay(*filter.DateFrom)
+		filter.DateFrom = &from
+	}
+
+	if filter.DateTo != nil {
+		to := endOfDay(*filter.DateTo)
+		filter.DateTo = &to
+	}
+
+	return l.lectureRepo.List(ctx, page, limit, filter)
+}
+
 func (l *lectureService) GetByDate(ctx context.Context, date time.Time) ([]*models.Lecture, error) {
 	return l.lectureRepo.FindByExactDate(ctx, date)
 }
 
+// Update меняет лекцию. Если задана version, правка отклоняется StaleError,
+// когда лекцию успели изменить после того, как клиент её прочитал.
 func (l *lectureService) Update(
 	ctx context.Context,
 	id int,
+	version *int,
 	dto dto.UpdateLectureRequest,
+	force bool,
 ) (*models.Lecture, error) {
+	return l.update(ctx, id, version, dto, nil, force)
+}
 
-	updates := map[string]interface{}{}
+// update применяет правку и очищает колонки времени из clear: через JSON
+// правки время не сбросить, а импорт очищает его пустой ячейкой.
+func (l *lectureService) update(
+	ctx context.Context,
+	id int,
+	version *int,
+	dto dto.UpdateLectureRequest,
+	clear []string,
+	force bool,
+) (*models.Lecture, error) {
+	lecture, err := l.lectureRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if version != nil && lecture.Version != *version {
+		return nil, &common.StaleError{Current: lecture}
+	}
+
+	candidate := clearLectureTimes(mappers.ApplyUpdateToLecture(lecture, dto), clear)
+	if err := validateLectureTimes(candidate); err != nil {
+		return nil, err
+	}
+
+	if err := l.resolveDirectories(ctx, false, candidate); err != nil {
+		return nil, err
+	}
+
+	if !force && (conflictFieldsChanged(dto) || len(clear) > 0) {
+		if err := l.ensureNoConflicts(ctx, []*models.Lecture{candidate}, false); err != nil {
+			return nil, err
+		}
+	}
 
+	if err := l.shortenUpdateURL(ctx, &dto); err != nil {
+		return nil, err
+	}
+
+	var updated *models.Lecture
+
+	err = l.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		ids, err := l.resolveUpdate(ctx, &dto)
+		if err != nil {
+			return err
+		}
+
+		updates := lectureUpdates(dto)
+		maps.Copy(updates, ids)
+		for _, column := range clear {
+			updates[column] = nil
+		}
+
+		updated, err = l.lectureRepo.Update(ctx, id, version, updates)
+		if err != nil {
+			return err
+		}
+
+		return l.recordLectures(ctx, AuditActionUpdate, []*models.Lecture{lecture}, []*models.Lecture{updated})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (l *lectureService) shortenUpdateURL(ctx context.Context, dto *dto.UpdateLectureRequest) error {
 	if dto.URL != nil && dto.ShortURL == nil {
 		shortUrl, err := l.shortLinkService.ShortUrl(ctx, *dto.URL)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		dto.ShortURL = shortUrl
 	}
 
+	return nil
+}
+
+func lectureUpdates(dto dto.UpdateLectureRequest) map[string]interface{} {
+	updates := map[string]interface{}{}
+
 	v := reflect.ValueOf(dto)
 	t := reflect.TypeOf(dto)
 
@@ -216,6 +444,11 @@ func (l *lectureService) Update(
 			continue
 		}
 
+		// Маска из ответа означает, что поле не менялось
+		if value, ok := fieldValue.Interface().(*string); ok && utils.IsMasked(*value) {
+			continue
+		}
+
 		updates[column] = fieldValue.Interface()
 	}
 
@@ -227,44 +460,202 @@ func (l *lectureService) Update(
 		updates["end"] = *dto.End
 	}
 
-	return l.lectureRepo.Update(ctx, id, updates)
+	if dto.Date != nil {
+		updates["date"] = timezone.Day(*dto.Date)
+	}
+
+	// Ключ пишется через vault.Secret, чтобы попасть в БД зашифрованным
+	delete(updates, "streamKey")
+	if key := vault.New(dto.StreamKey); key != nil {
+		updates["streamKey"] = key
+	}
+
+	return updates
+}
+
+// validateLectureTimes проверяет, что конец каждой лекции позже её начала.
+func validateLectureTimes(lectures ...*models.Lecture) error {
+	for i, lecture := range lectures {
+		if lecture.Start == nil || lecture.End == nil || *lecture.End > *lecture.Start {
+			continue
+		}
+
+		if len(lectures) > 1 {
+			return fmt.Errorf("%w: lectures[%d]: end must be after start", common.ErrInvalidInput, i)
+		}
+
+		return fmt.Errorf("%w: end must be after start", common.ErrInvalidInput)
+	}
+
+	return nil
 }
 
 func (l *lectureService) Remove(ctx context.Context, id int) (*models.Lecture, error) {
-	return l.lectureRepo.Delete(ctx, id)
+	var removed *models.Lecture
+
+	err := l.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		removed, err = l.lectureRepo.Delete(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return l.recordLectures(ctx, AuditActionDelete, []*models.Lecture{removed}, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return removed, nil
 }
 
+// recordLectures пишет в журнал изменения лекций; before и after сопоставляются по ID.
+func (l *lectureService) recordLectures(ctx context.Context, action string, before, after []*models.Lecture) error {
+	states := make(map[int]*AuditRecord, len(before)+len(after))
+	var order []int
+
+	state := func(id int) *AuditRecord {
+		if _, ok := states[id]; !ok {
+			states[id] = &AuditRecord{EntityID: strconv.Itoa(id)}
+			order = append(order, id)
+		}
+		return states[id]
+	}
+
+	for _, lecture := range before {
+		state(lecture.ID).Before = mappers.LectureToDto(lecture, visibility.Snapshot)
+		state(lecture.ID).BeforeSecrets = lectureSecrets(lecture)
+	}
+	for _, lecture := range after {
+		state(lecture.ID).After = mappers.LectureToDto(lecture, visibility.Snapshot)
+		state(lecture.ID).AfterSecrets = lectureSecrets(lecture)
+	}
+
+	records := make([]AuditRecord, 0, len(order))
+	for _, id := range order {
+		records = append(records, *states[id])
+	}
+
+	return l.audit.Record(ctx, AuditEntityLecture, action, records...)
+}
+
+// lectureSecrets возвращает отпечаток ключа трансляции для журнала аудита.
+func lectureSecrets(lecture *models.Lecture) map[string]string {
+	if lecture.StreamKey == nil || *lecture.StreamKey == "" {
+		return nil
+	}
+
+	return map[string]string{"streamKey": lecture.StreamKey.Fingerprint()}
+}
+
+// Лист плоской выгрузки
+const lectureExportSheet = "Lectures"
+
+// Export выгружает лекции за период в XLSX или CSV.
+// Колонки и форматы задаёт шаблон; без шаблона — раскладка, которую понимает импорт.
+// Лекции читаются курсором и пишутся потоком, поэтому память не растёт с длиной периода.
 func (l *lectureService) Export(
 	ctx context.Context,
 	filter dto.ExportLecturesExcelRequest,
+	view visibility.View,
 	writer io.Writer,
 ) error {
 	if filter.StartDate.IsZero() || filter.EndDate.IsZero() {
 		return fmt.Errorf("invalid date range")
 	}
 
-	lectures, err := l.lectureRepo.FindByDatesAndGroup(
+	if filter.Format == dto.ExportFormatCSV && filter.Profile != dto.ExportProfileFlat {
+		return fmt.Errorf("%w: csv export supports only the flat profile", common.ErrInvalidInput)
+	}
+
+	template, err := l.templates.Template(ctx, derefString(filter.Template))
+	if err != nil {
+		return err
+	}
+
+	columns := exportColumns(template)
+
+	groupID, err := l.filterGroupID(ctx, filter.Group)
+	if err != nil {
+		return err
+	}
+
+	if filter.Format == dto.ExportFormatCSV {
+		csvWriter, err := newLectureCSVWriter(writer, columns)
+		if err != nil {
+			return err
+		}
+
+		err = l.eachExportRow(ctx, filter, groupID, view, csvWriter.Write)
+		if err != nil {
+			return err
+		}
+
+		return csvWriter.Flush()
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	workbook, err := newLectureWorkbook(f, columns, filter.Profile)
+	if err != nil {
+		return err
+	}
+
+	if err := l.eachExportRow(ctx, filter, groupID, view, workbook.Write); err != nil {
+		return err
+	}
+
+	if err := workbook.Close(); err != nil {
+		return err
+	}
+
+	return f.Write(writer)
+}
+
+// eachExportRow отдаёт лекции выгрузки по одной, уже в виде строк ответа API для роли.
+func (l *lectureService) eachExportRow(
+	ctx context.Context,
+	filter dto.ExportLecturesExcelRequest,
+	groupID *int,
+	view visibility.View,
+	fn func(row *dto.LectureResponse) error,
+) error {
+	return l.lectureRepo.EachByDatesAndGroup(
 		ctx,
 		filter.StartDate,
 		filter.EndDate,
-		filter.Group,
+		groupID,
+		filter.Profile == dto.ExportProfileGroups,
+		func(lecture *models.Lecture) error {
+			return fn(mappers.LectureToDto(lecture, view))
+		},
 	)
-	if err != nil {
-		return err
+}
+
+// filterGroupID находит ID группы фильтра выгрузки. Неизвестная группа даёт
+// пустую выборку: ID 0 не совпадёт ни с одной лекцией.
+func (l *lectureService) filterGroupID(ctx context.Context, group *string) (*int, error) {
+	if group == nil || *group == "" {
+		return nil, nil
 	}
 
-	f := excelize.NewFile()
-	sheet := "Lectures"
-	index, _ := f.NewSheet(sheet)
-	f.SetActiveSheet(index)
+	id, err := l.lookupDirectory(ctx, models.DirectoryGroup, *group)
+	if err != nil {
+		return nil, err
+	}
 
-	headers := []string{
-		"ID", "Дата", "Начало", "Конец", "Группа", "Лектор",
-		"Платформа", "Корпус", "Место", "Ссылка", "Ключ потока",
-		"Описание", "Админ",
+	if id == nil {
+		id = new(int)
 	}
 
-	headerStyle, _ := f.NewStyle(&excelize.Style{
+	return id, nil
+}
+
+// exportHeaderStyle — стиль заголовков выгрузки.
+func exportHeaderStyle(f *excelize.File) int {
+	style, _ := f.NewStyle(&excelize.Style{
 		Font: &excelize.Font{
 			Bold:  true,
 			Color: "#FFFFFF",
@@ -287,13 +678,12 @@ func (l *lectureService) Export(
 		},
 	})
 
-	for i, h := range headers {
-		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
-		f.SetCellValue(sheet, cell, h)
-		f.SetCellStyle(sheet, cell, cell, headerStyle)
-	}
+	return style
+}
 
-	dataStyle, _ := f.NewStyle(&excelize.Style{
+// exportDataStyle — стиль ячеек с данными.
+func exportDataStyle(f *excelize.File) int {
+	style, _ := f.NewStyle(&excelize.Style{
 		Alignment: &excelize.Alignment{
 			Horizontal: "left",
 			Vertical:   "center",
@@ -306,106 +696,5 @@ func (l *lectureService) Export(
 		},
 	})
 
-	for i, lecture := range lectures {
-		row := i + 2
-
-		f.SetCellValue(sheet, "A"+strconv.Itoa(row), lecture.ID)
-		f.SetCellValue(sheet, "B"+strconv.Itoa(row), lecture.Date.Format("2006-01-02"))
-
-		if lecture.Start != nil {
-			f.SetCellValue(sheet, "C"+strconv.Itoa(row), *lecture.Start)
-		}
-		if lecture.End != nil {
-			f.SetCellValue(sheet, "D"+strconv.Itoa(row), *lecture.End)
-		}
-
-		cells := map[string]*string{
-			"E": lecture.Group,
-			"F": lecture.Lector,
-			"G": lecture.Platform,
-			"H": lecture.Unit,
-			"I": lecture.Location,
-			"J": lecture.ShortURL,
-			"K": lecture.StreamKey,
-			"L": lecture.Description,
-			"M": lecture.Admin,
-		}
-
-		for col, val := range cells {
-			if val != nil {
-				f.SetCellValue(sheet, col+strconv.Itoa(row), *val)
-			}
-		}
-
-		f.SetCellStyle(sheet, "A"+strconv.Itoa(row), "M"+strconv.Itoa(row), dataStyle)
-	}
-
-	// Автоматическая ширина колонок
-	for i := 1; i <= len(headers); i++ {
-		col, _ := excelize.ColumnNumberToName(i)
-		maxLen := len(headers[i-1])
-
-		for j := 0; j < len(lectures); j++ {
-			var val string
-
-			switch col {
-			case "A":
-				val = strconv.Itoa(lectures[j].ID)
-			case "B":
-				val = lectures[j].Date.Format("2006-01-02")
-			case "C":
-				if lectures[j].Start != nil {
-					val = *lectures[j].Start
-				}
-			case "D":
-				if lectures[j].End != nil {
-					val = *lectures[j].End
-				}
-			case "E":
-				if lectures[j].Group != nil {
-					val = *lectures[j].Group
-				}
-			case "F":
-				if lectures[j].Lector != nil {
-					val = *lectures[j].Lector
-				}
-			case "G":
-				if lectures[j].Platform != nil {
-					val = *lectures[j].Platform
-				}
-			case "H":
-				if lectures[j].Unit != nil {
-					val = *lectures[j].Unit
-				}
-			case "I":
-				if lectures[j].Location != nil {
-					val = *lectures[j].Location
-				}
-			case "J":
-				if lectures[j].ShortURL != nil {
-					val = *lectures[j].ShortURL
-				}
-			case "K":
-				if lectures[j].StreamKey != nil {
-					val = *lectures[j].StreamKey
-				}
-			case "L":
-				if lectures[j].Description != nil {
-					val = *lectures[j].Description
-				}
-			case "M":
-				if lectures[j].Admin != nil {
-					val = *lectures[j].Admin
-				}
-			}
-
-			if len(val) > maxLen {
-				maxLen = len(val)
-			}
-		}
-
-		f.SetColWidth(sheet, col, col, float64(maxLen+2))
-	}
-
-	return f.Write(writer)
+	return style
 }
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"table-api/internal/entitys"
+	"table-api/internal/handler/dto"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const lectureStatsSheet = "Статистика"
+
+// Заголовки колонок разрезов в файлах статистики
+var statsHeaders = map[dto.StatsDimension]string{
+	dto.StatsByLector:   "Лектор",
+	dto.StatsByGroup:    "Группа",
+	dto.StatsByPlatform: "Платформа",
+	dto.StatsByUnit:     "Корпус",
+	dto.StatsByMonth:    "Месяц",
+}
+
+// Stats считает лекции и часы за период по сочетаниям разрезов filter.By.
+// Без разрезов возвращается одна строка с итогом.
+func (l *lectureService) Stats(ctx context.Context, filter dto.LectureStatsRequest) ([]entitys.LectureStat, error) {
+	if filter.StartDate.IsZero() || filter.EndDate.IsZero() {
+		return nil, fmt.Errorf("invalid date range")
+	}
+
+	groupID, err := l.filterGroupID(ctx, filter.Group)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.lectureRepo.StatsByDatesAndGroup(ctx, filter.StartDate, filter.EndDate, groupID, filter.By)
+}
+
+// ExportStats пишет статистику в CSV или XLSX.
+func (l *lectureService) ExportStats(ctx context.Context, filter dto.LectureStatsRequest, writer io.Writer) error {
+	stats, err := l.Stats(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	header := make([]string, 0, len(filter.By)+2)
+	for _, dimension := range filter.By {
+		header = append(header, statsHeaders[dimension])
+	}
+	header = append(header, "Лекций", "Часов")
+
+	if filter.Format == dto.ExportFormatCSV {
+		return writeStatsCSV(writer, header, stats)
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	f.SetSheetName("Sheet1", lectureStatsSheet)
+
+	if err := f.SetSheetRow(lectureStatsSheet, "A1", &header); err != nil {
+		return err
+	}
+
+	lastColumn, _ := excelize.ColumnNumberToName(len(header))
+	f.SetCellStyle(lectureStatsSheet, "A1", lastColumn+"1", exportHeaderStyle(f))
+	f.SetColWidth(lectureStatsSheet, "A", lastColumn, 20)
+
+	for i, stat := range stats {
+		cell, _ := excelize.CoordinatesToCellName(1, i+2)
+		if err := f.SetSheetRow(lectureStatsSheet, cell, statsRow(stat)); err != nil {
+			return err
+		}
+	}
+
+	if len(stats) > 0 {
+		lastCell, _ := excelize.CoordinatesToCellName(len(header), len(stats)+1)
+		f.SetCellStyle(lectureStatsSheet, "A2", lastCell, exportDataStyle(f))
+	}
+
+	return f.Write(writer)
+}
+
+func writeStatsCSV(writer io.Writer, header []string, stats []entitys.LectureStat) error {
+	if _, err := io.WriteString(writer, "\uFEFF"); err != nil {
+		return err
+	}
+
+	w := csv.NewWriter(writer)
+	if err := w.Write(header); err != nil {
+		return err
+	}
+
+	record := make([]string, len(header))
+	for _, stat := range stats {
+		for i, value := range *statsRow(stat) {
+			record[i] = fmt.Sprint(value)
+		}
+
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+// statsRow — строка файла: значения разрезов, число лекций и часы.
+func statsRow(stat entitys.LectureStat) *[]any {
+	row := make([]any, 0, len(stat.Keys)+2)
+	for _, key := range stat.Keys {
+		row = append(row, valueOrNotSpecified(key))
+	}
+
+	row = append(row, stat.Lectures, minutesToHours(int(stat.Minutes)))
+
+	return &row
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"table-api/internal/models"
+	common "table-api/pkg"
+	"table-api/pkg/vault"
+)
+
+// RevealStreamKey отдаёт лекцию с открытым ключом и пишет просмотр в журнал.
+func (l *lectureService) RevealStreamKey(ctx context.Context, id int) (*models.Lecture, error) {
+	lecture, err := l.lectureRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if lecture.StreamKey == nil || *lecture.StreamKey == "" {
+		return nil, fmt.Errorf("%w: lecture has no stream key", common.ErrNotFound)
+	}
+
+	err = l.audit.Record(ctx, AuditEntityLecture, AuditActionReveal, AuditRecord{
+		EntityID: strconv.Itoa(lecture.ID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return lecture, nil
+}
+
+// RotateStreamKey выпускает лекции новый ключ трансляции.
+func (l *lectureService) RotateStreamKey(ctx context.Context, id int) (*models.Lecture, error) {
+	key, err := vault.Generate()
+	if err != nil {
+		return nil, err
+	}
+
+	var updated *models.Lecture
+
+	err = l.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		lecture, err := l.lectureRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		updated, err = l.lectureRepo.Update(ctx, id, nil, map[string]interface{}{
+			"stream_key": vault.Secret(key),
+		})
+		if err != nil {
+			return err
+		}
+
+		return l.recordLectures(ctx, AuditActionUpdate, []*models.Lecture{lecture}, []*models.Lecture{updated})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// RotateGroupStreamKeys выпускает один новый ключ для всех лекций группы.
+func (l *lectureService) RotateGroupStreamKeys(ctx context.Context, group string) ([]*models.Lecture, error) {
+	groupID, err := l.lookupDirectory(ctx, models.DirectoryGroup, group)
+	if err != nil {
+		return nil, err
+	}
+
+	if groupID == nil {
+		return nil, fmt.Errorf("%w: group not found", common.ErrNotFound)
+	}
+
+	key, err := vault.Generate()
+	if err != nil {
+		return nil, err
+	}
+
+	var updated []*models.Lecture
+
+	err = l.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		before, err := l.lectureRepo.FindByGroup(ctx, *groupID)
+		if err != nil {
+			return err
+		}
+
+		if len(before) == 0 {
+			return fmt.Errorf("%w: group has no lectures", common.ErrNotFound)
+		}
+
+		ids := lectureIDs(before)
+
+		_, err = l.lectureRepo.UpdateByIDs(ctx, ids, map[string]interface{}{
+			"stream_key": vault.Secret(key),
+		})
+		if err != nil {
+			return err
+		}
+
+		updated, err = l.lectureRepo.FindByIDs(ctx, ids)
+		if err != nil {
+			return err
+		}
+
+		return l.recordLectures(ctx, AuditActionUpdate, before, updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"table-api/internal/entitys"
+	"table-api/internal/models"
+	common "table-api/pkg"
+	"time"
+)
+
+func (l *lectureService) ListTrash(ctx context.Context, page, limit int) ([]*models.Lecture, *entitys.Pagination, error) {
+	page, limit = trashPage(page, limit)
+
+	return l.lectureRepo.ListDeleted(ctx, page, limit)
+}
+
+// Restore возвращает лекцию из корзины. Если её серия уже удалена,
+// лекция восстанавливается как одиночная.
+func (l *lectureService) Restore(ctx context.Context, id int, force bool) (*models.Lecture, error) {
+	lecture, err := l.lectureRepo.GetDeletedByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := map[string]interface{}{}
+
+	if lecture.SeriesID != nil {
+		_, err := l.seriesRepo.GetByID(ctx, *lecture.SeriesID)
+		switch {
+		case errors.Is(err, common.ErrNotFound):
+			updates["series_id"] = nil
+			lecture.SeriesID = nil
+		case err != nil:
+			return nil, err
+		}
+	}
+
+	if err := l.ensureNoConflicts(ctx, []*models.Lecture{lecture}, force); err != nil {
+		return nil, err
+	}
+
+	var restored *models.Lecture
+
+	err = l.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		restored, err = l.lectureRepo.Restore(ctx, id, updates)
+		if err != nil {
+			return err
+		}
+
+		return l.recordLectures(ctx, AuditActionRestore, nil, []*models.Lecture{restored})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return restored, nil
+}
+
+func (l *lectureService) Purge(ctx context.Context, id int) (*models.Lecture, error) {
+	var purged *models.Lecture
+
+	err := l.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		purged, err = l.lectureRepo.Purge(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return l.recordLectures(ctx, AuditActionPurge, []*models.Lecture{purged}, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return purged, nil
+}
+
+func (l *lectureService) PurgeTrash(ctx context.Context, before time.Time) (int64, error) {
+	return l.lectureRepo.PurgeDeletedBefore(ctx, before)
+}
@@ -62,3 +62,28 @@ func (s *MailService) Send(
 		s.log.Warn("failed send: ", err.Error())
 	}
 }
+
+// SendAlternative отправляет письмо с текстовой и HTML-версией.
+func (s *MailService) SendAlternative(
+	to string,
+	subject string,
+	text string,
+	html string,
+) {
+	m := gomail.NewMessage()
+
+	m.SetAddressHeader(
+		"From",
+		s.from.Address,
+		s.from.Name,
+	)
+
+	m.SetHeader("To", to)
+	m.SetHeader("Subject", subject)
+	m.SetBody("text/plain", text)
+	m.AddAlternative("text/html", html)
+
+	if err := s.dialer.DialAndSend(m); err != nil {
+		s.log.Warn("failed send: ", err.Error())
+	}
+}
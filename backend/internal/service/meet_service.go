@@ -6,21 +6,30 @@ import (
 	"log"
 	"os"
 	"reflect"
+	"strconv"
 	"strings"
 	"table-api/internal/entitys"
 	"table-api/internal/handler/dto"
 	"table-api/internal/mappers"
 	"table-api/internal/models"
+	"table-api/internal/visibility"
+	common "table-api/pkg"
+	"table-api/pkg/utils"
 	"table-api/pkg/validator"
 	"time"
 )
 
 type MeetRepository interface {
 	Create(ctx context.Context, meet *models.Meet) (*models.Meet, error)
-	Update(ctx context.Context, id int, updates map[string]interface{}) (*models.Meet, error)
+	Update(ctx context.Context, id int, version *int, updates map[string]interface{}) (*models.Meet, error)
 	List(ctx context.Context, page, limit int, filter dto.GetQueryMeetDto) ([]*models.Meet, *entitys.Pagination, error)
 	GetByID(ctx context.Context, id int) (*models.Meet, error)
 	MarkCompletedIfEnded() error
+	Delete(ctx context.Context, id int) (*models.Meet, error)
+	ListDeleted(ctx context.Context, page, limit int) ([]*models.Meet, *entitys.Pagination, error)
+	Restore(ctx context.Context, id int) (*models.Meet, error)
+	Purge(ctx context.Context, id int) (*models.Meet, error)
+	PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error)
 }
 
 type Mailer interface {
@@ -31,25 +40,81 @@ type meetService struct {
 	meetRepo         MeetRepository
 	shortLinkService ShortLinkService
 	mailService      Mailer
+	tx               Transactor
+	audit            AuditRecorder
+	rooms            RoomChecker
+	admins           AdminResolver
 	domain           string
 }
 
-func NewMeetService(repo MeetRepository, mail Mailer, s ShortLinkService) *meetService {
+func NewMeetService(
+	repo MeetRepository,
+	mail Mailer,
+	s ShortLinkService,
+	tx Transactor,
+	audit AuditRecorder,
+	rooms RoomChecker,
+	admins AdminResolver,
+) *meetService {
 	domain := os.Getenv("SERVER_DOMAIN")
 
 	if !validator.IsValidDomain(domain) {
 		log.Fatal("Invalid SERVER_DOMAIN")
 	}
 
-	return &meetService{meetRepo: repo, mailService: mail, shortLinkService: s, domain: domain}
+	return &meetService{
+		meetRepo:         repo,
+		mailService:      mail,
+		shortLinkService: s,
+		tx:               tx,
+		audit:            audit,
+		rooms:            rooms,
+		admins:           admins,
+		domain:           domain,
+	}
 }
 
 func (m *meetService) Create(ctx context.Context, dto dto.CreateMeetRequest) (*models.Meet, error) {
-	meet := mappers.DtoToMeet(&dto)
-	return m.meetRepo.Create(ctx, meet)
+	zone, err := m.rooms.Zone(ctx, dto.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	meet := mappers.DtoToMeet(&dto, zone)
+
+	if meet.Admin != nil && utils.NameKey(*meet.Admin) != "" {
+		admin, err := findAdmin(ctx, m.admins, *meet.Admin)
+		if err != nil {
+			return nil, err
+		}
+
+		name := adminName(admin)
+		meet.Admin, meet.AdminID = &name, &admin.ID
+	} else {
+		meet.Admin = nil
+	}
+
+	// Заявка принимается в любом случае, несоответствия аудитории только показываются
+	warnings, err := m.rooms.Warnings(ctx, meet.Location, meet.Platform, splitDevices(meet.Devices))
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := m.withAudit(ctx, AuditActionCreate, func(ctx context.Context) (*models.Meet, error) {
+		return m.meetRepo.Create(ctx, meet)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	created.Warnings = warnings
+
+	return created, nil
 }
 
-func (m *meetService) Update(ctx context.Context, id int, dto dto.UpdateMeetRequest) (*models.Meet, error) {
+// Update меняет мероприятие. Если задана version, правка отклоняется StaleError,
+// когда мероприятие успели изменить после того, как клиент его прочитал.
+func (m *meetService) Update(ctx context.Context, id int, version *int, dto dto.UpdateMeetRequest) (*models.Meet, error) {
 
 	v := reflect.ValueOf(dto)
 	t := reflect.TypeOf(dto)
@@ -68,21 +133,63 @@ func (m *meetService) Update(ctx context.Context, id int, dto dto.UpdateMeetRequ
 		jsonTag := fieldType.Tag.Get("json")
 		column := strings.Split(jsonTag, ",")[0]
 
-		if column == "" || column == "-" {
+		if column == "" || column == "-" || column == "start" || column == "end" {
+			continue
+		}
+
+		// Маска из ответа означает, что поле не менялось
+		if value, ok := fieldValue.Interface().(*string); ok && utils.IsMasked(*value) {
 			continue
 		}
 
 		updates[column] = fieldValue.Interface()
 	}
 
-	url := dto.URL
+	oldMeet, err := m.meetRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
 
-	if nil != url {
-		oldMeet, err := m.meetRepo.GetByID(ctx, id)
+	if version != nil && oldMeet.Version != *version {
+		return nil, &common.StaleError{Current: oldMeet}
+	}
+
+	// Админ — ссылка на пользователя; пустое имя снимает назначение
+	if dto.Admin != nil {
+		updates["admin"], updates["adminId"] = nil, nil
+
+		if utils.NameKey(*dto.Admin) != "" {
+			admin, err := findAdmin(ctx, m.admins, *dto.Admin)
+			if err != nil {
+				return nil, err
+			}
+
+			updates["admin"], updates["adminId"] = adminName(admin), admin.ID
+		}
+	}
+
+	if dto.Start != nil || dto.End != nil {
+		location := dto.Location
+		if location == nil {
+			location = oldMeet.Location
+		}
+
+		zone, err := m.rooms.Zone(ctx, location)
 		if err != nil {
 			return nil, err
 		}
 
+		if dto.Start != nil {
+			updates["start"] = dto.Start.In(zone)
+		}
+		if dto.End != nil {
+			updates["end"] = dto.End.In(zone)
+		}
+	}
+
+	url := dto.URL
+
+	if nil != url {
 		if oldMeet.Status == "new" {
 			updates["status"] = "active"
 		}
@@ -97,7 +204,16 @@ func (m *meetService) Update(ctx context.Context, id int, dto dto.UpdateMeetRequ
 		}
 	}
 
-	updatedMeet, err := m.meetRepo.Update(ctx, id, updates)
+	var updatedMeet *models.Meet
+
+	err = m.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		updatedMeet, err = m.meetRepo.Update(ctx, id, version, updates)
+		if err != nil {
+			return err
+		}
+
+		return m.recordMeet(ctx, AuditActionUpdate, oldMeet, updatedMeet)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -120,6 +236,33 @@ func (m *meetService) Update(ctx context.Context, id int, dto dto.UpdateMeetRequ
 	return updatedMeet, nil
 }
 
+// AssignAdmin назначает мероприятию админа и пишет изменение в журнал.
+func (m *meetService) AssignAdmin(ctx context.Context, id int, admin *models.User) (*models.Meet, error) {
+	var updated *models.Meet
+
+	err := m.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		before, err := m.meetRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		updated, err = m.meetRepo.Update(ctx, id, nil, map[string]interface{}{
+			"admin":   adminName(admin),
+			"adminId": admin.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		return m.recordMeet(ctx, AuditActionUpdate, before, updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
 func (m *meetService) List(ctx context.Context, page, limit int, filter dto.GetQueryMeetDto) ([]*models.Meet, *entitys.Pagination, error) {
 	if page < 1 {
 		page = 1
@@ -147,3 +290,77 @@ func (m *meetService) AutoUpdate(timeout time.Duration) {
 		time.Sleep(timeout)
 	}
 }
+
+func (m *meetService) Remove(ctx context.Context, id int) (*models.Meet, error) {
+	return m.withAudit(ctx, AuditActionDelete, func(ctx context.Context) (*models.Meet, error) {
+		return m.meetRepo.Delete(ctx, id)
+	})
+}
+
+func (m *meetService) ListTrash(ctx context.Context, page, limit int) ([]*models.Meet, *entitys.Pagination, error) {
+	page, limit = trashPage(page, limit)
+
+	return m.meetRepo.ListDeleted(ctx, page, limit)
+}
+
+func (m *meetService) Restore(ctx context.Context, id int) (*models.Meet, error) {
+	return m.withAudit(ctx, AuditActionRestore, func(ctx context.Context) (*models.Meet, error) {
+		return m.meetRepo.Restore(ctx, id)
+	})
+}
+
+func (m *meetService) Purge(ctx context.Context, id int) (*models.Meet, error) {
+	return m.withAudit(ctx, AuditActionPurge, func(ctx context.Context) (*models.Meet, error) {
+		return m.meetRepo.Purge(ctx, id)
+	})
+}
+
+func (m *meetService) PurgeTrash(ctx context.Context, before time.Time) (int64, error) {
+	return m.meetRepo.PurgeDeletedBefore(ctx, before)
+}
+
+// withAudit выполняет операцию над мероприятием и пишет её в журнал в одной транзакции.
+// Для delete и purge результат операции считается состоянием «до».
+func (m *meetService) withAudit(
+	ctx context.Context,
+	action string,
+	fn func(ctx context.Context) (*models.Meet, error),
+) (*models.Meet, error) {
+	var meet *models.Meet
+
+	err := m.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+
+		meet, err = fn(ctx)
+		if err != nil {
+			return err
+		}
+
+		if action == AuditActionDelete || action == AuditActionPurge {
+			return m.recordMeet(ctx, action, meet, nil)
+		}
+
+		return m.recordMeet(ctx, action, nil, meet)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return meet, nil
+}
+
+func (m *meetService) recordMeet(ctx context.Context, action string, before, after *models.Meet) error {
+	record := AuditRecord{}
+
+	if before != nil {
+		record.EntityID = strconv.Itoa(before.ID)
+		record.Before = mappers.MeetToDto(before, visibility.Snapshot)
+	}
+
+	if after != nil {
+		record.EntityID = strconv.Itoa(after.ID)
+		record.After = mappers.MeetToDto(after, visibility.Snapshot)
+	}
+
+	return m.audit.Record(ctx, AuditEntityMeet, action, record)
+}
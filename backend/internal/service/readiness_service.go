@@ -0,0 +1,369 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"table-api/internal/entitys"
+	"table-api/internal/mappers"
+	"table-api/internal/models"
+	"table-api/internal/readiness"
+	"table-api/internal/visibility"
+	common "table-api/pkg"
+	"table-api/pkg/timezone"
+	"table-api/pkg/utils"
+	"time"
+)
+
+// Роли, которым уходит ежедневный отчёт о готовности
+var readinessRecipientRoles = []string{"admin", "moderator"}
+
+type ReadinessLectureRepository interface {
+	FindByDateRange(ctx context.Context, startDate, endDate time.Time) ([]*models.Lecture, error)
+}
+
+type ReadinessMeetRepository interface {
+	FindOverlapping(ctx context.Context, start, end time.Time) ([]*models.Meet, error)
+}
+
+type ReadinessUserRepository interface {
+	FindByRole(ctx context.Context, role string) ([]*models.User, error)
+}
+
+type readinessService struct {
+	lectureRepo ReadinessLectureRepository
+	meetRepo    ReadinessMeetRepository
+	userRepo    ReadinessUserRepository
+	shortLinks  ShortLinkService
+	rooms       RoomChecker
+	mailer      Mailer
+	rules       *readiness.Rules
+	days        int
+	reportHour  int
+	logger      *slog.Logger
+}
+
+func NewReadinessService(
+	lectureRepo ReadinessLectureRepository,
+	meetRepo ReadinessMeetRepository,
+	userRepo ReadinessUserRepository,
+	shortLinks ShortLinkService,
+	rooms RoomChecker,
+	mailer Mailer,
+	rules *readiness.Rules,
+	days int,
+	reportHour int,
+	logger *slog.Logger,
+) *readinessService {
+	return &readinessService{
+		lectureRepo: lectureRepo,
+		meetRepo:    meetRepo,
+		userRepo:    userRepo,
+		shortLinks:  shortLinks,
+		rooms:       rooms,
+		mailer:      mailer,
+		rules:       rules,
+		days:        days,
+		reportHour:  reportHour,
+		logger:      logger,
+	}
+}
+
+// Check проверяет лекции и мероприятия с сегодняшнего дня на days дней вперёд.
+// days < 1 — период из настроек. В ответ попадают только записи с замечаниями.
+func (s *readinessService) Check(ctx context.Context, days int) (*entitys.Readiness, error) {
+	if days < 1 {
+		days = s.days
+	}
+
+	start := startOfDay(timezone.Now())
+	end := start.AddDate(0, 0, days)
+
+	lectures, err := s.lectureRepo.FindByDateRange(ctx, start, end.Add(-time.Second))
+	if err != nil {
+		return nil, err
+	}
+
+	meets, err := s.meetRepo.FindOverlapping(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &entitys.Readiness{
+		Start:   start,
+		End:     end.AddDate(0, 0, -1),
+		Checked: len(lectures) + len(meets),
+		Items:   []entitys.ReadinessItem{},
+	}
+
+	for _, lecture := range lectures {
+		issues, err := s.lectureIssues(ctx, lecture)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(issues) == 0 {
+			continue
+		}
+
+		item := entitys.ReadinessItem{
+			Kind:   entitys.AssignmentLecture,
+			ID:     lecture.ID,
+			Title:  joinNonEmpty(" — ", lecture.Group, lecture.Lector),
+			Date:   startOfDay(lecture.Date),
+			Admin:  derefString(lecture.Admin),
+			Issues: issues,
+		}
+
+		if lecture.Start != nil && lecture.End != nil {
+			item.Time = lecture.Start.String() + "–" + lecture.End.String()
+		}
+
+		result.Items = append(result.Items, item)
+	}
+
+	for _, meet := range meets {
+		issues, err := s.meetIssues(ctx, meet)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(issues) == 0 {
+			continue
+		}
+
+		start := meet.Start.In(timezone.Org())
+
+		result.Items = append(result.Items, entitys.ReadinessItem{
+			Kind:   entitys.AssignmentMeet,
+			ID:     meet.ID,
+			Title:  derefString(meet.EventName),
+			Date:   startOfDay(start),
+			Time:   start.Format("15:04") + "–" + meet.End.In(timezone.Org()).Format("15:04"),
+			Admin:  derefString(meet.Admin),
+			Issues: issues,
+		})
+	}
+
+	slices.SortStableFunc(result.Items, func(a, b entitys.ReadinessItem) int {
+		if c := a.Date.Compare(b.Date); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Time, b.Time)
+	})
+
+	return result, nil
+}
+
+func (s *readinessService) lectureIssues(ctx context.Context, lecture *models.Lecture) ([]entitys.ReadinessIssue, error) {
+	resp := mappers.LectureToDto(lecture, visibility.View{})
+
+	return s.evaluate(ctx, readiness.EntityLecture, resp, func(check string) ([]string, bool, error) {
+		switch check {
+		case readiness.CheckTimeOrder:
+			if lecture.Start != nil && lecture.End != nil && *lecture.End <= *lecture.Start {
+				return []string{lecture.Start.String() + "–" + lecture.End.String()}, false, nil
+			}
+		case readiness.CheckShortLink:
+			return s.shortLinkIssue(ctx, lecture.ShortURL, lecture.URL)
+		case readiness.CheckRoom:
+			warnings, err := s.rooms.Warnings(ctx, lecture.Location, lecture.Platform, nil)
+			return warnings, len(warnings) == 0, err
+		}
+
+		return nil, true, nil
+	})
+}
+
+func (s *readinessService) meetIssues(ctx context.Context, meet *models.Meet) ([]entitys.ReadinessIssue, error) {
+	resp := mappers.MeetToDto(meet, visibility.View{})
+
+	return s.evaluate(ctx, readiness.EntityMeet, resp, func(check string) ([]string, bool, error) {
+		switch check {
+		case readiness.CheckTimeOrder:
+			if meet.Start != nil && meet.End != nil && !meet.End.After(*meet.Start) {
+				return nil, false, nil
+			}
+		case readiness.CheckShortLink:
+			return s.shortLinkIssue(ctx, meet.ShortURL, meet.URL)
+		case readiness.CheckRoom:
+			warnings, err := s.rooms.Warnings(ctx, meet.Location, meet.Platform, splitDevices(meet.Devices))
+			return warnings, len(warnings) == 0, err
+		}
+
+		return nil, true, nil
+	})
+}
+
+// evaluate применяет правила вида записи. check выполняет проверку правила
+// и возвращает подробности и признак, что проверка пройдена.
+func (s *readinessService) evaluate(
+	ctx context.Context,
+	entity string,
+	resp any,
+	check func(check string) ([]string, bool, error),
+) ([]entitys.ReadinessIssue, error) {
+	var issues []entitys.ReadinessIssue
+
+	for _, rule := range s.rules.For(entity) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if !rule.Applies(resp) {
+			continue
+		}
+
+		details := rule.Missing(resp)
+		passed := len(details) == 0
+
+		if rule.Check != "" {
+			checkDetails, ok, err := check(rule.Check)
+			if err != nil {
+				return nil, err
+			}
+
+			details = append(details, checkDetails...)
+			passed = passed && ok
+		}
+
+		if passed {
+			continue
+		}
+
+		issues = append(issues, entitys.ReadinessIssue{
+			Rule:     rule.Name,
+			Severity: rule.Severity,
+			Message:  rule.Message,
+			Details:  details,
+		})
+	}
+
+	return issues, nil
+}
+
+// shortLinkIssue проверяет, что короткая ссылка существует и ведёт на url записи.
+func (s *readinessService) shortLinkIssue(ctx context.Context, code, url *string) ([]string, bool, error) {
+	if code == nil || *code == "" {
+		return nil, true, nil
+	}
+
+	target, err := s.shortLinks.Resolve(ctx, *code)
+	if errors.Is(err, common.ErrNotFound) {
+		return []string{fmt.Sprintf("short link %q does not exist", *code)}, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	if url == nil || strings.TrimSpace(*url) != strings.TrimSpace(*target) {
+		return []string{fmt.Sprintf("short link %q leads to %s", *code, *target)}, false, nil
+	}
+
+	return nil, true, nil
+}
+
+// AutoReport раз в сутки в заданный час рассылает отчёт о готовности
+// админам и модераторам с указанной почтой.
+func (s *readinessService) AutoReport() {
+	for {
+		time.Sleep(time.Until(nextDaily(time.Now(), utils.NewClock(s.reportHour, 0))))
+		s.report(context.Background())
+	}
+}
+
+// nextDaily возвращает ближайший после now момент времени суток at в поясе организации.
+func nextDaily(now time.Time, at utils.Clock) time.Time {
+	day := startOfDay(now.In(timezone.Org()))
+	next := at.On(day)
+
+	if !next.After(now) {
+		next = at.On(day.AddDate(0, 0, 1))
+	}
+
+	return next
+}
+
+func (s *readinessService) report(ctx context.Context) {
+	result, err := s.Check(ctx, s.days)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("readiness check failed: %v", err))
+		return
+	}
+
+	if len(result.Items) == 0 {
+		return
+	}
+
+	recipients, err := s.recipients(ctx)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("readiness report recipients: %v", err))
+		return
+	}
+
+	if len(recipients) == 0 {
+		s.logger.Warn("readiness report: no admins or moderators with email")
+		return
+	}
+
+	subject := fmt.Sprintf(
+		"Готовность расписания %s — %s: замечаний %d",
+		result.Start.Format("02.01.2006"), result.End.Format("02.01.2006"), len(result.Items),
+	)
+	body := reportBody(result)
+
+	for _, recipient := range recipients {
+		go s.mailer.Send(recipient, subject, body)
+	}
+}
+
+func (s *readinessService) recipients(ctx context.Context) ([]string, error) {
+	var emails []string
+
+	for _, role := range readinessRecipientRoles {
+		users, err := s.userRepo.FindByRole(ctx, role)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, user := range users {
+			if user.Email != nil && *user.Email != "" && !slices.Contains(emails, *user.Email) {
+				emails = append(emails, *user.Email)
+			}
+		}
+	}
+
+	return emails, nil
+}
+
+func reportBody(result *entitys.Readiness) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Проверено записей: %d, с замечаниями: %d\n", result.Checked, len(result.Items))
+
+	for _, item := range result.Items {
+		kind := "Лекция"
+		if item.Kind == entitys.AssignmentMeet {
+			kind = "Мероприятие"
+		}
+
+		fmt.Fprintf(&b, "\n%s #%d, %s %s %s\n", kind, item.ID, item.Date.Format("02.01.2006"), item.Time, item.Title)
+
+		if item.Admin != "" {
+			fmt.Fprintf(&b, "Админ: %s\n", item.Admin)
+		}
+
+		for _, issue := range item.Issues {
+			line := fmt.Sprintf("  [%s] %s", issue.Severity, issue.Message)
+			if len(issue.Details) > 0 {
+				line += ": " + strings.Join(issue.Details, "; ")
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+
+	return b.String()
+}
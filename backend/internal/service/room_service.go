@@ -0,0 +1,347 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"table-api/internal/handler/dto"
+	"table-api/internal/models"
+	common "table-api/pkg"
+	"table-api/pkg/timezone"
+	"table-api/pkg/utils"
+	"time"
+)
+
+type RoomRepository interface {
+	Create(ctx context.Context, room *models.Room) (*models.Room, error)
+	GetByID(ctx context.Context, id int) (*models.Room, error)
+	GetByLocationID(ctx context.Context, locationID int) (*models.Room, error)
+	List(ctx context.Context) ([]*models.Room, error)
+	Save(ctx context.Context, room *models.Room) (*models.Room, error)
+	Delete(ctx context.Context, id int) (*models.Room, error)
+}
+
+type RoomLectureRepository interface {
+	FindByExactDate(ctx context.Context, date time.Time) ([]*models.Lecture, error)
+}
+
+type RoomMeetRepository interface {
+	FindOverlapping(ctx context.Context, start, end time.Time) ([]*models.Meet, error)
+}
+
+// RoomChecker сверяет платформу и оборудование с возможностями выбранной аудитории
+// и знает часовой пояс места.
+type RoomChecker interface {
+	Warnings(ctx context.Context, location, platform *string, devices []string) ([]string, error)
+	Zone(ctx context.Context, location *string) (*time.Location, error)
+}
+
+type roomService struct {
+	roomRepo    RoomRepository
+	lectureRepo RoomLectureRepository
+	meetRepo    RoomMeetRepository
+	directories DirectoryResolver
+}
+
+func NewRoomService(
+	repo RoomRepository,
+	lectureRepo RoomLectureRepository,
+	meetRepo RoomMeetRepository,
+	directories DirectoryResolver,
+) *roomService {
+	return &roomService{
+		roomRepo:    repo,
+		lectureRepo: lectureRepo,
+		meetRepo:    meetRepo,
+		directories: directories,
+	}
+}
+
+func (r *roomService) Create(ctx context.Context, req dto.CreateRoomRequest) (*models.Room, error) {
+	location, err := r.directories.Resolve(ctx, models.DirectoryLocation, req.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	room := &models.Room{
+		LocationID: location.ID,
+		Building:   cleanOptional(req.Building),
+		Capacity:   req.Capacity,
+		Equipment:  uniqueNames(req.Equipment),
+		Platforms:  uniqueNames(req.Platforms),
+		TimeZone:   cleanOptional(req.TimeZone),
+	}
+
+	return r.roomRepo.Create(ctx, room)
+}
+
+func (r *roomService) Get(ctx context.Context, id int) (*models.Room, error) {
+	return r.roomRepo.GetByID(ctx, id)
+}
+
+func (r *roomService) Update(ctx context.Context, id int, req dto.UpdateRoomRequest) (*models.Room, error) {
+	room, err := r.roomRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Location != nil {
+		location, err := r.directories.Resolve(ctx, models.DirectoryLocation, *req.Location)
+		if err != nil {
+			return nil, err
+		}
+
+		room.LocationID = location.ID
+	}
+
+	if req.Building != nil {
+		room.Building = cleanOptional(req.Building)
+	}
+
+	if req.Capacity != nil {
+		room.Capacity = *req.Capacity
+	}
+
+	if req.Equipment != nil {
+		room.Equipment = uniqueNames(*req.Equipment)
+	}
+
+	if req.Platforms != nil {
+		room.Platforms = uniqueNames(*req.Platforms)
+	}
+
+	if req.TimeZone != nil {
+		room.TimeZone = cleanOptional(req.TimeZone)
+	}
+
+	return r.roomRepo.Save(ctx, room)
+}
+
+func (r *roomService) Remove(ctx context.Context, id int) (*models.Room, error) {
+	return r.roomRepo.Delete(ctx, id)
+}
+
+// List возвращает аудитории, подходящие под требования. Если задан слот,
+// отбрасываются аудитории, занятые в это время лекцией или мероприятием.
+func (r *roomService) List(ctx context.Context, filter dto.GetQueryRoomDto) ([]*models.Room, error) {
+	busy, err := r.busyLocations(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	rooms, err := r.roomRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.Room, 0, len(rooms))
+
+	for _, room := range rooms {
+		if filter.Building != nil && utils.NameKey(*filter.Building) != utils.NameKey(derefString(room.Building)) {
+			continue
+		}
+
+		if filter.MinCapacity != nil && room.Capacity < *filter.MinCapacity {
+			continue
+		}
+
+		if len(missingNames(room.Equipment, filter.Equipment)) > 0 {
+			continue
+		}
+
+		if filter.Platform != nil && len(missingNames(room.Platforms, []string{*filter.Platform})) > 0 {
+			continue
+		}
+
+		if roomIsBusy(room, busy) {
+			continue
+		}
+
+		result = append(result, room)
+	}
+
+	return result, nil
+}
+
+func (r *roomService) Warnings(ctx context.Context, location, platform *string, devices []string) ([]string, error) {
+	room, err := r.roomByLocation(ctx, location)
+	if err != nil {
+		return nil, err
+	}
+
+	// Аудитории нет в каталоге — сверять не с чем
+	if room == nil {
+		return nil, nil
+	}
+
+	entry := room.Location
+
+	var warnings []string
+
+	if platform != nil && utils.NameKey(*platform) != "" {
+		if len(missingNames(room.Platforms, []string{*platform})) > 0 {
+			warnings = append(warnings, fmt.Sprintf("room %q does not support platform %q", entry.Name, *platform))
+		}
+	}
+
+	if missing := missingNames(room.Equipment, devices); len(missing) > 0 {
+		warnings = append(warnings, fmt.Sprintf("room %q has no %s", entry.Name, strings.Join(missing, ", ")))
+	}
+
+	return warnings, nil
+}
+
+// Zone возвращает часовой пояс места; без аудитории или своего пояса — пояс организации.
+func (r *roomService) Zone(ctx context.Context, location *string) (*time.Location, error) {
+	room, err := r.roomByLocation(ctx, location)
+	if err != nil {
+		return nil, err
+	}
+
+	if room == nil || room.TimeZone == nil {
+		return timezone.Org(), nil
+	}
+
+	return time.LoadLocation(*room.TimeZone)
+}
+
+// roomByLocation находит аудиторию по названию места. Неизвестное место даёт nil.
+func (r *roomService) roomByLocation(ctx context.Context, location *string) (*models.Room, error) {
+	if location == nil || utils.NameKey(*location) == "" {
+		return nil, nil
+	}
+
+	entry, err := r.directories.Lookup(ctx, models.DirectoryLocation, *location)
+	if errors.Is(err, common.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	room, err := r.roomRepo.GetByLocationID(ctx, entry.ID)
+	if errors.Is(err, common.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return room, nil
+}
+
+// busyLocations возвращает ключи названий мест, занятых в слоте фильтра.
+func (r *roomService) busyLocations(ctx context.Context, filter dto.GetQueryRoomDto) (map[string]struct{}, error) {
+	if filter.Date == nil && filter.Start == nil && filter.End == nil {
+		return nil, nil
+	}
+
+	if filter.Date == nil || filter.Start == nil || filter.End == nil {
+		return nil, fmt.Errorf("%w: date, start and end must be given together", common.ErrInvalidInput)
+	}
+
+	if *filter.End <= *filter.Start {
+		return nil, fmt.Errorf("%w: end must be after start", common.ErrInvalidInput)
+	}
+
+	busy := map[string]struct{}{}
+
+	lectures, err := r.lectureRepo.FindByExactDate(ctx, *filter.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, lecture := range lectures {
+		start, end, ok := lectureInterval(lecture)
+		if ok && lecture.Location != nil && start < *filter.End && *filter.Start < end {
+			busy[utils.NameKey(*lecture.Location)] = struct{}{}
+		}
+	}
+
+	meets, err := r.meetRepo.FindOverlapping(ctx, filter.Start.On(*filter.Date), filter.End.On(*filter.Date))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, meet := range meets {
+		if meet.Location != nil {
+			busy[utils.NameKey(*meet.Location)] = struct{}{}
+		}
+	}
+
+	return busy, nil
+}
+
+func roomIsBusy(room *models.Room, busy map[string]struct{}) bool {
+	for _, key := range append([]string{room.Location.Key}, room.Location.AliasKeys...) {
+		if _, ok := busy[key]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// missingNames возвращает требования, которых нет среди available, без учёта регистра.
+func missingNames(available, required []string) []string {
+	keys := make(map[string]struct{}, len(available))
+	for _, a := range available {
+		keys[utils.NameKey(a)] = struct{}{}
+	}
+
+	var missing []string
+	for _, r := range required {
+		if _, ok := keys[utils.NameKey(r)]; !ok && utils.NameKey(r) != "" {
+			missing = append(missing, utils.CleanName(r))
+		}
+	}
+
+	return missing
+}
+
+// uniqueNames убирает пустые значения и повторы без учёта регистра.
+func uniqueNames(values []string) []string {
+	seen := map[string]struct{}{}
+	result := []string{}
+
+	for _, v := range values {
+		key := utils.NameKey(v)
+		if _, ok := seen[key]; ok || key == "" {
+			continue
+		}
+
+		seen[key] = struct{}{}
+		result = append(result, utils.CleanName(v))
+	}
+
+	return result
+}
+
+// splitDevices разбирает свободный список устройств мероприятия: "камера, микрофон; проектор".
+func splitDevices(devices *string) []string {
+	if devices == nil {
+		return nil
+	}
+
+	return strings.FieldsFunc(*devices, func(r rune) bool {
+		return r == ',' || r == ';' || r == '\n'
+	})
+}
+
+func cleanOptional(s *string) *string {
+	if s == nil || utils.NameKey(*s) == "" {
+		return nil
+	}
+
+	cleaned := utils.CleanName(*s)
+	return &cleaned
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
@@ -37,6 +37,16 @@ func (s *shortLinkService) GetUrl(ctx context.Context, code string) (*string, er
 	return &shortLink.URL, nil
 }
 
+// Resolve возвращает исходный URL по коду без учёта перехода
+func (s *shortLinkService) Resolve(ctx context.Context, code string) (*string, error) {
+	shortLink, err := s.shortLinkRepo.GetByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	return &shortLink.URL, nil
+}
+
 func (s *shortLinkService) ShortUrl(ctx context.Context, url string) (*string, error) {
 	var code string
 
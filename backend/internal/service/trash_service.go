@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// TrashPurger окончательно удаляет записи, помещённые в корзину раньше before.
+type TrashPurger interface {
+	PurgeTrash(ctx context.Context, before time.Time) (int64, error)
+}
+
+type trashService struct {
+	purgers   map[string]TrashPurger
+	retention time.Duration
+	logger    *slog.Logger
+}
+
+func NewTrashService(retention time.Duration, logger *slog.Logger, purgers map[string]TrashPurger) *trashService {
+	return &trashService{purgers: purgers, retention: retention, logger: logger}
+}
+
+// AutoPurge периодически очищает корзину от записей старше срока хранения.
+func (t *trashService) AutoPurge(timeout time.Duration) {
+	for {
+		t.purge(context.Background())
+		time.Sleep(timeout)
+	}
+}
+
+func (t *trashService) purge(ctx context.Context) {
+	before := time.Now().Add(-t.retention)
+
+	for name, purger := range t.purgers {
+		purged, err := purger.PurgeTrash(ctx, before)
+		if err != nil {
+			t.logger.Error(fmt.Sprintf("trash purge of %s failed: %v", name, err))
+			continue
+		}
+
+		if purged > 0 {
+			t.logger.Info(fmt.Sprintf("trash purge: removed %d %s", purged, name))
+		}
+	}
+}
+
+// trashPage приводит параметры пагинации корзины к допустимым значениям.
+func trashPage(page, limit int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	return page, limit
+}
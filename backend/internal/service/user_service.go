@@ -2,12 +2,16 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"table-api/internal/entitys"
 	"table-api/internal/handler/dto"
 	"table-api/internal/mappers"
 	"table-api/internal/models"
 	common "table-api/pkg"
 	"table-api/pkg/hasher"
+	"table-api/pkg/utils"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -24,6 +28,17 @@ type UserRepository interface {
 	Search(ctx context.Context, searchTerm string) ([]*models.User, error)
 	Update(ctx context.Context, id uuid.UUID, updates map[string]interface{}) (*models.User, error)
 	Delete(ctx context.Context, id uuid.UUID) (*models.User, error)
+	ListDeleted(ctx context.Context, page, limit int) ([]*models.User, *entitys.Pagination, error)
+	Restore(ctx context.Context, id uuid.UUID) (*models.User, error)
+	Purge(ctx context.Context, id uuid.UUID) (*models.User, error)
+	PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error)
+	FindByLoginOrName(ctx context.Context, name string) (*models.User, error)
+	FindByRole(ctx context.Context, role string) ([]*models.User, error)
+}
+
+// AdminResolver находит пользователя, которого назначают админом лекции или мероприятия.
+type AdminResolver interface {
+	Admin(ctx context.Context, name string) (*models.User, error)
 }
 
 type userService struct {
@@ -89,6 +104,9 @@ func (u *userService) Update(ctx context.Context, id uuid.UUID, dto dto.UpdateUs
 	if dto.Name != nil {
 		updates["name"] = *dto.Name
 	}
+	if dto.Email != nil {
+		updates["email"] = *dto.Email
+	}
 	if dto.Role != nil {
 		updates["role"] = *dto.Role
 	}
@@ -116,3 +134,48 @@ func (u *userService) Remove(ctx context.Context, id uuid.UUID) (*models.User, e
 
 	return deletedUser, nil
 }
+
+func (u *userService) ListTrash(ctx context.Context, page, limit int) ([]*models.User, *entitys.Pagination, error) {
+	page, limit = trashPage(page, limit)
+
+	return u.userRepo.ListDeleted(ctx, page, limit)
+}
+
+func (u *userService) Restore(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	return u.userRepo.Restore(ctx, id)
+}
+
+func (u *userService) Purge(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	return u.userRepo.Purge(ctx, id)
+}
+
+func (u *userService) PurgeTrash(ctx context.Context, before time.Time) (int64, error) {
+	return u.userRepo.PurgeDeletedBefore(ctx, before)
+}
+
+// Admin находит пользователя по логину или имени.
+func (u *userService) Admin(ctx context.Context, name string) (*models.User, error) {
+	return u.userRepo.FindByLoginOrName(ctx, name)
+}
+
+// findAdmin находит пользователя для поля Admin. Неизвестное имя — ошибка ввода.
+func findAdmin(ctx context.Context, admins AdminResolver, name string) (*models.User, error) {
+	user, err := admins.Admin(ctx, name)
+	if errors.Is(err, common.ErrNotFound) {
+		return nil, fmt.Errorf("%w: admin %q is not a user", common.ErrInvalidInput, utils.CleanName(name))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// adminName — подпись пользователя в поле Admin: имя, а без него логин.
+func adminName(user *models.User) string {
+	if user.Name != nil && utils.NameKey(*user.Name) != "" {
+		return utils.CleanName(*user.Name)
+	}
+
+	return user.Login
+}
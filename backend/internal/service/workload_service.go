@@ -0,0 +1,563 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"table-api/internal/entitys"
+	"table-api/internal/handler/dto"
+	"table-api/internal/models"
+	common "table-api/pkg"
+	"table-api/pkg/timezone"
+	"table-api/pkg/utils"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Роль пользователей, между которыми распределяются лекции и мероприятия
+const technicianRole = "technician"
+
+type WorkloadLectureRepository interface {
+	FindByDateRange(ctx context.Context, startDate, endDate time.Time) ([]*models.Lecture, error)
+}
+
+type WorkloadMeetRepository interface {
+	FindOverlapping(ctx context.Context, start, end time.Time) ([]*models.Meet, error)
+}
+
+type WorkloadUserRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*models.User, error)
+	FindByRole(ctx context.Context, role string) ([]*models.User, error)
+}
+
+type AvailabilityRepository interface {
+	ListByUsers(ctx context.Context, userIDs []uuid.UUID) ([]*models.Availability, error)
+	Replace(ctx context.Context, userID uuid.UUID, slots []*models.Availability) ([]*models.Availability, error)
+}
+
+// LectureAdminAssigner назначает админа лекциям с записью в журнал аудита.
+type LectureAdminAssigner interface {
+	AssignAdmin(ctx context.Context, ids []int, admin *models.User) ([]*models.Lecture, error)
+}
+
+// MeetAdminAssigner назначает админа мероприятию с записью в журнал аудита.
+type MeetAdminAssigner interface {
+	AssignAdmin(ctx context.Context, id int, admin *models.User) (*models.Meet, error)
+}
+
+type workloadService struct {
+	lectureRepo      WorkloadLectureRepository
+	meetRepo         WorkloadMeetRepository
+	userRepo         WorkloadUserRepository
+	availabilityRepo AvailabilityRepository
+	lectures         LectureAdminAssigner
+	meets            MeetAdminAssigner
+	rooms            RoomChecker
+	tx               Transactor
+}
+
+func NewWorkloadService(
+	lectureRepo WorkloadLectureRepository,
+	meetRepo WorkloadMeetRepository,
+	userRepo WorkloadUserRepository,
+	availabilityRepo AvailabilityRepository,
+	lectures LectureAdminAssigner,
+	meets MeetAdminAssigner,
+	rooms RoomChecker,
+	tx Transactor,
+) *workloadService {
+	return &workloadService{
+		lectureRepo:      lectureRepo,
+		meetRepo:         meetRepo,
+		userRepo:         userRepo,
+		availabilityRepo: availabilityRepo,
+		lectures:         lectures,
+		meets:            meets,
+		rooms:            rooms,
+		tx:               tx,
+	}
+}
+
+// workItem — лекция или мероприятие как отрезок работы админа.
+// Лекция без времени начала и конца считается, но без часов.
+type workItem struct {
+	kind    string
+	id      int
+	start   time.Time
+	end     time.Time
+	timed   bool
+	adminID *uuid.UUID
+	admin   string
+}
+
+func (w workItem) minutes() int {
+	if !w.timed {
+		return 0
+	}
+
+	return int(w.end.Sub(w.start).Minutes())
+}
+
+func (w workItem) assigned() bool {
+	return w.adminID != nil || w.admin != ""
+}
+
+// Report считает нагрузку админов по неделям за период [start, end] по датам.
+// Техники без записей тоже попадают в отчёт, чтобы был виден простой.
+func (s *workloadService) Report(ctx context.Context, start, end time.Time) (*entitys.Workload, error) {
+	items, err := s.collect(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	technicians, err := s.userRepo.FindByRole(ctx, technicianRole)
+	if err != nil {
+		return nil, err
+	}
+
+	admins := make(map[string]*entitys.AdminWorkload)
+	for _, technician := range technicians {
+		id := technician.ID
+		admins[id.String()] = &entitys.AdminWorkload{AdminID: &id, Admin: adminName(technician)}
+	}
+
+	unassigned := make(map[string]*entitys.WorkloadWeek)
+
+	for _, item := range items {
+		if !item.assigned() {
+			addWorkload(unassigned, item)
+			continue
+		}
+
+		// Имена без пользователя остались от старых записей и считаются отдельно
+		key := "name:" + utils.NameKey(item.admin)
+		if item.adminID != nil {
+			key = item.adminID.String()
+		}
+
+		workload, ok := admins[key]
+		if !ok {
+			workload = &entitys.AdminWorkload{AdminID: item.adminID, Admin: item.admin}
+			admins[key] = workload
+		}
+
+		workload.Weeks = addWeek(workload.Weeks, item)
+		workload.Total.Minutes += item.minutes()
+		countWorkItem(&workload.Total, item)
+	}
+
+	result := &entitys.Workload{Start: startOfDay(start), End: startOfDay(end)}
+
+	for _, workload := range admins {
+		result.Admins = append(result.Admins, workload)
+	}
+
+	slices.SortFunc(result.Admins, func(a, b *entitys.AdminWorkload) int {
+		if a.Total.Minutes != b.Total.Minutes {
+			return b.Total.Minutes - a.Total.Minutes
+		}
+		return strings.Compare(a.Admin, b.Admin)
+	})
+
+	for _, week := range unassigned {
+		result.Unassigned = append(result.Unassigned, week)
+	}
+	sortWeeks(result.Unassigned)
+
+	return result, nil
+}
+
+// AutoAssign раздаёт техникам лекции и мероприятия без админа за период [start, end].
+// Записи обходятся по времени начала; из техников, у которых запись попадает в окно
+// доступности и не пересекается с их другими записями, выбирается наименее
+// загруженный на этой неделе. В режиме dryRun назначения только возвращаются.
+func (s *workloadService) AutoAssign(ctx context.Context, start, end time.Time, dryRun bool) (*entitys.AutoAssignment, error) {
+	var result *entitys.AutoAssignment
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		plan, technicians, err := s.plan(ctx, start, end)
+		if err != nil {
+			return err
+		}
+
+		result = plan
+		if dryRun {
+			return nil
+		}
+
+		lectureIDs := make(map[uuid.UUID][]int)
+		for _, assignment := range plan.Assigned {
+			switch assignment.Kind {
+			case entitys.AssignmentLecture:
+				lectureIDs[assignment.AdminID] = append(lectureIDs[assignment.AdminID], assignment.ID)
+			case entitys.AssignmentMeet:
+				if _, err := s.meets.AssignAdmin(ctx, assignment.ID, technicians[assignment.AdminID]); err != nil {
+					return err
+				}
+			}
+		}
+
+		for adminID, ids := range lectureIDs {
+			if _, err := s.lectures.AssignAdmin(ctx, ids, technicians[adminID]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *workloadService) plan(
+	ctx context.Context,
+	start, end time.Time,
+) (*entitys.AutoAssignment, map[uuid.UUID]*models.User, error) {
+	items, err := s.collect(ctx, start, end)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	technicians, err := s.userRepo.FindByRole(ctx, technicianRole)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(technicians) == 0 {
+		return nil, nil, fmt.Errorf("%w: there are no users with role %q", common.ErrInvalidInput, technicianRole)
+	}
+
+	byID := make(map[uuid.UUID]*models.User, len(technicians))
+	ids := make([]uuid.UUID, 0, len(technicians))
+	for _, technician := range technicians {
+		byID[technician.ID] = technician
+		ids = append(ids, technician.ID)
+	}
+
+	slots, err := s.availabilityRepo.ListByUsers(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	availability := make(map[uuid.UUID][]*models.Availability)
+	for _, slot := range slots {
+		availability[slot.UserID] = append(availability[slot.UserID], slot)
+	}
+
+	// Уже назначенные записи занимают время техника и входят в его недельную нагрузку
+	busy := make(map[uuid.UUID][]workItem)
+	load := make(map[uuid.UUID]map[string]int)
+	for _, id := range ids {
+		load[id] = make(map[string]int)
+	}
+
+	var pending []workItem
+	for _, item := range items {
+		switch {
+		case !item.assigned():
+			pending = append(pending, item)
+		case item.adminID != nil && byID[*item.adminID] != nil:
+			busy[*item.adminID] = append(busy[*item.adminID], item)
+			load[*item.adminID][isoWeek(item.start)] += item.minutes()
+		}
+	}
+
+	result := &entitys.AutoAssignment{Assigned: []entitys.Assignment{}, Skipped: []entitys.SkippedAssignment{}}
+
+	for _, item := range pending {
+		if !item.timed {
+			result.Skipped = append(result.Skipped, entitys.SkippedAssignment{
+				Kind: item.kind, ID: item.id, Reason: "start and end time are not set",
+			})
+			continue
+		}
+
+		week := isoWeek(item.start)
+		available := false
+
+		var chosen *models.User
+		for _, technician := range technicians {
+			if !withinAvailability(availability[technician.ID], item) {
+				continue
+			}
+			available = true
+
+			if overlapsWork(busy[technician.ID], item) {
+				continue
+			}
+
+			if chosen == nil || load[technician.ID][week] < load[chosen.ID][week] {
+				chosen = technician
+			}
+		}
+
+		if chosen == nil {
+			reason := "no technician is available at this time"
+			if available {
+				reason = "all available technicians are busy at this time"
+			}
+
+			result.Skipped = append(result.Skipped, entitys.SkippedAssignment{Kind: item.kind, ID: item.id, Reason: reason})
+			continue
+		}
+
+		busy[chosen.ID] = append(busy[chosen.ID], item)
+		load[chosen.ID][week] += item.minutes()
+
+		result.Assigned = append(result.Assigned, entitys.Assignment{
+			Kind:    item.kind,
+			ID:      item.id,
+			Start:   item.start,
+			End:     item.end,
+			AdminID: chosen.ID,
+			Admin:   adminName(chosen),
+		})
+	}
+
+	return result, byID, nil
+}
+
+// collect собирает лекции и мероприятия за период, отсортированные по началу.
+func (s *workloadService) collect(ctx context.Context, start, end time.Time) ([]workItem, error) {
+	if end.Before(start) {
+		return nil, fmt.Errorf("%w: end date is before start date", common.ErrInvalidInput)
+	}
+
+	lectures, err := s.lectureRepo.FindByDateRange(ctx, startOfDay(start), endOfDay(end))
+	if err != nil {
+		return nil, err
+	}
+
+	meets, err := s.meetRepo.FindOverlapping(ctx, startOfDay(start), startOfDay(end).AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]workItem, 0, len(lectures)+len(meets))
+	zones := make(map[string]*time.Location)
+
+	for _, lecture := range lectures {
+		item := workItem{
+			kind:    entitys.AssignmentLecture,
+			id:      lecture.ID,
+			start:   startOfDay(lecture.Date),
+			adminID: lecture.AdminID,
+			admin:   strings.TrimSpace(derefString(lecture.Admin)),
+		}
+		item.end = item.start
+
+		if from, to, ok := lectureInterval(lecture); ok {
+			location := strings.TrimSpace(derefString(lecture.Location))
+
+			zone, ok := zones[location]
+			if !ok {
+				if zone, err = s.rooms.Zone(ctx, lecture.Location); err != nil {
+					return nil, err
+				}
+				zones[location] = zone
+			}
+
+			day := time.Date(item.start.Year(), item.start.Month(), item.start.Day(), 0, 0, 0, 0, zone)
+			item.start, item.end, item.timed = from.On(day), to.On(day), true
+		}
+
+		items = append(items, item)
+	}
+
+	for _, meet := range meets {
+		items = append(items, workItem{
+			kind:    entitys.AssignmentMeet,
+			id:      meet.ID,
+			start:   *meet.Start,
+			end:     *meet.End,
+			timed:   meet.End.After(*meet.Start),
+			adminID: meet.AdminID,
+			admin:   strings.TrimSpace(derefString(meet.Admin)),
+		})
+	}
+
+	slices.SortStableFunc(items, func(a, b workItem) int {
+		if c := a.start.Compare(b.start); c != 0 {
+			return c
+		}
+		if a.kind != b.kind {
+			return strings.Compare(a.kind, b.kind)
+		}
+		return a.id - b.id
+	})
+
+	return items, nil
+}
+
+// GetAvailability возвращает окна доступности техника.
+func (s *workloadService) GetAvailability(ctx context.Context, userID uuid.UUID) ([]*models.Availability, error) {
+	if _, err := s.technician(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return s.availabilityRepo.ListByUsers(ctx, []uuid.UUID{userID})
+}
+
+// SetAvailability заменяет окна доступности техника. Техник может менять только свои окна.
+func (s *workloadService) SetAvailability(
+	ctx context.Context,
+	userID uuid.UUID,
+	req dto.SetAvailabilityRequest,
+) ([]*models.Availability, error) {
+	if role, _ := ctx.Value("role").(string); role == technicianRole {
+		if actor, _ := ctx.Value("userID").(uuid.UUID); actor != userID {
+			return nil, fmt.Errorf("%w: technicians can only change their own availability", common.ErrForbidden)
+		}
+	}
+
+	if _, err := s.technician(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	slots := make([]*models.Availability, 0, len(req.Slots))
+	for _, slot := range req.Slots {
+		if slot.End <= slot.Start {
+			return nil, fmt.Errorf("%w: availability on weekday %d ends before it starts", common.ErrInvalidInput, slot.Weekday)
+		}
+
+		slots = append(slots, &models.Availability{Weekday: slot.Weekday, Start: slot.Start, End: slot.End})
+	}
+
+	slices.SortFunc(slots, func(a, b *models.Availability) int {
+		if a.Weekday != b.Weekday {
+			return a.Weekday - b.Weekday
+		}
+		return int(a.Start - b.Start)
+	})
+
+	for i := 1; i < len(slots); i++ {
+		if slots[i].Weekday == slots[i-1].Weekday && slots[i].Start < slots[i-1].End {
+			return nil, fmt.Errorf("%w: availability windows on weekday %d overlap", common.ErrInvalidInput, slots[i].Weekday)
+		}
+	}
+
+	var saved []*models.Availability
+
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		saved, err = s.availabilityRepo.Replace(ctx, userID, slots)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return saved, nil
+}
+
+func (s *workloadService) technician(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Role != technicianRole {
+		return nil, fmt.Errorf("%w: user %s is not a technician", common.ErrInvalidInput, user.Login)
+	}
+
+	return user, nil
+}
+
+// withinAvailability проверяет, что запись целиком лежит в одном окне доступности.
+// Окна задаются в поясе организации; техник без окон доступен всегда.
+func withinAvailability(slots []*models.Availability, item workItem) bool {
+	if len(slots) == 0 {
+		return true
+	}
+
+	start := item.start.In(timezone.Org())
+	end := item.end.In(timezone.Org())
+	day := startOfDay(start)
+
+	if end.After(day.AddDate(0, 0, 1)) {
+		return false
+	}
+
+	from := utils.NewClock(start.Hour(), start.Minute())
+	to := utils.Clock(end.Sub(day).Minutes())
+
+	for _, slot := range slots {
+		if slot.Weekday == int(start.Weekday()) && slot.Start <= from && to <= slot.End {
+			return true
+		}
+	}
+
+	return false
+}
+
+func overlapsWork(busy []workItem, item workItem) bool {
+	for _, other := range busy {
+		if other.timed && item.start.Before(other.end) && other.start.Before(item.end) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isoWeek возвращает ISO-неделю момента в поясе организации, например "2025-W10".
+func isoWeek(t time.Time) string {
+	year, week := t.In(timezone.Org()).ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
+
+// weekStart возвращает понедельник недели момента t.
+func weekStart(t time.Time) time.Time {
+	day := startOfDay(t)
+	offset := (int(day.Weekday()) + 6) % 7
+
+	return day.AddDate(0, 0, -offset)
+}
+
+func countWorkItem(week *entitys.WorkloadWeek, item workItem) {
+	if item.kind == entitys.AssignmentMeet {
+		week.Meets++
+	} else {
+		week.Lectures++
+	}
+}
+
+func addWorkload(weeks map[string]*entitys.WorkloadWeek, item workItem) {
+	key := isoWeek(item.start)
+
+	week, ok := weeks[key]
+	if !ok {
+		week = &entitys.WorkloadWeek{Week: key, Start: weekStart(item.start)}
+		weeks[key] = week
+	}
+
+	week.Minutes += item.minutes()
+	countWorkItem(week, item)
+}
+
+func addWeek(weeks []*entitys.WorkloadWeek, item workItem) []*entitys.WorkloadWeek {
+	key := isoWeek(item.start)
+
+	index := slices.IndexFunc(weeks, func(week *entitys.WorkloadWeek) bool { return week.Week == key })
+	if index < 0 {
+		weeks = append(weeks, &entitys.WorkloadWeek{Week: key, Start: weekStart(item.start)})
+		sortWeeks(weeks)
+		index = slices.IndexFunc(weeks, func(week *entitys.WorkloadWeek) bool { return week.Week == key })
+	}
+
+	weeks[index].Minutes += item.minutes()
+	countWorkItem(weeks[index], item)
+
+	return weeks
+}
+
+func sortWeeks(weeks []*entitys.WorkloadWeek) {
+	slices.SortFunc(weeks, func(a, b *entitys.WorkloadWeek) int {
+		return a.Start.Compare(b.Start)
+	})
+}
@@ -0,0 +1,246 @@
+package visibility
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"table-api/internal/handler/dto"
+	"table-api/pkg/utils"
+)
+
+// Режимы показа поля
+const (
+	FieldShown  = "show"
+	FieldMasked = "mask"
+	FieldHidden = "hide"
+)
+
+// Подписчики календарных лент не входят в систему,
+// для них правила задаются под этим именем роли.
+const RoleFeed = "feed"
+
+// Rules — режим показа по имени поля в JSON-ответе. Поля без правила видны.
+type Rules map[string]string
+
+// View — правила для сущностей, которые видит одна роль.
+type View struct {
+	Lecture Rules `json:"lecture"`
+	Meet    Rules `json:"meet"`
+}
+
+// Entity возвращает правила по виду записи журнала аудита: "lecture" или "meet".
+func (v View) Entity(entityType string) Rules {
+	switch entityType {
+	case "lecture":
+		return v.Lecture
+	case "meet":
+		return v.Meet
+	}
+
+	return nil
+}
+
+// Snapshot — вид для журнала аудита: ключи трансляций в нём только маской.
+var Snapshot = View{Lecture: Rules{"streamKey": FieldMasked}}
+
+// Policy сопоставляет ролям их вид. Роль без записи получает Default.
+type Policy struct {
+	Default View            `json:"default"`
+	Roles   map[string]View `json:"roles"`
+}
+
+// DefaultPolicy действует, пока политика не задана файлом.
+func DefaultPolicy() *Policy {
+	pii := Rules{"customerName": FieldHidden, "email": FieldHidden, "phone": FieldHidden}
+
+	return &Policy{
+		Default: View{Lecture: Rules{"streamKey": FieldHidden}, Meet: pii},
+		Roles: map[string]View{
+			"admin":      {},
+			"moderator":  {Lecture: Rules{"streamKey": FieldMasked}},
+			"viewer":     {Lecture: Rules{"streamKey": FieldMasked}},
+			"technician": {Lecture: Rules{"streamKey": FieldMasked}, Meet: pii},
+			RoleFeed:     {Lecture: Rules{"streamKey": FieldHidden, "admin": FieldHidden, "adminId": FieldHidden}},
+		},
+	}
+}
+
+// Load читает политику из JSON-файла. Пустой путь — политика по умолчанию.
+func Load(path string) (*Policy, error) {
+	if path == "" {
+		return DefaultPolicy(), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read field visibility policy: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var policy Policy
+	if err := decoder.Decode(&policy); err != nil {
+		return nil, fmt.Errorf("invalid field visibility policy: %w", err)
+	}
+
+	if err := policy.validate(); err != nil {
+		return nil, fmt.Errorf("invalid field visibility policy: %w", err)
+	}
+
+	return &policy, nil
+}
+
+// For возвращает вид для роли из токена.
+func (p *Policy) For(role string) View {
+	if view, ok := p.Roles[role]; ok {
+		return view
+	}
+
+	return p.Default
+}
+
+func (p *Policy) validate() error {
+	lectureFields := jsonFields(reflect.TypeOf(dto.LectureResponse{}))
+	meetFields := jsonFields(reflect.TypeOf(dto.MeetResponse{}))
+
+	views := map[string]View{"default": p.Default}
+	for role, view := range p.Roles {
+		views[role] = view
+	}
+
+	for role, view := range views {
+		if err := view.Lecture.validate(lectureFields); err != nil {
+			return fmt.Errorf("%s.lecture: %w", role, err)
+		}
+		if err := view.Meet.validate(meetFields); err != nil {
+			return fmt.Errorf("%s.meet: %w", role, err)
+		}
+	}
+
+	return nil
+}
+
+func (r Rules) validate(fields map[string]reflect.Type) error {
+	for field, mode := range r {
+		t, ok := fields[field]
+		if !ok || field == "id" {
+			return fmt.Errorf("unknown field %q", field)
+		}
+
+		switch mode {
+		case FieldShown, FieldHidden:
+		case FieldMasked:
+			if t != reflect.TypeOf((*string)(nil)) {
+				return fmt.Errorf("field %q cannot be masked", field)
+			}
+		default:
+			return fmt.Errorf("unknown mode %q for field %q", mode, field)
+		}
+	}
+
+	return nil
+}
+
+// Apply применяет правила к DTO ответа по указателю.
+// Скрытое поле обнуляется, маскируемое сохраняет последние четыре символа.
+func Apply(resp any, rules Rules) {
+	if len(rules) == 0 {
+		return
+	}
+
+	v := reflect.ValueOf(resp).Elem()
+	t := v.Type()
+
+	for i := 0; i < v.NumField(); i++ {
+		name := jsonName(t.Field(i))
+
+		switch rules[name] {
+		case FieldHidden:
+			v.Field(i).SetZero()
+		case FieldMasked:
+			if value, ok := v.Field(i).Interface().(*string); ok && value != nil && *value != "" {
+				masked := utils.Mask(*value)
+				v.Field(i).Set(reflect.ValueOf(&masked))
+			}
+		}
+	}
+}
+
+// Value применяет правило поля name к значению вне DTO, например к значению
+// из журнала аудита. Для скрытого поля возвращает false.
+func Value(rules Rules, name string, value any) (any, bool) {
+	switch rules[name] {
+	case FieldHidden:
+		return nil, false
+	case FieldMasked:
+		if s, ok := value.(string); ok && s != "" {
+			return utils.Mask(s), true
+		}
+	}
+
+	return value, true
+}
+
+// ApplyJSON применяет правила к готовому JSON-ответу, например к ответу,
+// сохранённому для повтора по Idempotency-Key. Правила действуют на поля
+// объектов любой вложенности; пустое тело возвращается как есть.
+func ApplyJSON(body []byte, rules Rules) ([]byte, error) {
+	if len(rules) == 0 || len(bytes.TrimSpace(body)) == 0 {
+		return body, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var document any
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(applyValue(document, rules))
+}
+
+func applyValue(value any, rules Rules) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for name, field := range v {
+			field, ok := Value(rules, name, field)
+			if !ok {
+				delete(v, name)
+				continue
+			}
+
+			v[name] = applyValue(field, rules)
+		}
+	case []any:
+		for i := range v {
+			v[i] = applyValue(v[i], rules)
+		}
+	}
+
+	return value
+}
+
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		if name := jsonName(t.Field(i)); name != "" {
+			fields[name] = t.Field(i).Type
+		}
+	}
+
+	return fields
+}
+
+func jsonName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "-" {
+		return ""
+	}
+
+	return name
+}
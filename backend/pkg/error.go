@@ -8,5 +8,25 @@ var (
 	ErrInvalidInput  = errors.New("invalid input")
 	ErrUnauthorized  = errors.New("unauthorized")
 	ErrForbidden     = errors.New("forbidden")
+	ErrConflict      = errors.New("conflict")
 	ErrInternal      = errors.New("internal error")
+	// Запись изменилась после того, как клиент её прочитал
+	ErrPreconditionFailed = errors.New("precondition failed")
 )
+
+// StaleError — запись уже изменена другим пользователем. Current — её текущее состояние.
+type StaleError struct {
+	Current any
+}
+
+func (e *StaleError) Error() string {
+	return "record has been modified by another user"
+}
+
+func (e *StaleError) Unwrap() error {
+	return ErrPreconditionFailed
+}
+
+func (e *StaleError) Details() any {
+	return e.Current
+}
@@ -7,6 +7,11 @@ import (
 	common "table-api/pkg"
 )
 
+// detailedError — ошибка со структурированными подробностями для клиента
+type detailedError interface {
+	Details() any
+}
+
 func JsonResponse(w http.ResponseWriter, data any, code int) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(code)
@@ -20,19 +25,42 @@ func ErrorResponse(w http.ResponseWriter, message string, code int) {
 	}, code)
 }
 
+func DetailedErrorResponse(w http.ResponseWriter, message string, details any, code int) {
+	JsonResponse(w, map[string]any{
+		"status":  "error",
+		"message": message,
+		"details": details,
+	}, code)
+}
+
 func HandleErrorResponse(w http.ResponseWriter, err error) {
+	var code int
+
 	switch {
 	case errors.Is(err, common.ErrNotFound):
-		ErrorResponse(w, err.Error(), http.StatusNotFound)
+		code = http.StatusNotFound
 	case errors.Is(err, common.ErrAlreadyExists):
-		ErrorResponse(w, err.Error(), http.StatusConflict)
+		code = http.StatusConflict
+	case errors.Is(err, common.ErrConflict):
+		code = http.StatusConflict
 	case errors.Is(err, common.ErrInvalidInput):
-		ErrorResponse(w, err.Error(), http.StatusBadRequest)
+		code = http.StatusBadRequest
 	case errors.Is(err, common.ErrUnauthorized):
-		ErrorResponse(w, err.Error(), http.StatusUnauthorized)
+		code = http.StatusUnauthorized
 	case errors.Is(err, common.ErrForbidden):
-		ErrorResponse(w, err.Error(), http.StatusForbidden)
+		code = http.StatusForbidden
+	case errors.Is(err, common.ErrPreconditionFailed):
+		code = http.StatusPreconditionFailed
 	default:
 		ErrorResponse(w, "internal server error", http.StatusInternalServerError)
+		return
 	}
+
+	var detailed detailedError
+	if errors.As(err, &detailed) {
+		DetailedErrorResponse(w, err.Error(), detailed.Details(), code)
+		return
+	}
+
+	ErrorResponse(w, err.Error(), code)
 }
@@ -0,0 +1,132 @@
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Event — событие VEVENT. Если AllDay, время Start и End не учитывается.
+// Floating означает локальное время без привязки к зоне: клиент покажет его как есть.
+type Event struct {
+	UID          string
+	Summary      string
+	Description  string
+	Location     string
+	URL          string
+	Start        time.Time
+	End          time.Time
+	AllDay       bool
+	Floating     bool
+	LastModified time.Time
+	Sequence     int
+}
+
+// Calendar — календарь VCALENDAR для подписки.
+type Calendar struct {
+	ProdID string
+	Name   string
+	Events []Event
+}
+
+const (
+	dateLayout     = "20060102"
+	dateTimeLayout = "20060102T150405"
+	utcLayout      = "20060102T150405Z"
+
+	// Максимальная длина строки по RFC 5545 без CRLF
+	maxLineLength = 75
+)
+
+// Write сериализует календарь в формат text/calendar.
+func (c *Calendar) Write(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	now := time.Now().UTC().Format(utcLayout)
+
+	writeLine(bw, "BEGIN:VCALENDAR")
+	writeLine(bw, "VERSION:2.0")
+	writeLine(bw, "PRODID:"+c.ProdID)
+	writeLine(bw, "CALSCALE:GREGORIAN")
+	writeLine(bw, "METHOD:PUBLISH")
+	if c.Name != "" {
+		writeLine(bw, "X-WR-CALNAME:"+escapeText(c.Name))
+	}
+
+	for _, e := range c.Events {
+		writeLine(bw, "BEGIN:VEVENT")
+		writeLine(bw, "UID:"+e.UID)
+		writeLine(bw, "DTSTAMP:"+now)
+
+		if e.AllDay {
+			writeLine(bw, "DTSTART;VALUE=DATE:"+e.Start.Format(dateLayout))
+			writeLine(bw, "DTEND;VALUE=DATE:"+e.Start.AddDate(0, 0, 1).Format(dateLayout))
+		} else {
+			writeLine(bw, "DTSTART:"+formatDateTime(e.Start, e.Floating))
+			writeLine(bw, "DTEND:"+formatDateTime(e.End, e.Floating))
+		}
+
+		writeLine(bw, "SUMMARY:"+escapeText(e.Summary))
+		if e.Description != "" {
+			writeLine(bw, "DESCRIPTION:"+escapeText(e.Description))
+		}
+		if e.Location != "" {
+			writeLine(bw, "LOCATION:"+escapeText(e.Location))
+		}
+		if e.URL != "" {
+			writeLine(bw, "URL:"+e.URL)
+		}
+		if !e.LastModified.IsZero() {
+			writeLine(bw, "LAST-MODIFIED:"+e.LastModified.UTC().Format(utcLayout))
+		}
+		writeLine(bw, "SEQUENCE:"+strconv.Itoa(e.Sequence))
+		writeLine(bw, "END:VEVENT")
+	}
+
+	writeLine(bw, "END:VCALENDAR")
+
+	return bw.Flush()
+}
+
+func formatDateTime(t time.Time, floating bool) string {
+	if floating {
+		return t.Format(dateTimeLayout)
+	}
+
+	return t.UTC().Format(utcLayout)
+}
+
+// writeLine пишет строку с переносом длинных строк (line folding) по RFC 5545.
+// Строка продолжения начинается с пробела, который тоже входит в 75 октетов.
+func writeLine(w *bufio.Writer, line string) {
+	limit := maxLineLength
+
+	for len(line) > limit {
+		cut := limit
+		// Не разрываем многобайтовый символ UTF-8
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+
+		w.WriteString(line[:cut])
+		w.WriteString("\r\n ")
+		line = line[cut:]
+		limit = maxLineLength - 1
+	}
+
+	w.WriteString(line)
+	w.WriteString("\r\n")
+}
+
+func escapeText(s string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	)
+
+	return replacer.Replace(s)
+}
@@ -24,7 +24,9 @@ func CorsMiddleware(allowedFrontendDomain string) Middleware {
 			}
 
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PATCH, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, If-Match, Idempotency-Key")
+			// ETag нужен клиенту для If-Match при правке лекций и мероприятий
+			w.Header().Set("Access-Control-Expose-Headers", "ETag, Idempotent-Replayed")
 			w.Header().Set("Access-Control-Allow-Credentials", "true")
 
 			next(w, r, ps)
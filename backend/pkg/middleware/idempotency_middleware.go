@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"table-api/internal/entitys"
+	httprespond "table-api/pkg/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// IdempotencyStore хранит ответы на запросы с заголовком Idempotency-Key.
+type IdempotencyStore interface {
+	// Begin занимает ключ; если запрос уже выполнен, возвращает сохранённый ответ
+	Begin(ctx context.Context, key, fingerprint string) (*entitys.StoredResponse, error)
+	Complete(ctx context.Context, key string, response entitys.StoredResponse)
+	// Release освобождает ключ после сбоя, чтобы запрос можно было повторить
+	Release(ctx context.Context, key string)
+}
+
+type bodyRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *bodyRecorder) WriteHeader(code int) {
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *bodyRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+// IdempotencyMiddleware отдаёт повтор запроса с тем же Idempotency-Key из сохранённого
+// ответа, не выполняя его снова. Ответы 5xx не сохраняются. Ставится после AuthMiddleware:
+// ключи хранятся отдельно для каждого пользователя.
+func IdempotencyMiddleware(store IdempotencyStore) Middleware {
+	return func(next httprouter.Handle) httprouter.Handle {
+		return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+			key := r.Header.Get("Idempotency-Key")
+			if key == "" {
+				next(w, r, ps)
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				httprespond.ErrorResponse(w, "Bad request", http.StatusBadRequest)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			hash := sha256.New()
+			io.WriteString(hash, r.Method+" "+r.URL.RequestURI()+"\n")
+			hash.Write(body)
+			fingerprint := hex.EncodeToString(hash.Sum(nil))
+
+			stored, err := store.Begin(r.Context(), key, fingerprint)
+			if err != nil {
+				httprespond.HandleErrorResponse(w, err)
+				return
+			}
+
+			if stored != nil {
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("Idempotent-Replayed", "true")
+				w.WriteHeader(stored.Status)
+				w.Write(stored.Body)
+				return
+			}
+
+			rec := &bodyRecorder{ResponseWriter: w, status: http.StatusOK}
+
+			next(rec, r, ps)
+
+			// Клиент мог отключиться, не дождавшись ответа: ответ всё равно сохраняется
+			ctx := context.WithoutCancel(r.Context())
+
+			if rec.status >= http.StatusInternalServerError {
+				store.Release(ctx, key)
+				return
+			}
+
+			store.Complete(ctx, key, entitys.StoredResponse{Status: rec.status, Body: rec.body.Bytes()})
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package timezone
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+var ErrInvalidTime = errors.New("invalid time, expected RFC 3339 or 2006-01-02T15:04")
+
+var org = time.UTC
+
+// Configure задаёт часовой пояс организации. Локальная зона процесса не меняется:
+// значения из БД переводятся в пояс организации драйвером, текущее время — Now.
+func Configure(name string) error {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return err
+	}
+
+	org = loc
+	return nil
+}
+
+// Org возвращает часовой пояс организации.
+func Org() *time.Location {
+	return org
+}
+
+// Now возвращает текущее время в часовом поясе организации.
+// От него считаются "сегодня" и "завтра".
+func Now() time.Time {
+	return time.Now().In(org)
+}
+
+// ParseDate читает дату "2006-01-02" как полночь в часовом поясе организации.
+func ParseDate(s string) (time.Time, error) {
+	return time.ParseInLocation("2006-01-02", s, org)
+}
+
+// Day переносит календарный день t, как он записан у клиента,
+// на полночь в часовом поясе организации. "2025-03-10T00:00:00Z" и
+// "2025-03-10T00:00:00+03:00" дают один и тот же день.
+func Day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, org)
+}
+
+// Month возвращает границы месяца в часовом поясе организации: [start, end).
+func Month(year, month int) (time.Time, time.Time) {
+	start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, org)
+
+	return start, start.AddDate(0, 1, 0)
+}
+
+// Layouts без смещения, которые принимает Time
+var localLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+}
+
+// Time — момент времени из запроса API. Значение со смещением принимается как есть,
+// без смещения — как местное время (Floating), зона которого выбирается позже:
+// зона места проведения или организации.
+type Time struct {
+	time.Time
+	Floating bool
+}
+
+func (t *Time) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return ErrInvalidTime
+	}
+
+	s = strings.TrimSpace(s)
+
+	if parsed, err := time.Parse(time.RFC3339, s); err == nil {
+		*t = Time{Time: parsed}
+		return nil
+	}
+
+	for _, layout := range localLayouts {
+		if parsed, err := time.ParseInLocation(layout, s, org); err == nil {
+			*t = Time{Time: parsed, Floating: true}
+			return nil
+		}
+	}
+
+	return ErrInvalidTime
+}
+
+func (t Time) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Time.In(org).Format(time.RFC3339))
+}
+
+// In возвращает момент времени, читая местное время в зоне loc.
+// Значение со смещением не меняется.
+func (t Time) In(loc *time.Location) time.Time {
+	if !t.Floating || loc == nil {
+		return t.Time
+	}
+
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
@@ -0,0 +1,329 @@
+package timezone
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+// useZone задаёт пояс организации на время теста.
+func useZone(t *testing.T, name string) *time.Location {
+	t.Helper()
+
+	prev := org
+	if err := Configure(name); err != nil {
+		t.Fatalf("Configure(%q): %v", name, err)
+	}
+	t.Cleanup(func() { org = prev })
+
+	return org
+}
+
+func mustParse(t *testing.T, value string) time.Time {
+	t.Helper()
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		t.Fatalf("parse %q: %v", value, err)
+	}
+
+	return parsed
+}
+
+func TestConfigureKeepsLocal(t *testing.T) {
+	local := time.Local
+
+	useZone(t, "Europe/Berlin")
+
+	if time.Local != local {
+		t.Fatalf("Configure changed time.Local to %v", time.Local)
+	}
+	if got := Now().Location(); got != Org() {
+		t.Fatalf("Now() location = %v, want %v", got, Org())
+	}
+}
+
+func TestDay(t *testing.T) {
+	tests := []struct {
+		name  string
+		zone  string
+		input string
+		want  string
+		hours float64
+	}{
+		{
+			name:  "moscow utc midnight",
+			zone:  "Europe/Moscow",
+			input: "2025-03-30T00:00:00Z",
+			want:  "2025-03-30T00:00:00+03:00",
+			hours: 24,
+		},
+		{
+			name:  "moscow day as written by client",
+			zone:  "Europe/Moscow",
+			input: "2025-10-26T23:30:00-05:00",
+			want:  "2025-10-26T00:00:00+03:00",
+			hours: 24,
+		},
+		{
+			name:  "berlin spring forward",
+			zone:  "Europe/Berlin",
+			input: "2025-03-30T00:00:00+03:00",
+			want:  "2025-03-30T00:00:00+01:00",
+			hours: 23,
+		},
+		{
+			name:  "berlin fall back",
+			zone:  "Europe/Berlin",
+			input: "2025-10-26T00:00:00Z",
+			want:  "2025-10-26T00:00:00+02:00",
+			hours: 25,
+		},
+		{
+			name:  "berlin regular day",
+			zone:  "Europe/Berlin",
+			input: "2025-10-27T12:00:00+01:00",
+			want:  "2025-10-27T00:00:00+01:00",
+			hours: 24,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc := useZone(t, tt.zone)
+
+			day := Day(mustParse(t, tt.input))
+
+			if got := day.Format(time.RFC3339); got != tt.want {
+				t.Fatalf("Day() = %s, want %s", got, tt.want)
+			}
+			if day.Location() != loc {
+				t.Fatalf("Day() location = %v, want %v", day.Location(), loc)
+			}
+
+			// Граница следующего дня, как в FindByExactDate
+			if got := day.AddDate(0, 0, 1).Sub(day).Hours(); got != tt.hours {
+				t.Fatalf("day length = %vh, want %vh", got, tt.hours)
+			}
+		})
+	}
+}
+
+func TestMonth(t *testing.T) {
+	tests := []struct {
+		name      string
+		zone      string
+		year      int
+		month     int
+		wantStart string
+		wantEnd   string
+		hours     float64
+	}{
+		{
+			name:      "moscow october",
+			zone:      "Europe/Moscow",
+			year:      2025,
+			month:     10,
+			wantStart: "2025-10-01T00:00:00+03:00",
+			wantEnd:   "2025-11-01T00:00:00+03:00",
+			hours:     31 * 24,
+		},
+		{
+			name:      "berlin march with spring forward",
+			zone:      "Europe/Berlin",
+			year:      2025,
+			month:     3,
+			wantStart: "2025-03-01T00:00:00+01:00",
+			wantEnd:   "2025-04-01T00:00:00+02:00",
+			hours:     31*24 - 1,
+		},
+		{
+			name:      "berlin october with fall back",
+			zone:      "Europe/Berlin",
+			year:      2025,
+			month:     10,
+			wantStart: "2025-10-01T00:00:00+02:00",
+			wantEnd:   "2025-11-01T00:00:00+01:00",
+			hours:     31*24 + 1,
+		},
+		{
+			name:      "berlin december to next year",
+			zone:      "Europe/Berlin",
+			year:      2025,
+			month:     12,
+			wantStart: "2025-12-01T00:00:00+01:00",
+			wantEnd:   "2026-01-01T00:00:00+01:00",
+			hours:     31 * 24,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useZone(t, tt.zone)
+
+			start, end := Month(tt.year, tt.month)
+
+			if got := start.Format(time.RFC3339); got != tt.wantStart {
+				t.Fatalf("start = %s, want %s", got, tt.wantStart)
+			}
+			if got := end.Format(time.RFC3339); got != tt.wantEnd {
+				t.Fatalf("end = %s, want %s", got, tt.wantEnd)
+			}
+			if got := end.Sub(start).Hours(); got != tt.hours {
+				t.Fatalf("month length = %vh, want %vh", got, tt.hours)
+			}
+		})
+	}
+}
+
+func TestTimeUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		zone     string
+		input    string
+		want     string
+		floating bool
+		err      error
+	}{
+		{
+			name:  "offset kept as is",
+			zone:  "Europe/Berlin",
+			input: `"2025-03-30T10:00:00+03:00"`,
+			want:  "2025-03-30T07:00:00Z",
+		},
+		{
+			name:     "local time in moscow",
+			zone:     "Europe/Moscow",
+			input:    `"2025-03-30T10:00"`,
+			want:     "2025-03-30T07:00:00Z",
+			floating: true,
+		},
+		{
+			name:     "local time before spring forward in berlin",
+			zone:     "Europe/Berlin",
+			input:    `"2025-03-30 01:30"`,
+			want:     "2025-03-30T00:30:00Z",
+			floating: true,
+		},
+		{
+			name:     "local time after spring forward in berlin",
+			zone:     "Europe/Berlin",
+			input:    `"2025-03-30T10:00:00"`,
+			want:     "2025-03-30T08:00:00Z",
+			floating: true,
+		},
+		{
+			name:     "local time after fall back in berlin",
+			zone:     "Europe/Berlin",
+			input:    `" 2025-10-26 10:00:00 "`,
+			want:     "2025-10-26T09:00:00Z",
+			floating: true,
+		},
+		{
+			name:  "date without time",
+			zone:  "Europe/Berlin",
+			input: `"2025-03-30"`,
+			err:   ErrInvalidTime,
+		},
+		{
+			name:  "not a string",
+			zone:  "Europe/Berlin",
+			input: `1743321600`,
+			err:   ErrInvalidTime,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useZone(t, tt.zone)
+
+			var got Time
+			err := json.Unmarshal([]byte(tt.input), &got)
+
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("error = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if s := got.UTC().Format(time.RFC3339); s != tt.want {
+				t.Fatalf("time = %s, want %s", s, tt.want)
+			}
+			if got.Floating != tt.floating {
+				t.Fatalf("floating = %v, want %v", got.Floating, tt.floating)
+			}
+		})
+	}
+}
+
+func TestTimeMarshalJSON(t *testing.T) {
+	useZone(t, "Europe/Berlin")
+
+	data, err := json.Marshal(Time{Time: mustParse(t, "2025-10-26T09:00:00Z")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := `"2025-10-26T10:00:00+01:00"`; string(data) != want {
+		t.Fatalf("json = %s, want %s", data, want)
+	}
+}
+
+func TestTimeIn(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		loc   *time.Location
+		want  string
+	}{
+		{
+			name:  "floating read in berlin summer time",
+			input: `"2025-03-30T10:00"`,
+			loc:   berlin,
+			want:  "2025-03-30T10:00:00+02:00",
+		},
+		{
+			name:  "floating read in berlin winter time",
+			input: `"2025-10-26T10:00"`,
+			loc:   berlin,
+			want:  "2025-10-26T10:00:00+01:00",
+		},
+		{
+			name:  "floating without zone stays in organisation zone",
+			input: `"2025-03-30T10:00"`,
+			loc:   nil,
+			want:  "2025-03-30T10:00:00+03:00",
+		},
+		{
+			name:  "offset is not moved",
+			input: `"2025-03-30T10:00:00+03:00"`,
+			loc:   berlin,
+			want:  "2025-03-30T10:00:00+03:00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useZone(t, "Europe/Moscow")
+
+			var value Time
+			if err := json.Unmarshal([]byte(tt.input), &value); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := value.In(tt.loc).Format(time.RFC3339); got != tt.want {
+				t.Fatalf("In() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package utils
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidClock = errors.New("invalid time of day")
+
+// ParseClock переводит время суток вида "09:00", "9.30" или "09:00:00" в минуты от полуночи.
+// Секунды допускаются ради формата PostgreSQL time и отбрасываются.
+func ParseClock(s string) (int, error) {
+	s = strings.TrimSpace(s)
+
+	hoursStr, rest, ok := strings.Cut(s, ":")
+	if !ok {
+		hoursStr, rest, ok = strings.Cut(s, ".")
+	}
+	if !ok {
+		return 0, ErrInvalidClock
+	}
+
+	minutesStr, secondsStr, hasSeconds := strings.Cut(rest, ":")
+
+	hours, err := strconv.Atoi(hoursStr)
+	if err != nil || hours < 0 || hours > 23 {
+		return 0, ErrInvalidClock
+	}
+
+	minutes, err := strconv.Atoi(minutesStr)
+	if err != nil || minutes < 0 || minutes > 59 || len(minutesStr) != 2 {
+		return 0, ErrInvalidClock
+	}
+
+	if hasSeconds {
+		seconds, err := strconv.ParseFloat(secondsStr, 64)
+		if err != nil || seconds < 0 || seconds >= 60 {
+			return 0, ErrInvalidClock
+		}
+	}
+
+	return hours*60 + minutes, nil
+}
+
+// Clock — время суток в минутах от полуночи.
+// В JSON передаётся строкой "09:00", в БД хранится в колонке типа time.
+type Clock int
+
+func NewClock(hours, minutes int) Clock {
+	return Clock(hours*60 + minutes)
+}
+
+func (c Clock) Hours() int {
+	return int(c) / 60
+}
+
+func (c Clock) Minutes() int {
+	return int(c) % 60
+}
+
+func (c Clock) String() string {
+	return fmt.Sprintf("%02d:%02d", c.Hours(), c.Minutes())
+}
+
+// On возвращает момент времени c в день day (в зоне day).
+func (c Clock) On(day time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), c.Hours(), c.Minutes(), 0, 0, day.Location())
+}
+
+func (c Clock) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+func (c *Clock) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("%w: expected string HH:MM", ErrInvalidClock)
+	}
+
+	minutes, err := ParseClock(s)
+	if err != nil {
+		return fmt.Errorf("%w: %q", ErrInvalidClock, s)
+	}
+
+	*c = Clock(minutes)
+	return nil
+}
+
+func (c *Clock) Scan(src any) error {
+	var s string
+
+	switch v := src.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	case time.Time:
+		*c = NewClock(v.Hour(), v.Minute())
+		return nil
+	default:
+		return fmt.Errorf("%w: cannot scan %T", ErrInvalidClock, src)
+	}
+
+	minutes, err := ParseClock(s)
+	if err != nil {
+		return err
+	}
+
+	*c = Clock(minutes)
+	return nil
+}
+
+func (c Clock) Value() (driver.Value, error) {
+	return c.String() + ":00", nil
+}
+
+func (Clock) GormDataType() string {
+	return "time"
+}
@@ -0,0 +1,21 @@
+package utils
+
+import "strings"
+
+// Маска закрытого значения в ответах API
+const maskPrefix = "••••"
+
+// Mask оставляет видимыми последние четыре символа: "••••1234".
+func Mask(value string) string {
+	runes := []rune(value)
+	if len(runes) <= 4 {
+		return maskPrefix
+	}
+
+	return maskPrefix + string(runes[len(runes)-4:])
+}
+
+// IsMasked сообщает, что клиент вернул замаскированное значение, а не новое.
+func IsMasked(value string) bool {
+	return strings.HasPrefix(value, maskPrefix)
+}
@@ -0,0 +1,15 @@
+package utils
+
+import "strings"
+
+// NameKey приводит название к виду для сравнения: без регистра,
+// без пробелов по краям и с одиночными пробелами внутри.
+// "ИВТ-21" и " ивт-21 " дают один ключ.
+func NameKey(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+// CleanName убирает лишние пробелы, сохраняя регистр.
+func CleanName(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,291 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidRRule       = errors.New("invalid recurrence rule")
+	ErrTooManyOccurrences = errors.New("too many occurrences")
+)
+
+var weekdayCodes = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// RRule — подмножество правила повторения RFC 5545.
+// Поддерживаются FREQ=DAILY|WEEKLY, INTERVAL, COUNT, UNTIL и BYDAY.
+type RRule struct {
+	Freq     string
+	Interval int
+	Count    int
+	Until    *time.Time
+	ByDay    []time.Weekday
+}
+
+// ParseRRule разбирает строку вида "FREQ=WEEKLY;INTERVAL=2;UNTIL=20250601;BYDAY=MO,WE".
+// Префикс "RRULE:" допускается.
+func ParseRRule(s string) (*RRule, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	if s == "" {
+		return nil, ErrInvalidRRule
+	}
+
+	rule := &RRule{Interval: 1}
+
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidRRule, part)
+		}
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			freq := strings.ToUpper(value)
+			if freq != "DAILY" && freq != "WEEKLY" {
+				return nil, fmt.Errorf("%w: unsupported FREQ %s", ErrInvalidRRule, value)
+			}
+			rule.Freq = freq
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("%w: bad INTERVAL %s", ErrInvalidRRule, value)
+			}
+			rule.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("%w: bad COUNT %s", ErrInvalidRRule, value)
+			}
+			rule.Count = n
+		case "UNTIL":
+			until, err := parseRRuleDate(value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: bad UNTIL %s", ErrInvalidRRule, value)
+			}
+			rule.Until = &until
+		case "BYDAY":
+			for _, code := range strings.Split(value, ",") {
+				day, ok := weekdayCodes[strings.ToUpper(code)]
+				if !ok {
+					return nil, fmt.Errorf("%w: bad BYDAY %s", ErrInvalidRRule, code)
+				}
+				rule.ByDay = append(rule.ByDay, day)
+			}
+		case "WKST":
+			// Неделя всегда начинается с понедельника
+		default:
+			return nil, fmt.Errorf("%w: unsupported part %s", ErrInvalidRRule, key)
+		}
+	}
+
+	if rule.Freq == "" {
+		return nil, fmt.Errorf("%w: FREQ is required", ErrInvalidRRule)
+	}
+
+	if rule.Count > 0 && rule.Until != nil {
+		return nil, fmt.Errorf("%w: COUNT and UNTIL are mutually exclusive", ErrInvalidRRule)
+	}
+
+	if rule.Count == 0 && rule.Until == nil {
+		return nil, fmt.Errorf("%w: COUNT or UNTIL is required", ErrInvalidRRule)
+	}
+
+	sort.Slice(rule.ByDay, func(i, j int) bool {
+		return mondayOffset(rule.ByDay[i]) < mondayOffset(rule.ByDay[j])
+	})
+
+	return rule, nil
+}
+
+// String возвращает правило в формате RFC 5545.
+func (r *RRule) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+	}
+
+	if len(r.ByDay) > 0 {
+		codes := make([]string, 0, len(r.ByDay))
+		for _, day := range r.ByDay {
+			for code, d := range weekdayCodes {
+				if d == day {
+					codes = append(codes, code)
+				}
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// Expand возвращает даты повторений начиная с dtstart.
+// Даты из exdates исключаются, но учитываются в COUNT, как того требует RFC 5545.
+// Если повторений больше limit, возвращается ErrTooManyOccurrences.
+func (r *RRule) Expand(dtstart time.Time, exdates []time.Time, limit int) ([]time.Time, error) {
+	start := truncateDay(dtstart)
+
+	var until *time.Time
+	if r.Until != nil {
+		u := time.Date(r.Until.Year(), r.Until.Month(), r.Until.Day(), 0, 0, 0, 0, start.Location())
+		until = &u
+	}
+
+	excluded := make(map[string]struct{}, len(exdates))
+	for _, d := range exdates {
+		excluded[d.Format("2006-01-02")] = struct{}{}
+	}
+
+	byDay := r.ByDay
+	if len(byDay) == 0 {
+		byDay = []time.Weekday{start.Weekday()}
+	}
+
+	var (
+		result    []time.Time
+		generated int
+	)
+
+	emit := func(d time.Time) (bool, error) {
+		if until != nil && d.After(*until) {
+			return false, nil
+		}
+
+		generated++
+		if _, skip := excluded[d.Format("2006-01-02")]; !skip {
+			result = append(result, d)
+			if len(result) > limit {
+				return false, ErrTooManyOccurrences
+			}
+		}
+
+		return r.Count == 0 || generated < r.Count, nil
+	}
+
+	switch r.Freq {
+	case "DAILY":
+		// Ограничиваем число шагов, чтобы BYDAY без совпадений не зациклил расчёт
+		for d, steps := start, 0; steps <= 7*limit; d, steps = d.AddDate(0, 0, r.Interval), steps+1 {
+			if len(r.ByDay) > 0 && !containsWeekday(r.ByDay, d.Weekday()) {
+				if until != nil && d.After(*until) {
+					return result, nil
+				}
+				continue
+			}
+
+			next, err := emit(d)
+			if err != nil {
+				return nil, err
+			}
+			if !next {
+				return result, nil
+			}
+		}
+	case "WEEKLY":
+		weekStart := start.AddDate(0, 0, -mondayOffset(start.Weekday()))
+
+		for ; ; weekStart = weekStart.AddDate(0, 0, 7*r.Interval) {
+			for _, day := range byDay {
+				d := weekStart.AddDate(0, 0, mondayOffset(day))
+				if d.Before(start) {
+					continue
+				}
+
+				next, err := emit(d)
+				if err != nil {
+					return nil, err
+				}
+				if !next {
+					return result, nil
+				}
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// CountBefore возвращает число повторений (включая исключённые даты) строго до date.
+func (r *RRule) CountBefore(dtstart, date time.Time, limit int) (int, error) {
+	all, err := r.Expand(dtstart, nil, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	day := truncateDay(date)
+	n := 0
+	for _, d := range all {
+		if d.Before(day) {
+			n++
+		}
+	}
+
+	return n, nil
+}
+
+// FirstFrom возвращает первое повторение (включая исключённые даты) не раньше date.
+// Если таких повторений нет, возвращает false.
+func (r *RRule) FirstFrom(dtstart, date time.Time, limit int) (time.Time, bool, error) {
+	all, err := r.Expand(dtstart, nil, limit)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	day := truncateDay(date)
+	for _, d := range all {
+		if !d.Before(day) {
+			return d, true, nil
+		}
+	}
+
+	return time.Time{}, false, nil
+}
+
+func parseRRuleDate(s string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405", "20060102"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return truncateDay(t), nil
+		}
+	}
+
+	return time.Time{}, ErrInvalidRRule
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func mondayOffset(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
+
+func containsWeekday(days []time.Weekday, day time.Weekday) bool {
+	for _, d := range days {
+		if d == day {
+			return true
+		}
+	}
+
+	return false
+}
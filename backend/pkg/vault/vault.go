@@ -0,0 +1,196 @@
+package vault
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"table-api/pkg/utils"
+)
+
+// Зашифрованные значения хранятся с префиксом версии, чтобы отличать их
+// от старых открытых ключей и иметь возможность сменить схему.
+const encryptedPrefix = "enc:v1:"
+
+var (
+	ErrNotConfigured = errors.New("stream key vault is not configured")
+	ErrDecrypt       = errors.New("failed to decrypt stream key")
+)
+
+var aead cipher.AEAD
+
+// Configure задаёт секрет шифрования. Секрет любой длины
+// приводится к ключу AES-256 через SHA-256.
+func Configure(secret string) error {
+	if secret == "" {
+		return ErrNotConfigured
+	}
+
+	key := sha256.Sum256([]byte(secret))
+
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return err
+	}
+
+	aead = gcm
+	return nil
+}
+
+// Encrypt шифрует значение AES-GCM со случайным nonce.
+// Уже зашифрованное значение возвращается без изменений.
+func Encrypt(plain string) (string, error) {
+	if IsEncrypted(plain) {
+		return plain, nil
+	}
+
+	if aead == nil {
+		return "", ErrNotConfigured
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := aead.Seal(nonce, nonce, []byte(plain), nil)
+
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt расшифровывает значение. Значения без префикса считаются
+// открытыми ключами, записанными до появления шифрования.
+func Decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+
+	if aead == nil {
+		return "", ErrNotConfigured
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, encryptedPrefix))
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", ErrDecrypt
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+
+	plain, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", ErrDecrypt
+	}
+
+	return string(plain), nil
+}
+
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, encryptedPrefix)
+}
+
+// Secret — ключ трансляции. В памяти хранится открытым,
+// в БД пишется зашифрованным, в JSON всегда уходит маской.
+type Secret string
+
+func (s Secret) Mask() string {
+	return utils.Mask(string(s))
+}
+
+// Fingerprint — SHA-256 ключа для сравнения в памяти: маски разных ключей
+// совпадают, если совпадают последние символы. Отпечаток не сохраняется.
+func (s Secret) Fingerprint() string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// Value шифрует ключ при записи; пустая строка остаётся пустой,
+// чтобы фильтр лекций без ключа работал без расшифровки.
+func (s Secret) Value() (driver.Value, error) {
+	if s == "" {
+		return "", nil
+	}
+
+	return Encrypt(string(s))
+}
+
+func (s *Secret) Scan(src any) error {
+	var value string
+
+	switch v := src.(type) {
+	case string:
+		value = v
+	case []byte:
+		value = string(v)
+	default:
+		return fmt.Errorf("unsupported stream key type %T", src)
+	}
+
+	plain, err := Decrypt(value)
+	if err != nil {
+		return err
+	}
+
+	*s = Secret(plain)
+	return nil
+}
+
+// MarshalJSON маскирует ключ, даже если модель сериализуется напрямую.
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Mask())
+}
+
+// New оборачивает ключ из запроса. Замаскированное значение
+// не считается новым ключом.
+func New(value *string) *Secret {
+	if value == nil || utils.IsMasked(*value) {
+		return nil
+	}
+
+	s := Secret(*value)
+	return &s
+}
+
+// Plain возвращает ключ в открытом виде.
+func Plain(s *Secret) *string {
+	if s == nil {
+		return nil
+	}
+
+	value := string(*s)
+	return &value
+}
+
+// Masked возвращает маску ключа для ответа.
+func Masked(s *Secret) *string {
+	if s == nil || *s == "" {
+		return Plain(s)
+	}
+
+	value := s.Mask()
+	return &value
+}
+
+// Generate выпускает новый случайный ключ трансляции вида xxxx-xxxx-xxxx-xxxx.
+func Generate() (string, error) {
+	buf := make([]byte, 10)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	raw := strings.ToLower(base32.StdEncoding.EncodeToString(buf))
+
+	return raw[0:4] + "-" + raw[4:8] + "-" + raw[8:12] + "-" + raw[12:16], nil
+}
@@ -87,3 +87,9 @@ function clearTokensAndRedirect() {
 }
 
 export default api;
+
+/** Текущая запись из ответа 412: правку отклонили, потому что запись уже изменили. */
+export function staleCurrent<T>(error: unknown): T | null {
+  if (!axios.isAxiosError(error) || error.response?.status !== 412) return null;
+  return (error.response.data?.details as T | undefined) ?? null;
+}
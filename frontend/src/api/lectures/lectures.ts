@@ -4,7 +4,12 @@ import type {
   LectureUpdateLinksRequest,
   LectureUpdateRequest,
 } from "../../types/request/lecture";
-import type { LectureResponse, LecturesResponse } from "../../types/response/lecture";
+import type {
+  CreateLecturesResponse,
+  LectureResponse,
+  LecturesResponse,
+  LinkOperationResponse,
+} from "../../types/response/lecture";
 import api from "../api";
 
 export const getLecturesByDate = async (date: string): Promise<LecturesResponse> => {
@@ -17,25 +22,50 @@ export const createLecture = async (body: LectureCreateRequest): Promise<Lecture
   return data;
 };
 
+// idempotencyKey — ключ попытки: повтор с тем же ключом вернёт первый ответ, не создавая лекции снова
 export const createManyLectures = async (
-  body: LectureCreateAdvancedRequest
+  body: LectureCreateAdvancedRequest,
+  idempotencyKey?: string
 ): Promise<LecturesResponse> => {
-  const { data } = await api.post<LecturesResponse>("/lectures/advanced", body);
+  const headers = idempotencyKey ? { "Idempotency-Key": idempotencyKey } : undefined;
+  const { data } = await api.post<LecturesResponse>("/lectures/advanced", body, { headers });
+  return data;
+};
+
+// Пакет создаётся построчно: корректные лекции сохраняются, ошибки остальных приходят в results
+export const createManyLecturesPartial = async (
+  body: LectureCreateAdvancedRequest,
+  idempotencyKey?: string
+): Promise<CreateLecturesResponse> => {
+  const headers = idempotencyKey ? { "Idempotency-Key": idempotencyKey } : undefined;
+  const { data } = await api.post<CreateLecturesResponse>("/lectures/advanced", body, {
+    headers,
+    params: { partial: true },
+  });
   return data;
 };
 
+// version — версия из ответа; если лекцию успели изменить, сервер ответит 412
 export const updateLecture = async (
   id: number,
-  body: LectureUpdateRequest
+  body: LectureUpdateRequest,
+  version?: number
 ): Promise<LectureResponse> => {
-  const { data } = await api.patch<LectureResponse>(`/lectures/${id}`, body);
+  const headers = version !== undefined ? { "If-Match": `"${version}"` } : undefined;
+  const { data } = await api.patch<LectureResponse>(`/lectures/${id}`, body, { headers });
   return data;
 };
 
 export const updateLecturesLink = async (
   body: LectureUpdateLinksRequest
-): Promise<LecturesResponse> => {
-  const { data } = await api.post<LecturesResponse>("/lectures/links", body);
+): Promise<LinkOperationResponse> => {
+  const { data } = await api.post<LinkOperationResponse>("/lectures/links", body);
+  return data;
+};
+
+// operationId — из ответа updateLecturesLink: отменяется именно эта операция
+export const undoLecturesLink = async (operationId: number): Promise<LinkOperationResponse> => {
+  const { data } = await api.post<LinkOperationResponse>("/lectures/links/undo", { operationId });
   return data;
 };
 
@@ -11,11 +11,14 @@ export const createMeet = async (body: MeetCreateRequest): Promise<MeetResponse>
   return data;
 };
 
+// version — версия из ответа; если мероприятие успели изменить, сервер ответит 412
 export const updateMeet = async (
   id: number,
-  body: MeetUpdateRequest
+  body: MeetUpdateRequest,
+  version?: number
 ): Promise<MeetResponse> => {
-  const { data } = await api.patch<MeetResponse>(`/meets/${id}`, body);
+  const headers = version !== undefined ? { "If-Match": `"${version}"` } : undefined;
+  const { data } = await api.patch<MeetResponse>(`/meets/${id}`, body, { headers });
   return data;
 };
 
@@ -14,7 +14,7 @@ import {
 } from "../api/lectures/lectures";
 import type { LectureResponse } from "../types/response/lecture";
 import type { LectureUpdateRequest } from "../types/request/lecture";
-import { baseURL } from "../api/api.ts";
+import { baseURL, staleCurrent } from "../api/api.ts";
 
 interface Lecture {
   id: number;
@@ -34,6 +34,7 @@ interface Lecture {
   customTime: string;
   createdAt: string;
   updatedAt: string;
+  version: number;
 }
 
 const STORAGE_KEY = "lectures_visible_columns";
@@ -82,7 +83,10 @@ function tableFieldToApiField(
   };
   return (
     map[field] ??
-    (field === "id" || field === "createdAt" || field === "updatedAt"
+    (field === "id" ||
+    field === "createdAt" ||
+    field === "updatedAt" ||
+    field === "version"
       ? null
       : (field as keyof LectureUpdateRequest))
   );
@@ -107,6 +111,7 @@ function mapLectureResponseToLecture(r: LectureResponse): Lecture {
     customTime: r.abnormalTime ?? "",
     createdAt: r.createdAt ?? "",
     updatedAt: r.updatedAt ?? "",
+    version: r.version,
   };
 }
 
@@ -170,30 +175,43 @@ export default function Lectures() {
   };
 
   const handleCellSave = (
-    lectureId: number,
+    row: Lecture,
     field: keyof Lecture,
     value: string,
   ) => {
     const apiField = tableFieldToApiField(field);
     if (apiField == null) return;
     const body: LectureUpdateRequest = { [apiField]: value };
-    updateLecture(lectureId, body)
+    updateLecture(row.id, body, row.version)
       .then((updated) => {
         setLectures((prev) =>
           prev.map((lecture) =>
-            lecture.id === lectureId
+            lecture.id === row.id
               ? {
                   ...lecture,
                   [field]: value,
                   updatedAt:
                     updated.updatedAt ?? new Date().toLocaleString("ru-RU"),
+                  version: updated.version,
                 }
               : lecture,
           ),
         );
       })
-      .catch(() => {
-        // Можно показать уведомление об ошибке
+      .catch((error) => {
+        // Лекцию успели изменить: показываем её текущее состояние вместо своей правки
+        const current = staleCurrent<LectureResponse>(error);
+        if (current == null) return;
+        setLectures((prev) =>
+          prev.map((lecture) =>
+            lecture.id === row.id
+              ? mapLectureResponseToLecture(current)
+              : lecture,
+          ),
+        );
+        alert(
+          "Лекцию уже изменили. Показаны актуальные данные, повторите правку.",
+        );
       });
   };
 
@@ -369,7 +387,7 @@ export default function Lectures() {
                           value={value || ""}
                           onSave={(newValue) =>
                             handleCellSave(
-                              lecture.id,
+                              lecture,
                               column.key as keyof Lecture,
                               newValue,
                             )
@@ -4,6 +4,7 @@ import EditableSelectCell from "../components/EditableSelectCell";
 import ColumnSettingsModal from "../components/ColumnSettingsModal";
 import MeetsExportModal from "../components/MeetsExportModal";
 import { getMeets, updateMeet } from "../api/meets/meets";
+import { staleCurrent } from "../api/api";
 import type { MeetResponse } from "../types/response/meet";
 import type { MeetUpdateRequest } from "../types/request/meets";
 import type { Pagination } from "../types/response/pagination";
@@ -30,6 +31,7 @@ interface Meet {
   admin: string;
   createdAt: string;
   updatedAt: string;
+  version: number;
 }
 
 const STORAGE_KEY = "meets_visible_columns";
@@ -132,7 +134,13 @@ function tableFieldToApiField(field: keyof Meet): keyof MeetUpdateRequest | null
     shortUrl: "shortUrl",
     admin: "admin",
   };
-  if (field === "id" || field === "createdAt" || field === "updatedAt") return null;
+  if (
+    field === "id" ||
+    field === "createdAt" ||
+    field === "updatedAt" ||
+    field === "version"
+  )
+    return null;
   return map[field] ?? (field as keyof MeetUpdateRequest);
 }
 
@@ -155,6 +163,7 @@ function mapMeetResponseToMeet(r: MeetResponse): Meet {
     admin: r.admin ?? "",
     createdAt: r.CreatedAt ?? "",
     updatedAt: r.UpdatedAt ?? "",
+    version: r.version,
   };
 }
 
@@ -238,28 +247,33 @@ export default function Meets() {
     // Здесь будет логика экспорта
   };
 
-  const handleCellSave = (meetId: number, field: keyof Meet, value: string) => {
+  const handleCellSave = (row: Meet, field: keyof Meet, value: string) => {
     const apiField = tableFieldToApiField(field);
     if (apiField == null) return;
     const sendValue =
       apiField === "start" || apiField === "end" ? formatStartEndForApi(value) : value;
     const body: MeetUpdateRequest = { [apiField]: sendValue };
-    updateMeet(meetId, body)
+    updateMeet(row.id, body, row.version)
       .then((updated) => {
         setMeets((prev) =>
           prev.map((m) =>
-            m.id === meetId
+            m.id === row.id
               ? {
                   ...m,
                   [field]: value,
                   updatedAt: updated.UpdatedAt ?? new Date().toLocaleString("ru-RU"),
+                  version: updated.version,
                 }
               : m
           )
         );
       })
-      .catch(() => {
-        // Можно показать уведомление об ошибке
+      .catch((error) => {
+        // Мероприятие успели изменить: показываем его текущее состояние вместо своей правки
+        const current = staleCurrent<MeetResponse>(error);
+        if (current == null) return;
+        setMeets((prev) => prev.map((m) => (m.id === row.id ? mapMeetResponseToMeet(current) : m)));
+        alert("Мероприятие уже изменили. Показаны актуальные данные, повторите правку.");
       });
   };
 
@@ -421,7 +435,7 @@ export default function Meets() {
                         <EditableSelectCell
                           key={column.key}
                           value={value || ""}
-                          onSave={(v) => handleCellSave(meet.id, "status", v)}
+                          onSave={(v) => handleCellSave(meet, "status", v)}
                           options={MEET_STATUS_ROW_OPTIONS}
                         />
                       );
@@ -434,7 +448,7 @@ export default function Meets() {
                           value={value || ""}
                           onSave={(v) => {
                             const digits = v.replace(/\D/g, "").slice(0, 11);
-                            handleCellSave(meet.id, "phone", digits);
+                            handleCellSave(meet, "phone", digits);
                           }}
                           maxLength={11}
                           disabled={isDisabled}
@@ -471,7 +485,7 @@ export default function Meets() {
                       <EditableCell
                         key={column.key}
                         value={value || ""}
-                        onSave={(v) => handleCellSave(meet.id, column.key as keyof Meet, v)}
+                        onSave={(v) => handleCellSave(meet, column.key as keyof Meet, v)}
                         maxLength={maxLength}
                         type={type}
                         disabled={isDisabled}
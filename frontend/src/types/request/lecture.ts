@@ -40,4 +40,9 @@ export interface LectureCreateAdvancedRequest {
 export interface LectureUpdateLinksRequest {
   groupName: string;
   url: string;
+  dateFrom?: string;
+  dateTo?: string;
+  lector?: string;
+  platform?: string;
+  skipLinked?: boolean;
 }
@@ -15,8 +15,57 @@ export interface LectureResponse {
   start?: string;
   end?: string;
   abnormalTime?: string;
+  version: number;
   createdAt?: string;
   updatedAt?: string;
 }
 
 export type LecturesResponse = LectureResponse[];
+
+export interface LinkValues {
+  url?: string;
+  shortUrl?: string;
+}
+
+export interface LinkDiffRow {
+  lectureId: number;
+  date: string;
+  group?: string;
+  lector?: string;
+  platform?: string;
+  before: LinkValues;
+  after: LinkValues;
+}
+
+export interface LinkOperationResponse {
+  operationId?: number;
+  dryRun: boolean;
+  count: number;
+  skipped: number;
+  changes: LinkDiffRow[];
+}
+
+export interface LectureConflict {
+  index?: number;
+  lectureId?: number;
+  date: string;
+  dimension: string;
+  value: string;
+  conflictingIds: number[];
+  conflictingIndexes?: number[];
+}
+
+export interface CreateLectureResult {
+  index: number;
+  status: "created" | "failed";
+  lecture?: LectureResponse;
+  errors?: string[];
+  conflicts?: LectureConflict[];
+}
+
+export interface CreateLecturesResponse {
+  total: number;
+  created: number;
+  failed: number;
+  results: CreateLectureResult[];
+}
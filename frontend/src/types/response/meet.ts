@@ -15,6 +15,7 @@ export interface MeetResponse {
   status: string;
   description?: string | null; // может быть null или отсутствовать
   admin?: string | null; // может быть null или отсутствовать
+  version: number; // передаётся в If-Match при правке
 
   start: string; // ISO дата-время
   end: string; // ISO дата-время
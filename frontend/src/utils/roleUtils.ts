@@ -3,15 +3,17 @@ export const ROLE_API = {
   ADMIN: "admin",
   MODERATOR: "moderator",
   VIEWER: "viewer",
+  TECHNICIAN: "technician",
 } as const;
 
 export type RoleApi = (typeof ROLE_API)[keyof typeof ROLE_API];
 
-/** Подписи для отображения: Админ, Модер, Зритель */
+/** Подписи для отображения: Админ, Модер, Зритель, Техник */
 const API_TO_LABEL: Record<string, string> = {
   [ROLE_API.ADMIN]: "Админ",
   [ROLE_API.MODERATOR]: "Модер",
   [ROLE_API.VIEWER]: "Зритель",
+  [ROLE_API.TECHNICIAN]: "Техник",
 };
 
 /** Варианты для селекта роли в таблице и модалке создания */
@@ -19,6 +21,7 @@ export const ROLE_OPTIONS: { value: string; label: string }[] = [
   { value: ROLE_API.ADMIN, label: "Админ" },
   { value: ROLE_API.MODERATOR, label: "Модер" },
   { value: ROLE_API.VIEWER, label: "Зритель" },
+  { value: ROLE_API.TECHNICIAN, label: "Техник" },
 ];
 
 /**
@@ -36,6 +39,7 @@ export function roleLabelToApi(labelOrApi: string): string {
     Админ: ROLE_API.ADMIN,
     Модер: ROLE_API.MODERATOR,
     Зритель: ROLE_API.VIEWER,
+    Техник: ROLE_API.TECHNICIAN,
   };
   return reverse[labelOrApi] ?? labelOrApi;
 }